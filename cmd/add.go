@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	addAll      bool
+	addUpdate   bool
+	addPatterns []string
+	addNoTUI    bool
+	addDryRun   bool
+	addStayOpen bool
+)
+
+// runAdd opens the staging UI, or stages the matching files directly when a scripting flag is set
+func runAdd(cmd *cobra.Command, args []string) {
+	spec, err := git.ParseCwdPathspec(args)
+	exitOnError(err)
+
+	if !addAll && !addUpdate && len(addPatterns) == 0 && !addNoTUI && !addDryRun {
+		exitOnError(ui.StartAddTUI(spec, addStayOpen))
+		return
+	}
+
+	service := mustGitService()
+	files, err := service.Status(cmd.Context())
+	exitOnError(err)
+
+	selected, unmerged := selectAddFiles(files, spec, addPatterns, addUpdate)
+	for _, f := range unmerged {
+		fmt.Fprintf(os.Stderr, "skipping unmerged path '%s'; resolve it with 'go-git-tui resolve'\n", f.Path)
+	}
+
+	out := cmd.OutOrStdout()
+	if len(selected) == 0 {
+		fmt.Fprintln(out, "No matching changes to stage")
+		return
+	}
+
+	if addDryRun || verbosity > 0 {
+		for _, f := range selected {
+			action := "add"
+			if f.Status[1] == 'D' {
+				action = "remove"
+			}
+			fmt.Fprintf(out, "%s '%s'\n", action, f.Path)
+		}
+	}
+	if addDryRun {
+		return
+	}
+
+	paths := make([]string, 0, len(selected))
+	for _, f := range selected {
+		paths = append(paths, f.Path)
+	}
+	exitOnError(service.Stage(cmd.Context(), paths))
+}
+
+// selectAddFiles returns the files with unstaged changes that match the pathspec and every
+// pattern, sorted by path. Unmerged files are returned separately to be skipped: staging
+// them would not resolve the conflict, 'go-git-tui resolve' does.
+func selectAddFiles(files []git.GitFile, spec *git.Pathspec, patterns []string, trackedOnly bool) ([]git.GitFile, []git.GitFile) {
+	var selected, unmerged []git.GitFile
+	for _, f := range files {
+		if len(f.Status) < 2 || f.Status[1] == ' ' {
+			continue // Nothing left to stage
+		}
+		if trackedOnly && f.Status == "??" {
+			continue
+		}
+		if !spec.Match(f.Path) || !matchesAll(patterns, f.Path) {
+			continue
+		}
+
+		if git.IsConflicted(f.Status) {
+			unmerged = append(unmerged, f)
+		} else {
+			selected = append(selected, f)
+		}
+	}
+
+	sort.Slice(selected, func(i, j int) bool { return selected[i].Path < selected[j].Path })
+	return selected, unmerged
+}
+
+// matchesAll reports whether path matches every --pattern glob
+func matchesAll(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		if !git.MatchPattern(pattern, path) {
+			return false
+		}
+	}
+	return true
+}
+
+func init() {
+	addCmd.Flags().BoolVarP(&addAll, "all", "A", false, "Stage all changes, including untracked files, without opening the UI")
+	addCmd.Flags().BoolVarP(&addUpdate, "update", "u", false, "Stage changes to tracked files only, without opening the UI")
+	addCmd.Flags().StringArrayVarP(&addPatterns, "pattern", "p", nil, "Only stage files matching a glob such as '*.go' or 'internal/**/*.go'; repeatable")
+	addCmd.Flags().BoolVar(&addNoTUI, "no-tui", false, "Stage the matching files without opening the UI")
+	addCmd.Flags().BoolVarP(&addDryRun, "dry-run", "n", false, "Print the files that would be staged without staging them")
+	addCmd.Flags().BoolVar(&addStayOpen, "stay-open", true, "Keep the UI open after staging to keep building up the index; --stay-open=false exits after staging once")
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+)
+
+func TestSelectAddFiles(t *testing.T) {
+	files := []git.GitFile{
+		{Status: "??", Path: "new.go"},
+		{Status: " M", Path: "internal/a.go"},
+		{Status: "M ", Path: "internal/staged.go"},
+		{Status: " D", Path: "internal/gone_test.go"},
+		{Status: "UU", Path: "internal/conflict.go"},
+		{Status: " M", Path: "README.md"},
+	}
+
+	tests := []struct {
+		name         string
+		specs        []string
+		patterns     []string
+		trackedOnly  bool
+		want         []string
+		wantUnmerged []string
+	}{
+		{
+			name:         "GIVEN no filters THEN every unstaged change is selected",
+			want:         []string{"README.md", "internal/a.go", "internal/gone_test.go", "new.go"},
+			wantUnmerged: []string{"internal/conflict.go"},
+		},
+		{
+			name:         "GIVEN update THEN untracked files are skipped",
+			patterns:     []string{"*.go"},
+			trackedOnly:  true,
+			want:         []string{"internal/a.go", "internal/gone_test.go"},
+			wantUnmerged: []string{"internal/conflict.go"},
+		},
+		{
+			name:         "GIVEN a pathspec with an exclude THEN only matching files are selected",
+			specs:        []string{"internal", ":!*_test.go"},
+			want:         []string{"internal/a.go"},
+			wantUnmerged: []string{"internal/conflict.go"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			spec, err := git.ParsePathspec("", tc.specs)
+			require.NoError(t, err)
+
+			selected, unmerged := selectAddFiles(files, spec, tc.patterns, tc.trackedOnly)
+
+			paths := func(files []git.GitFile) []string {
+				var out []string
+				for _, f := range files {
+					out = append(out, f.Path)
+				}
+				return out
+			}
+			assert.Equal(t, tc.want, paths(selected))
+			assert.Equal(t, tc.wantUnmerged, paths(unmerged))
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var autosquashCmd = &cobra.Command{
+	Use:   "autosquash",
+	Short: "Fold fixup! and amend! commits into their targets before pushing",
+	Long: `Rebase the commits that are not on upstream yet so that every fixup!, amend!
+and squash! commit is folded into the commit it targets.
+
+Create fixup commits with 'go-git-tui commit' and press f to pick the target.
+If the rebase stops on a conflict, run 'go-git-tui rebase' to resolve and continue.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		state, err := mustGitService().Autosquash(cmd.Context())
+		exitOnError(err)
+
+		if state.InProgress() {
+			exitOnError(fmt.Errorf("autosquash stopped (%s); run 'go-git-tui rebase' to continue", state.Summary()))
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), "Fixup commits were squashed into their targets")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(autosquashCmd)
+}
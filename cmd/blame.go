@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui"
+
+	"github.com/spf13/cobra"
+)
+
+var blameCmd = &cobra.Command{
+	Use:   "blame <path>",
+	Short: "Show who last changed each line of a file",
+	Long: `Show the commit, author and date that last changed each line of a file.
+
+User Manual:
+  - Use w/s to move between lines
+  - ENTER shows the diff of the commit that changed the line
+  - p blames the file at that commit's parent to dig past refactors, b goes back`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path, err := git.RelativePath(args[0])
+		exitOnError(err)
+		exitOnError(ui.StartBlameTUI(path))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(blameCmd)
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/LaansDole/go-git-tui/internal/changelog"
+	"github.com/LaansDole/go-git-tui/internal/git"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	changelogFrom   string
+	changelogTo     string
+	changelogName   string
+	changelogFormat string
+	changelogTitles map[string]string
+	changelogWrite  bool
+	changelogFile   string
+
+	changelogCmd = &cobra.Command{
+		Use:   "changelog",
+		Short: "Generate a changelog from conventional commits",
+		Long: `Walk the commits between two revisions, group the conventional commits by type into
+sections (Features, Bug Fixes, Documentation, ...) and render them.
+
+By default the range starts at the last release tag before --to and the result is
+printed to stdout. With --write the release is prepended to CHANGELOG.md instead.
+
+Example: go-git-tui changelog --to v1.2.0 --title chore= --title docs="Docs" --write`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			formatter, err := changelog.Lookup(changelogFormat)
+			exitOnError(err)
+			if changelogWrite && changelogFormat != "markdown" {
+				exitOnError(fmt.Errorf("--write only supports the markdown format"))
+			}
+
+			service := mustGitService()
+			from := changelogFrom
+			if from == "" {
+				previous, err := service.PreviousRelease(cmd.Context(), changelogTo)
+				exitOnError(err)
+				if previous != nil {
+					from = previous.Name
+				}
+			}
+
+			commits, err := service.ReleaseCommits(cmd.Context(), from, changelogTo)
+			exitOnError(err)
+
+			log := changelog.Build(commits, changelog.Config{Titles: changelogTitles})
+			log.From, log.To = from, changelogTo
+			log.Version, log.Date = releaseHeading(changelogName, changelogTo, commits)
+
+			var buf bytes.Buffer
+			exitOnError(formatter.Format(&buf, log))
+
+			if !changelogWrite {
+				_, err = cmd.OutOrStdout().Write(buf.Bytes())
+				exitOnError(err)
+				return
+			}
+
+			if log.Empty() {
+				fmt.Fprintln(cmd.OutOrStdout(), "No conventional commits in range; nothing written")
+				return
+			}
+			exitOnError(changelog.Prepend(changelogFile, buf.Bytes()))
+			fmt.Fprintf(cmd.OutOrStdout(), "Prepended %s to %s\n", log.Version, changelogFile)
+		},
+	}
+)
+
+// releaseHeading names a changelog after --name, or the version tag it ends at. Other
+// ranges are unreleased changes dated today.
+func releaseHeading(name, to string, commits []git.CommitInfo) (string, time.Time) {
+	date := time.Now()
+	if _, ok := git.ParseVersion(to); ok && len(commits) > 0 {
+		date = commits[len(commits)-1].When
+		if name == "" {
+			name = to
+		}
+	}
+	if name == "" {
+		name = "Unreleased"
+	}
+	return name, date
+}
+
+func init() {
+	changelogCmd.Flags().StringVar(&changelogFrom, "from", "", "Start of the range, exclusive (default: the previous release tag)")
+	changelogCmd.Flags().StringVar(&changelogTo, "to", "HEAD", "End of the range, inclusive")
+	changelogCmd.Flags().StringVar(&changelogName, "name", "", "Release heading (default: --to when it is a version tag, else \"Unreleased\")")
+	changelogCmd.Flags().StringVarP(&changelogFormat, "format", "f", "markdown", "Output format: markdown or json")
+	changelogCmd.Flags().StringToStringVar(&changelogTitles, "title", nil, "Section title for a commit type, e.g. --title feat=\"New Features\"; an empty title hides the type")
+	changelogCmd.Flags().BoolVarP(&changelogWrite, "write", "w", false, "Prepend the release to the changelog file instead of printing it")
+	changelogCmd.Flags().StringVar(&changelogFile, "file", "CHANGELOG.md", "Changelog file used with --write")
+	rootCmd.AddCommand(changelogCmd)
+}
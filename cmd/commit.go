@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui"
+
+	"github.com/spf13/cobra"
+)
+
+var commitFlags git.CommitMessage
+
+// runCommit commits directly when the type and message are given, and otherwise opens
+// the commit TUI pre-filled with the fields that were given
+func runCommit(cmd *cobra.Command, args []string) {
+	msg := commitFlags
+
+	if msg.Type == "" || msg.Description == "" {
+		if msg.Type != "" && !git.IsCommitType(msg.Type) {
+			exitOnError(msg.Validate())
+		}
+		exitOnError(ui.StartCommitTUI(msg))
+		return
+	}
+
+	exitOnError(msg.Validate())
+
+	service := mustGitService()
+	state, err := service.State(cmd.Context())
+	exitOnError(err)
+	if state.InProgress() {
+		exitOnError(fmt.Errorf("%w; run 'go-git-tui commit' without flags to conclude it",
+			&git.InProgressError{Action: "commit", Operation: state.Operation}))
+	}
+
+	files, err := service.Status(cmd.Context())
+	exitOnError(err)
+	if !git.HasStagedChanges(files) {
+		exitOnError(git.ErrNothingStaged)
+	}
+
+	exitOnError(service.Commit(cmd.Context(), msg.Prefix(), msg.Text()))
+	fmt.Fprintf(cmd.OutOrStdout(), "Committed %s: %s\n", msg.Prefix(), msg.Description)
+}
+
+func init() {
+	commitCmd.Flags().StringVarP(&commitFlags.Type, "type", "t", "", "Commit type, such as feat or fix")
+	commitCmd.Flags().StringVarP(&commitFlags.Scope, "scope", "s", "", "Optional scope, such as api")
+	commitCmd.Flags().StringVarP(&commitFlags.Description, "message", "m", "", "Single-line commit message")
+	commitCmd.Flags().StringVarP(&commitFlags.Body, "body", "b", "", "Optional commit body")
+	commitCmd.Flags().BoolVar(&commitFlags.Breaking, "breaking", false, "Mark the commit as a breaking change")
+}
@@ -4,29 +4,38 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/LaansDole/go-git-tui/internal/git"
 	"github.com/LaansDole/go-git-tui/internal/ui"
 
 	"github.com/spf13/cobra"
 )
 
 func main() {
+	var stayOpen bool
 	cmd := &cobra.Command{
-		Use:   "gadd",
+		Use:   "gadd [pathspec...]",
 		Short: "Interactive TUI for staging Git files",
 		Long: `This program allows you to stage files using a terminal UI for faster interaction.
 
 User Manual:
   - Use ARROW KEYS (UP/DOWN) to move
   - TAB to select files
-  - ENTER once you have done selecting the files you want to add`,
+  - ENTER stages the selected files and keeps the UI open to stage more
+  - C stages the selected files and opens the commit message editor`,
 		Run: func(cmd *cobra.Command, args []string) {
-			if err := ui.StartAddTUI(); err != nil {
+			spec, err := git.ParseCwdPathspec(args)
+			if err == nil {
+				err = ui.StartAddTUI(spec, stayOpen)
+			}
+			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				os.Exit(1)
 			}
 		},
 	}
 
+	cmd.Flags().BoolVar(&stayOpen, "stay-open", true, "Keep the UI open after staging to keep building up the index; --stay-open=false exits after staging once")
+
 	if err := cmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
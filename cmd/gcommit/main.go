@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/LaansDole/go-git-tui/internal/git"
 	"github.com/LaansDole/go-git-tui/internal/ui"
 
 	"github.com/spf13/cobra"
@@ -21,7 +22,7 @@ User Manual:
   - Use ARROW KEYS (UP/DOWN) to navigate options
   - ENTER to confirm selections`,
 		Run: func(cmd *cobra.Command, args []string) {
-			if err := ui.StartCommitTUI(); err != nil {
+			if err := ui.StartCommitTUI(git.CommitMessage{}); err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				os.Exit(1)
 			}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui"
+
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history <path>",
+	Short: "Browse the commits that changed a file",
+	Long: `Browse the commits that changed a file, following renames.
+
+User Manual:
+  - Use w/s to move between commits; the diff shows what each commit changed in the file
+  - j/k scroll the diff
+  - r restores the file's content at the selected commit into the working tree`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path, err := git.RelativePath(args[0])
+		exitOnError(err)
+		exitOnError(ui.StartHistoryTUI(path))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+}
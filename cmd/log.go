@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/LaansDole/go-git-tui/internal/ui"
+
+	"github.com/spf13/cobra"
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log [revision]",
+	Short: "Browse commits and cherry-pick or revert them",
+	Long: `Browse the commit log of HEAD or another revision with the diff of each commit.
+
+User Manual:
+  - Use w/s to move between commits and j/k to scroll the diff
+  - TAB selects several commits
+  - c cherry-picks the selected commits onto the current branch, oldest first
+  - v reverts them with a conventional "revert:" message
+  - When a commit conflicts, r resolves, c continues with the rest and a aborts
+
+Example: go-git-tui log main   # backport fixes from main onto the checked out branch`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		rev := ""
+		if len(args) == 1 {
+			rev = args[0]
+		}
+		exitOnError(ui.StartLogTUI(rev))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(logCmd)
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/LaansDole/go-git-tui/internal/ui"
+
+	"github.com/spf13/cobra"
+)
+
+var rebaseCmd = &cobra.Command{
+	Use:   "rebase",
+	Short: "Rewrite recent commits with an interactive rebase",
+	Long: `Pick a base commit and edit the todo list of the commits after it.
+
+User Manual:
+  - Use j/k to choose the base commit and press ENTER
+  - j/k move the cursor and J/K move the commit up or down
+  - p/r/e/s/f/d set pick, reword, edit, squash, fixup or drop
+  - ENTER starts the rebase; when it stops, c continues, a aborts and r resolves conflicts
+  - Running the command during a stopped rebase resumes it`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := ui.StartRebaseTUI(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rebaseCmd)
+}
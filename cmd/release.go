@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	releaseBump    string
+	releaseMessage string
+	releaseDryRun  bool
+	releaseYes     bool
+
+	releaseCmd = &cobra.Command{
+		Use:   "release",
+		Short: "Tag the next semver release based on conventional commits",
+		Long: `Find the last semver tag reachable from HEAD, parse the conventional commits made
+since then and propose the next version:
+
+  - a breaking change (feat!: or a BREAKING CHANGE footer) bumps the major version
+  - feat bumps the minor version
+  - fix and perf bump the patch version
+
+After confirmation an annotated tag is created at HEAD. Push it with 'git push --tags'.
+Use 'go-git-tui tag' to list and delete tags.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			plan, err := mustGitService().PlanRelease(cmd.Context())
+			exitOnError(err)
+
+			if releaseBump != "" {
+				bump, err := git.ParseBump(releaseBump)
+				exitOnError(err)
+				plan.Bump = bump
+				plan.Next = plan.Current.Apply(bump)
+			}
+
+			out := cmd.OutOrStdout()
+			printReleasePlan(out, plan)
+
+			if plan.Bump == git.BumpNone {
+				fmt.Fprintln(out, "No releasable changes; use --bump to release anyway")
+				return
+			}
+			if releaseDryRun {
+				return
+			}
+
+			name := plan.Next.String()
+			if !releaseYes && !confirm(cmd.InOrStdin(), out, fmt.Sprintf("Create annotated tag %s?", name)) {
+				fmt.Fprintln(out, "Aborted")
+				return
+			}
+
+			message := releaseMessage
+			if message == "" {
+				message = "Release " + name
+			}
+			exitOnError(mustGitService().CreateTag(cmd.Context(), name, message))
+			fmt.Fprintf(out, "Tagged %s\n", name)
+		},
+	}
+)
+
+// printReleasePlan writes the previous version, the commits since then and the proposed version
+func printReleasePlan(out io.Writer, plan *git.ReleasePlan) {
+	if plan.Previous != nil {
+		fmt.Fprintf(out, "Last release: %s (%s)\n", plan.Previous.Name, plan.Previous.Hash[:7])
+	} else {
+		fmt.Fprintln(out, "No previous release")
+	}
+
+	for _, c := range plan.Commits {
+		fmt.Fprintf(out, "  %s %s\n", c.Commit.ShortHash, c.Commit.Subject)
+	}
+	if plan.Skipped > 0 {
+		fmt.Fprintf(out, "  (%d non-conventional commit(s) ignored)\n", plan.Skipped)
+	}
+
+	if plan.Bump != git.BumpNone {
+		fmt.Fprintf(out, "Next version: %s (%s)\n", plan.Next, plan.Bump)
+	}
+}
+
+// confirm asks a yes/no question and defaults to no
+func confirm(in io.Reader, out io.Writer, question string) bool {
+	fmt.Fprintf(out, "%s [y/N] ", question)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+func init() {
+	releaseCmd.Flags().StringVar(&releaseBump, "bump", "", "Force the bump instead of computing it (major, minor or patch)")
+	releaseCmd.Flags().StringVarP(&releaseMessage, "message", "m", "", "Tag message (default \"Release <version>\")")
+	releaseCmd.Flags().BoolVar(&releaseDryRun, "dry-run", false, "Only print the proposed version")
+	releaseCmd.Flags().BoolVarP(&releaseYes, "yes", "y", false, "Create the tag without asking for confirmation")
+	rootCmd.AddCommand(releaseCmd)
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui"
+	"github.com/LaansDole/go-git-tui/internal/ui/common"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	setURLPush bool
+
+	remoteCmd = &cobra.Command{
+		Use:   "remote",
+		Short: "Manage remotes",
+		Long: `Manage the repository's remotes. Without a subcommand an interactive TUI is opened.
+
+User Manual:
+  - Use w/s to move between remotes
+  - a to add, r to rename, d to remove a remote
+  - u to change the fetch URL, p to change the push URL`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := ui.StartRemoteTUI(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	remoteListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List remotes with their fetch and push URLs",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			remotes, err := mustGitService().Remotes(cmd.Context())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			for _, r := range remotes {
+				fmt.Fprintf(w, "%s\t%s\t(fetch)\n", r.Name, r.FetchURL)
+				for _, url := range r.PushURLs {
+					fmt.Fprintf(w, "%s\t%s\t(push)\n", r.Name, url)
+				}
+			}
+			exitOnError(w.Flush())
+		},
+	}
+
+	remoteAddCmd = &cobra.Command{
+		Use:   "add <name> <url>",
+		Short: "Add a remote",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			exitOnError(mustGitService().AddRemote(cmd.Context(), args[0], args[1]))
+		},
+	}
+
+	remoteRenameCmd = &cobra.Command{
+		Use:   "rename <old> <new>",
+		Short: "Rename a remote",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			exitOnError(mustGitService().RenameRemote(cmd.Context(), args[0], args[1]))
+		},
+	}
+
+	remoteRemoveCmd = &cobra.Command{
+		Use:     "remove <name>",
+		Aliases: []string{"rm"},
+		Short:   "Remove a remote",
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			exitOnError(mustGitService().RemoveRemote(cmd.Context(), args[0]))
+		},
+	}
+
+	remoteSetURLCmd = &cobra.Command{
+		Use:   "set-url <name> <url>",
+		Short: "Change the fetch (or, with --push, the push) URL of a remote",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			exitOnError(mustGitService().SetRemoteURL(cmd.Context(), args[0], args[1], setURLPush))
+		},
+	}
+)
+
+// mustGitService opens the repository of the working directory or exits
+func mustGitService() *git.DefaultGitService {
+	service, err := git.NewGitService()
+	exitOnError(err)
+	return service
+}
+
+// exitOnError prints err and exits with a failure status when it is non-nil
+func exitOnError(err error) {
+	if err != nil {
+		slog.Error("command failed", "error", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if help := common.DescribeError(err); help.Hint != "" {
+			fmt.Fprintf(os.Stderr, "Hint: %s\n", help.Hint)
+		}
+		os.Exit(1)
+	}
+}
+
+func init() {
+	remoteSetURLCmd.Flags().BoolVar(&setURLPush, "push", false, "Change the push URL instead of the fetch URL")
+
+	remoteCmd.AddCommand(remoteListCmd)
+	remoteCmd.AddCommand(remoteAddCmd)
+	remoteCmd.AddCommand(remoteRenameCmd)
+	remoteCmd.AddCommand(remoteRemoveCmd)
+	remoteCmd.AddCommand(remoteSetURLCmd)
+
+	rootCmd.AddCommand(remoteCmd)
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/LaansDole/go-git-tui/internal/ui"
+
+	"github.com/spf13/cobra"
+)
+
+var resolveCmd = &cobra.Command{
+	Use:   "resolve",
+	Short: "Resolve merge conflicts interactively",
+	Long: `Resolve unmerged files left by a merge, rebase, cherry-pick or stash pop.
+
+User Manual:
+  - Use w/s to move between conflicted files and n/p to move between hunks
+  - o keeps ours, t keeps theirs, b keeps both, a keeps the base version
+  - ENTER writes the resolved file and stages it`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := ui.StartConflictTUI(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(resolveCmd)
+}
@@ -2,8 +2,12 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
 
+	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/logging"
 	"github.com/LaansDole/go-git-tui/internal/ui"
 
 	"github.com/spf13/cobra"
@@ -13,36 +17,57 @@ import (
 const version = "v1.0.6"
 
 var (
-	verbose bool
+	verbosity int
+	logFile   string
+	logCloser io.Closer
+
+	// runDashboard opens the dashboard, replaced in tests
+	runDashboard = ui.StartDashboardTUI
 
 	rootCmd = &cobra.Command{
 		Use:   "go-git-tui",
 		Short: "A Git TUI application",
-		Long:  `A terminal user interface for Git operations built with go-git and Cobra CLI.`,
+		Long: `A terminal user interface for Git operations built with go-git and Cobra CLI.
+
+Without a subcommand it opens a dashboard with the status, staging, commit, log and
+branch views as tabs; ctrl+n and ctrl+p switch between them.
+
+Every git operation is logged to $XDG_STATE_HOME/go-git-tui/log (or --log-file):
+failures by default, all operations with -v and debug details with -vv.`,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			setupLogging()
+		},
+		Args: cobra.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
-			// Default behavior when no subcommand is specified
-			if err := cmd.Help(); err != nil {
-				fmt.Fprintf(os.Stderr, "Error showing help: %v\n", err)
-				os.Exit(1)
-			}
+			// Without a subcommand every view opens as a tab of the dashboard
+			exitOnError(runDashboard())
 		},
 	}
 
 	addCmd = &cobra.Command{
-		Use:   "add",
+		Use:   "add [pathspec...]",
 		Short: "Stage files interactively",
 		Long: `Stage files using a terminal UI for faster interaction.
   
 User Manual:
   - Use ARROW KEYS (UP/DOWN) to move
   - TAB to select files
-  - ENTER once you have done selecting the files you want to add`,
-		Run: func(cmd *cobra.Command, args []string) {
-			if err := ui.StartAddTUI(); err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
-			}
-		},
+  - ENTER stages the selected files and keeps the UI open to stage more
+  - C stages the selected files and opens the commit message editor
+
+With --stay-open=false the UI exits after staging once.
+
+Pathspecs limit the listed files and follow git's rules, including :(exclude),
+:(glob) with ** and :/ for the top of the repository.
+
+With --all, --update, --pattern or --no-tui the matching files are staged without
+opening the UI; --dry-run only prints them.
+
+Examples:
+  go-git-tui add internal ':!*_test.go'
+  go-git-tui add --update --pattern '*.go'
+  go-git-tui add --all --dry-run`,
+		Run: runAdd,
 	}
 
 	commitCmd = &cobra.Command{
@@ -54,23 +79,44 @@ User Manual:
   - Use w/s keys to navigate commit types
   - TAB to select a commit type and proceed to message input
   - ENTER to confirm commit message
-  - ESC to go back to type selection`,
-		Run: func(cmd *cobra.Command, args []string) {
-			if err := ui.StartCommitTUI(); err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
-			}
-		},
+  - ESC to go back to type selection
+  - f lists commits not on upstream: TAB creates a fixup! commit, e an amend! commit
+  - S autosquashes pending fixup commits before pushing
+
+With --type and --message the commit is created directly, validated like the TUI.
+When only some fields are given, the TUI opens at the first missing step.
+
+Example: go-git-tui commit -t fix -s api -m "handle nil" -b "Details..." --breaking`,
+		Run: runCommit,
 	}
 )
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	if logCloser != nil {
+		logCloser.Close()
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
+// setupLogging sends the git operation log to the log file. A log that cannot be opened
+// is reported once and otherwise ignored, since it must never stop a command.
+func setupLogging() {
+	logger, closer, err := logging.Open(logFile, verbosity)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: logging disabled: %v\n", err)
+		return
+	}
+
+	logCloser = closer
+	slog.SetDefault(logger)
+	git.SetLogger(logger)
+	slog.Debug("command started", "args", os.Args[1:], "version", version)
+}
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print the version information",
@@ -107,7 +153,8 @@ var docsCmd = &cobra.Command{
 
 func init() {
 	// Global flags
-	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
+	rootCmd.PersistentFlags().CountVarP(&verbosity, "verbose", "v", "Log every git operation (-v) or debug details too (-vv)")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Log file (default $XDG_STATE_HOME/go-git-tui/log)")
 
 	// Add subcommands
 	rootCmd.AddCommand(addCmd)
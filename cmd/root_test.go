@@ -7,23 +7,35 @@ import (
 
 func TestRootCommand(t *testing.T) {
 	tests := []struct {
-		name       string
-		args       []string
-		wantOutput bool
-		wantError  bool
+		name          string
+		args          []string
+		wantDashboard bool
+		wantError     bool
 	}{
 		{
-			name:       "GIVEN no arguments THEN help is displayed without error",
-			args:       []string{},
-			wantOutput: true,
-			wantError:  false,
+			name:          "GIVEN no arguments THEN the dashboard opens",
+			args:          []string{},
+			wantDashboard: true,
+		},
+		{
+			name:      "GIVEN an unknown argument THEN it is an error",
+			args:      []string{"nonexistent"},
+			wantError: true,
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
+			opened := false
+			t.Cleanup(func(run func() error) func() { return func() { runDashboard = run } }(runDashboard))
+			runDashboard = func() error {
+				opened = true
+				return nil
+			}
+
 			buf := new(bytes.Buffer)
 			rootCmd.SetOut(buf)
+			rootCmd.SetErr(buf)
 			rootCmd.SetArgs(tc.args)
 
 			err := rootCmd.Execute()
@@ -32,13 +44,8 @@ func TestRootCommand(t *testing.T) {
 				t.Errorf("Execute() error = %v, wantError %v", err, tc.wantError)
 			}
 
-			gotOutput := buf.String()
-			if tc.wantOutput && len(gotOutput) == 0 {
-				t.Error("Expected non-empty output but got nothing")
-			}
-
-			if !tc.wantOutput && len(gotOutput) > 0 {
-				t.Errorf("Expected no output but got: %s", gotOutput)
+			if opened != tc.wantDashboard {
+				t.Errorf("dashboard opened = %v, want %v", opened, tc.wantDashboard)
 			}
 		})
 	}
@@ -60,6 +67,61 @@ func TestCommandRegistration(t *testing.T) {
 			commandUse: "commit",
 			wantFound:  true,
 		},
+		{
+			name:       "GIVEN remote command THEN it is registered in root command",
+			commandUse: "remote",
+			wantFound:  true,
+		},
+		{
+			name:       "GIVEN resolve command THEN it is registered in root command",
+			commandUse: "resolve",
+			wantFound:  true,
+		},
+		{
+			name:       "GIVEN rebase command THEN it is registered in root command",
+			commandUse: "rebase",
+			wantFound:  true,
+		},
+		{
+			name:       "GIVEN autosquash command THEN it is registered in root command",
+			commandUse: "autosquash",
+			wantFound:  true,
+		},
+		{
+			name:       "GIVEN blame command THEN it is registered in root command",
+			commandUse: "blame",
+			wantFound:  true,
+		},
+		{
+			name:       "GIVEN history command THEN it is registered in root command",
+			commandUse: "history",
+			wantFound:  true,
+		},
+		{
+			name:       "GIVEN log command THEN it is registered in root command",
+			commandUse: "log",
+			wantFound:  true,
+		},
+		{
+			name:       "GIVEN release command THEN it is registered in root command",
+			commandUse: "release",
+			wantFound:  true,
+		},
+		{
+			name:       "GIVEN tag command THEN it is registered in root command",
+			commandUse: "tag",
+			wantFound:  true,
+		},
+		{
+			name:       "GIVEN changelog command THEN it is registered in root command",
+			commandUse: "changelog",
+			wantFound:  true,
+		},
+		{
+			name:       "GIVEN status command THEN it is registered in root command",
+			commandUse: "status",
+			wantFound:  true,
+		},
 		{
 			name:       "GIVEN nonexistent command THEN it is not found in root command",
 			commandUse: "nonexistent",
@@ -71,7 +133,7 @@ func TestCommandRegistration(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			found := false
 			for _, cmd := range rootCmd.Commands() {
-				if cmd.Use == tc.commandUse {
+				if cmd.Name() == tc.commandUse {
 					found = true
 					break
 				}
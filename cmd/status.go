@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	statusFormat string
+	statusNull   bool
+
+	statusCmd = &cobra.Command{
+		Use:   "status",
+		Short: "Show the working tree status",
+		Long: `Show the current branch, its upstream and the state of every changed file in the
+index and the working tree.
+
+Formats:
+  table      human readable (default)
+  porcelain  compatible with 'git status --porcelain --branch'; add -z for NUL-terminated entries
+  json       for scripts and prompts`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			service := mustGitService()
+
+			branch, err := service.BranchStatus(cmd.Context())
+			exitOnError(err)
+			files, err := service.Status(cmd.Context())
+			exitOnError(err)
+			sortStatusFiles(files)
+
+			out := cmd.OutOrStdout()
+			switch statusFormat {
+			case "table":
+				exitOnError(printStatusTable(out, branch, files))
+			case "porcelain":
+				printStatusPorcelain(out, branch, files, statusNull)
+			case "json":
+				exitOnError(printStatusJSON(out, branch, files))
+			default:
+				exitOnError(fmt.Errorf("unknown format %q: expected table, porcelain or json", statusFormat))
+			}
+		},
+	}
+)
+
+// statusFile is the JSON form of a changed file
+type statusFile struct {
+	Path     string `json:"path"`
+	Status   string `json:"status"`
+	Index    string `json:"index"`
+	Worktree string `json:"worktree"`
+}
+
+// statusReport is the JSON form of the status command's output
+type statusReport struct {
+	Branch   string       `json:"branch"`
+	Head     string       `json:"head"`
+	Detached bool         `json:"detached"`
+	Upstream string       `json:"upstream"`
+	Ahead    int          `json:"ahead"`
+	Behind   int          `json:"behind"`
+	Files    []statusFile `json:"files"`
+}
+
+func printStatusJSON(out io.Writer, branch *git.BranchStatus, files []git.GitFile) error {
+	report := statusReport{
+		Branch:   branch.Branch,
+		Head:     branch.Head,
+		Detached: branch.Detached,
+		Upstream: branch.Upstream,
+		Ahead:    branch.Ahead,
+		Behind:   branch.Behind,
+		Files:    make([]statusFile, 0, len(files)),
+	}
+	for _, f := range files {
+		report.Files = append(report.Files, statusFile{
+			Path:     f.Path,
+			Status:   f.Status,
+			Index:    f.IndexState(),
+			Worktree: f.WorktreeState(),
+		})
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// printStatusPorcelain writes the branch header and entries of 'git status --porcelain --branch'
+func printStatusPorcelain(out io.Writer, branch *git.BranchStatus, files []git.GitFile, null bool) {
+	end := "\n"
+	if null {
+		end = "\x00"
+	}
+
+	header := "## "
+	switch {
+	case branch.Detached:
+		header += "HEAD (no branch)"
+	case branch.Head == "":
+		header += "No commits yet on " + branch.Branch
+	default:
+		header += branch.Branch
+	}
+	if branch.Upstream != "" {
+		header += "..." + branch.Upstream
+		if divergence := branch.Divergence(); divergence != "" {
+			header += " [" + divergence + "]"
+		}
+	}
+	fmt.Fprint(out, header+end)
+
+	for _, f := range files {
+		path := f.Path
+		if !null {
+			path = quotePath(path)
+		}
+		fmt.Fprintf(out, "%s %s%s", f.Status, path, end)
+	}
+}
+
+// sortStatusFiles orders files like git: tracked changes by path, then untracked files
+func sortStatusFiles(files []git.GitFile) {
+	sort.Slice(files, func(i, j int) bool {
+		iu, ju := files[i].Status == "??", files[j].Status == "??"
+		if iu != ju {
+			return ju
+		}
+		return files[i].Path < files[j].Path
+	})
+}
+
+// quotePath quotes a path the way git does in porcelain output when it contains
+// spaces, quotes, backslashes, control characters or non-ASCII bytes
+func quotePath(path string) string {
+	if !strings.ContainsFunc(path, func(r rune) bool {
+		return r <= ' ' || r == '"' || r == '\\' || r >= 0x7f
+	}) {
+		return path
+	}
+
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; {
+		case c == '"' || c == '\\':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case c == '\t':
+			sb.WriteString(`\t`)
+		case c == '\n':
+			sb.WriteString(`\n`)
+		case c < ' ' || c >= 0x7f:
+			fmt.Fprintf(&sb, "\\%03o", c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+func printStatusTable(out io.Writer, branch *git.BranchStatus, files []git.GitFile) error {
+	switch {
+	case branch.Detached:
+		fmt.Fprintf(out, "HEAD detached at %s\n", branch.Head[:7])
+	case branch.Head == "":
+		fmt.Fprintf(out, "On branch %s, no commits yet\n", branch.Branch)
+	default:
+		fmt.Fprintf(out, "On branch %s\n", branch.Branch)
+	}
+
+	if branch.Upstream != "" {
+		state := branch.Divergence()
+		if state == "" {
+			state = "up to date"
+		}
+		fmt.Fprintf(out, "Tracking %s (%s)\n", branch.Upstream, state)
+	}
+
+	if len(files) == 0 {
+		fmt.Fprintln(out, "Nothing to commit, working tree clean")
+		return nil
+	}
+
+	fmt.Fprintln(out)
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "INDEX\tWORKTREE\tPATH")
+	for _, f := range files {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", tableState(f.IndexState()), tableState(f.WorktreeState()), f.Path)
+	}
+	return w.Flush()
+}
+
+// tableState hides unchanged states so that the changes stand out
+func tableState(state string) string {
+	if state == "unmodified" {
+		return "-"
+	}
+	return state
+}
+
+func init() {
+	statusCmd.Flags().StringVarP(&statusFormat, "format", "f", "table", "Output format: table, porcelain or json")
+	statusCmd.Flags().BoolVarP(&statusNull, "null", "z", false, "Terminate porcelain entries with NUL instead of newline")
+	rootCmd.AddCommand(statusCmd)
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+)
+
+func TestPrintStatusPorcelain(t *testing.T) {
+	files := []git.GitFile{
+		{Status: "??", Path: "new file"},
+		{Status: "MM", Path: "b.go"},
+		{Status: "A ", Path: "a.go"},
+	}
+	sortStatusFiles(files)
+
+	tests := []struct {
+		name   string
+		branch git.BranchStatus
+		null   bool
+		want   string
+	}{
+		{
+			name:   "GIVEN a diverged branch THEN the header matches git",
+			branch: git.BranchStatus{Branch: "main", Head: "abc", Upstream: "origin/main", Ahead: 2, Behind: 1},
+			want:   "## main...origin/main [ahead 2, behind 1]\nA  a.go\nMM b.go\n?? \"new file\"\n",
+		},
+		{
+			name:   "GIVEN a detached HEAD and -z THEN paths are not quoted",
+			branch: git.BranchStatus{Head: "abc", Detached: true},
+			null:   true,
+			want:   "## HEAD (no branch)\x00A  a.go\x00MM b.go\x00?? new file\x00",
+		},
+		{
+			name:   "GIVEN an unborn branch THEN the header says so",
+			branch: git.BranchStatus{Branch: "main"},
+			want:   "## No commits yet on main\nA  a.go\nMM b.go\n?? \"new file\"\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			printStatusPorcelain(&buf, &tc.branch, files, tc.null)
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"github.com/LaansDole/go-git-tui/internal/ui"
+
+	"github.com/spf13/cobra"
+)
+
+var tagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "List and delete tags",
+	Long: `List the repository's tags, newest version first, with the commit and message of
+annotated tags.
+
+User Manual:
+  - Use w/s to move between tags
+  - d deletes the selected tag after confirmation
+
+Create release tags with 'go-git-tui release'.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		exitOnError(ui.StartTagTUI())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tagCmd)
+}
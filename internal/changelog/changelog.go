@@ -0,0 +1,112 @@
+// Package changelog builds release notes from conventional commits and renders them
+// in pluggable output formats.
+package changelog
+
+import (
+	"time"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+)
+
+// DefaultTitles are the section titles for the commit types offered by the commit TUI
+var DefaultTitles = map[string]string{
+	"feat":     "Features",
+	"fix":      "Bug Fixes",
+	"perf":     "Performance",
+	"revert":   "Reverts",
+	"docs":     "Documentation",
+	"refactor": "Refactoring",
+	"test":     "Tests",
+	"style":    "Styles",
+	"chore":    "Chores",
+}
+
+// DefaultOrder is the order sections appear in
+var DefaultOrder = []string{"feat", "fix", "perf", "revert", "docs", "refactor", "test", "style", "chore"}
+
+// Config controls how commits are grouped into sections
+type Config struct {
+	Titles map[string]string // Section title per commit type, merged over DefaultTitles
+	Order  []string          // Section order, DefaultOrder when empty
+}
+
+// Entry is a single change in a section
+type Entry struct {
+	Scope       string `json:"scope,omitempty"`
+	Description string `json:"description"`
+	Breaking    bool   `json:"breaking,omitempty"`
+	Hash        string `json:"hash"`
+	ShortHash   string `json:"short_hash"`
+	Author      string `json:"author"`
+}
+
+// Section groups the entries of one commit type
+type Section struct {
+	Type    string  `json:"type"`
+	Title   string  `json:"title"`
+	Entries []Entry `json:"entries"`
+}
+
+// Changelog is the set of changes between two revisions
+type Changelog struct {
+	Version  string    `json:"version"`
+	Date     time.Time `json:"date"`
+	From     string    `json:"from,omitempty"`
+	To       string    `json:"to"`
+	Breaking []Entry   `json:"breaking,omitempty"`
+	Sections []Section `json:"sections"`
+}
+
+// Build groups the conventional commits among commits into sections, newest first.
+// Commits that are not conventional or whose type has no title are left out.
+func Build(commits []git.CommitInfo, cfg Config) *Changelog {
+	titles := map[string]string{}
+	for t, title := range DefaultTitles {
+		titles[t] = title
+	}
+	for t, title := range cfg.Titles {
+		titles[t] = title
+	}
+
+	order := cfg.Order
+	if len(order) == 0 {
+		order = DefaultOrder
+	}
+
+	entries := map[string][]Entry{}
+	log := &Changelog{Sections: []Section{}}
+	for i := len(commits) - 1; i >= 0; i-- {
+		cc, ok := git.ParseConventional(commits[i])
+		if !ok {
+			continue
+		}
+
+		entry := Entry{
+			Scope:       cc.Scope,
+			Description: cc.Description,
+			Breaking:    cc.Breaking,
+			Hash:        cc.Commit.Hash,
+			ShortHash:   cc.Commit.ShortHash,
+			Author:      cc.Commit.Author,
+		}
+		entries[cc.Type] = append(entries[cc.Type], entry)
+		if cc.Breaking {
+			log.Breaking = append(log.Breaking, entry)
+		}
+	}
+
+	for _, t := range order {
+		title, ok := titles[t]
+		if !ok || title == "" || len(entries[t]) == 0 {
+			continue
+		}
+		log.Sections = append(log.Sections, Section{Type: t, Title: title, Entries: entries[t]})
+	}
+
+	return log
+}
+
+// Empty reports whether the changelog has no entries
+func (c *Changelog) Empty() bool {
+	return len(c.Sections) == 0 && len(c.Breaking) == 0
+}
@@ -0,0 +1,131 @@
+package changelog
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+)
+
+func commit(hash, message string) git.CommitInfo {
+	return git.CommitInfo{Hash: hash, ShortHash: hash[:7], Message: message, Author: "Test User"}
+}
+
+// oldest first, as returned by ReleaseCommits
+var testCommits = []git.CommitInfo{
+	commit("1111111aaaa", "feat(ui): add tag list"),
+	commit("2222222bbbb", "fix: handle empty repos"),
+	commit("3333333cccc", "Merge branch 'topic'"),
+	commit("4444444dddd", "feat!: drop gadd alias"),
+	commit("5555555eeee", "chore: bump deps"),
+}
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       Config
+		wantTypes []string
+		wantTitle string
+	}{
+		{
+			name:      "GIVEN the default config THEN sections follow the default order",
+			wantTypes: []string{"feat", "fix", "chore"},
+			wantTitle: "Features",
+		},
+		{
+			name:      "GIVEN a custom title THEN it replaces the default",
+			cfg:       Config{Titles: map[string]string{"feat": "New Stuff"}},
+			wantTypes: []string{"feat", "fix", "chore"},
+			wantTitle: "New Stuff",
+		},
+		{
+			name:      "GIVEN an empty title and a custom order THEN the type is hidden",
+			cfg:       Config{Titles: map[string]string{"chore": ""}, Order: []string{"fix", "feat", "chore"}},
+			wantTypes: []string{"fix", "feat"},
+			wantTitle: "Bug Fixes",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			log := Build(testCommits, tc.cfg)
+
+			var types []string
+			for _, s := range log.Sections {
+				types = append(types, s.Type)
+			}
+			assert.Equal(t, tc.wantTypes, types)
+			assert.Equal(t, tc.wantTitle, log.Sections[0].Title)
+			require.Len(t, log.Breaking, 1)
+			assert.Equal(t, "drop gadd alias", log.Breaking[0].Description)
+		})
+	}
+}
+
+func TestFormats(t *testing.T) {
+	log := Build(testCommits, Config{})
+	log.Version = "v1.0.0"
+	log.Date = time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	t.Run("GIVEN markdown THEN features are listed newest first with scopes", func(t *testing.T) {
+		f, err := Lookup("markdown")
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, f.Format(&buf, log))
+		assert.Equal(t, `## v1.0.0 (2026-01-02)
+
+### ⚠ BREAKING CHANGES
+
+- drop gadd alias (4444444)
+
+### Features
+
+- drop gadd alias (4444444)
+- **ui:** add tag list (1111111)
+
+### Bug Fixes
+
+- handle empty repos (2222222)
+
+### Chores
+
+- bump deps (5555555)
+`, buf.String())
+	})
+
+	t.Run("GIVEN json THEN the changelog round-trips", func(t *testing.T) {
+		f, err := Lookup("json")
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, f.Format(&buf, log))
+
+		var decoded Changelog
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Equal(t, log.Sections, decoded.Sections)
+	})
+
+	t.Run("GIVEN an unknown format THEN lookup fails", func(t *testing.T) {
+		_, err := Lookup("yaml")
+		assert.Error(t, err)
+	})
+}
+
+func TestPrepend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "CHANGELOG.md")
+
+	require.NoError(t, Prepend(path, []byte("## v0.1.0\n\n- first\n")))
+	require.NoError(t, Prepend(path, []byte("## v0.2.0\n\n- second\n")))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# Changelog\n\n## v0.2.0\n\n- second\n\n## v0.1.0\n\n- first\n", string(content))
+}
@@ -0,0 +1,42 @@
+package changelog
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// fileHeading is written at the top of a new changelog file
+const fileHeading = "# Changelog\n"
+
+// Prepend inserts a rendered release at the top of the changelog file at path, below its
+// "# Changelog" heading. The file is created when it does not exist.
+func Prepend(path string, release []byte) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	content := string(existing)
+	heading := fileHeading
+	if strings.HasPrefix(content, "# ") {
+		// Keep the existing title line, whatever it says
+		line, rest, _ := strings.Cut(content, "\n")
+		heading, content = line+"\n", rest
+	}
+
+	var sb strings.Builder
+	sb.WriteString(heading)
+	sb.WriteString("\n")
+	sb.WriteString(strings.TrimRight(string(release), "\n"))
+	sb.WriteString("\n")
+	if rest := strings.TrimLeft(content, "\n"); rest != "" {
+		sb.WriteString("\n")
+		sb.WriteString(rest)
+	}
+
+	if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
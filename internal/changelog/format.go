@@ -0,0 +1,88 @@
+package changelog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Formatter renders a changelog in an output format
+type Formatter interface {
+	Format(w io.Writer, c *Changelog) error
+}
+
+// FormatterFunc adapts a function to the Formatter interface
+type FormatterFunc func(w io.Writer, c *Changelog) error
+
+// Format calls f(w, c)
+func (f FormatterFunc) Format(w io.Writer, c *Changelog) error { return f(w, c) }
+
+// formatters holds the registered output formats by name
+var formatters = map[string]Formatter{
+	"markdown": FormatterFunc(formatMarkdown),
+	"json":     FormatterFunc(formatJSON),
+}
+
+// Register adds an output format, replacing any format with the same name
+func Register(name string, f Formatter) {
+	formatters[name] = f
+}
+
+// Lookup returns the output format registered under name
+func Lookup(name string) (Formatter, error) {
+	f, ok := formatters[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown changelog format %q (available: %s)", name, strings.Join(Formats(), ", "))
+	}
+	return f, nil
+}
+
+// Formats returns the names of the registered output formats
+func Formats() []string {
+	names := make([]string, 0, len(formatters))
+	for name := range formatters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// formatMarkdown renders a release heading followed by one list per section
+func formatMarkdown(w io.Writer, c *Changelog) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## %s (%s)\n", c.Version, c.Date.Format("2006-01-02"))
+
+	if len(c.Breaking) > 0 {
+		sb.WriteString("\n### ⚠ BREAKING CHANGES\n\n")
+		for _, e := range c.Breaking {
+			writeMarkdownEntry(&sb, e)
+		}
+	}
+
+	for _, section := range c.Sections {
+		fmt.Fprintf(&sb, "\n### %s\n\n", section.Title)
+		for _, e := range section.Entries {
+			writeMarkdownEntry(&sb, e)
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func writeMarkdownEntry(sb *strings.Builder, e Entry) {
+	sb.WriteString("- ")
+	if e.Scope != "" {
+		fmt.Fprintf(sb, "**%s:** ", e.Scope)
+	}
+	fmt.Fprintf(sb, "%s (%s)\n", e.Description, e.ShortHash)
+}
+
+// formatJSON renders the changelog as indented JSON
+func formatJSON(w io.Writer, c *Changelog) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(c)
+}
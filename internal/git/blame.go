@@ -0,0 +1,155 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// BlameLine is one line of a file with the commit that last changed it
+type BlameLine struct {
+	Number    int // 1-based line number
+	Text      string
+	Hash      string
+	ShortHash string
+	Author    string
+	When      time.Time
+}
+
+// BlameResult is the line-by-line authorship of a file at a revision
+type BlameResult struct {
+	Path  string
+	Rev   CommitInfo // Commit the file was blamed at
+	Lines []BlameLine
+}
+
+// Blame annotates every line of path at rev with the commit that introduced it.
+// An empty rev blames the file at HEAD.
+func (g *GitRepository) Blame(ctx context.Context, path, rev string) (*BlameResult, error) {
+	if g.repo == nil {
+		return nil, errNotInitialized
+	}
+
+	commit, err := g.resolveCommit(rev)
+	if err != nil {
+		return nil, err
+	}
+
+	blame, err := git.Blame(commit, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to blame %s at %s: %w", path, commit.Hash.String()[:7], err)
+	}
+
+	lines := make([]BlameLine, 0, len(blame.Lines))
+	for i, line := range blame.Lines {
+		hash := line.Hash.String()
+		lines = append(lines, BlameLine{
+			Number:    i + 1,
+			Text:      line.Text,
+			Hash:      hash,
+			ShortHash: hash[:7],
+			Author:    line.AuthorName,
+			When:      line.Date,
+		})
+	}
+
+	return &BlameResult{
+		Path:  path,
+		Rev:   newCommitInfo(commit),
+		Lines: lines,
+	}, nil
+}
+
+// CommitDiff returns the changes a commit made to each file, compared to its first parent.
+// Diffs are rendered with the same line diff used for the working tree, and renamed
+// files are reported once under their new path.
+func (g *GitRepository) CommitDiff(ctx context.Context, hash string) ([]DiffResult, error) {
+	if g.repo == nil {
+		return nil, errNotInitialized
+	}
+
+	commit, err := g.resolveCommit(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit tree: %w", err)
+	}
+
+	// A root commit is compared against an empty tree
+	parentTree := &object.Tree{}
+	if commit.NumParents() > 0 {
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent commit: %w", err)
+		}
+		if parentTree, err = parent.Tree(); err != nil {
+			return nil, fmt.Errorf("failed to get parent tree: %w", err)
+		}
+	}
+
+	changes, err := object.DiffTreeWithOptions(context.Background(), parentTree, tree, object.DefaultDiffTreeOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff commit: %w", err)
+	}
+
+	diffs := make([]DiffResult, 0, len(changes))
+	for _, change := range changes {
+		diff, err := changeDiff(change)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, *diff)
+	}
+
+	return diffs, nil
+}
+
+// changeDiff renders a single tree change
+func changeDiff(change *object.Change) (*DiffResult, error) {
+	from, to, err := change.Files()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read changed files: %w", err)
+	}
+
+	path := change.To.Name
+	if path == "" {
+		path = change.From.Name
+	}
+
+	oldContent, oldBinary, err := fileContent(from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	newContent, newBinary, err := fileContent(to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if oldBinary || newBinary {
+		return &DiffResult{Path: path, IsBinary: true, Content: "[Binary file]"}, nil
+	}
+
+	content, stats := generateDiff(oldContent, newContent)
+	return &DiffResult{Path: path, Content: content, Stats: stats}, nil
+}
+
+// fileContent returns the content of a file in a tree, which is empty when the file is nil
+func fileContent(f *object.File) (string, bool, error) {
+	if f == nil {
+		return "", false, nil
+	}
+
+	binary, err := f.IsBinary()
+	if err != nil || binary {
+		return "", binary, err
+	}
+
+	content, err := f.Contents()
+	return content, false, err
+}
@@ -0,0 +1,63 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlame(t *testing.T) {
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	commitFile(t, repoPath, "file.txt", "one\ntwo\n", "feat: add file")
+	commitFile(t, repoPath, "file.txt", "one\n2\nthree\n", "fix: change file")
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	result, err := repo.Blame(t.Context(), "file.txt", "")
+	require.NoError(t, err)
+	assert.Equal(t, "fix: change file", result.Rev.Subject)
+	require.Len(t, result.Lines, 3)
+
+	log, err := repo.Log(t.Context(), LogOptions{})
+	require.NoError(t, err)
+	head, first := log[0], log[1]
+
+	assert.Equal(t, first.Hash, result.Lines[0].Hash)
+	assert.Equal(t, head.Hash, result.Lines[1].Hash)
+	assert.Equal(t, "three", result.Lines[2].Text)
+	assert.Equal(t, 3, result.Lines[2].Number)
+
+	// Re-blaming at the parent sees the original version of the line
+	parent, err := repo.Blame(t.Context(), "file.txt", head.Hash+"^")
+	require.NoError(t, err)
+	require.Len(t, parent.Lines, 2)
+	assert.Equal(t, "two", parent.Lines[1].Text)
+}
+
+func TestCommitDiff(t *testing.T) {
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	commitFile(t, repoPath, "a.txt", "a\n", "feat: a")
+	commitFile(t, repoPath, "a.txt", "a\nb\n", "feat: b")
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	diffs, err := repo.CommitDiff(t.Context(), "HEAD")
+	require.NoError(t, err)
+	require.Len(t, diffs, 1)
+	assert.Equal(t, "a.txt", diffs[0].Path)
+	assert.Equal(t, 1, diffs[0].Stats.Added)
+	assert.Contains(t, diffs[0].Content, "+ b")
+
+	// The root commit is compared against an empty tree
+	diffs, err = repo.CommitDiff(t.Context(), "HEAD~1")
+	require.NoError(t, err)
+	require.Len(t, diffs, 1)
+	assert.Equal(t, 1, diffs[0].Stats.Added)
+}
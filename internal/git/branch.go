@@ -0,0 +1,228 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// BranchStatus describes the checked out branch and how it relates to its upstream
+type BranchStatus struct {
+	Branch   string // Empty when HEAD is detached
+	Head     string // Empty in a repository without commits
+	Detached bool
+	Upstream string // Empty when the branch has no upstream
+	Ahead    int    // Commits on the branch that are not on the upstream
+	Behind   int    // Commits on the upstream that are not on the branch
+}
+
+// Divergence describes how far the branch and its upstream have diverged, like
+// "ahead 2, behind 1", and is empty when they point to the same commit
+func (b *BranchStatus) Divergence() string {
+	var parts []string
+	if b.Ahead > 0 {
+		parts = append(parts, fmt.Sprintf("ahead %d", b.Ahead))
+	}
+	if b.Behind > 0 {
+		parts = append(parts, fmt.Sprintf("behind %d", b.Behind))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Branch is a local branch and the commit it points to
+type Branch struct {
+	Name     string
+	Commit   CommitInfo
+	Upstream string // Empty when the branch has no upstream
+	Current  bool   // The branch is checked out
+}
+
+// BranchStatus returns the current branch, its upstream and how far they have diverged
+func (g *GitRepository) BranchStatus(ctx context.Context) (*BranchStatus, error) {
+	if g.repo == nil {
+		return nil, errNotInitialized
+	}
+
+	head, err := g.repo.Reference(plumbing.HEAD, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	status := &BranchStatus{}
+	if head.Type() == plumbing.SymbolicReference {
+		status.Branch = head.Target().Short()
+	} else {
+		status.Detached = true
+	}
+
+	resolved, err := g.repo.Head()
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		// An unborn branch has neither commits nor anything to compare against
+		return status, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	status.Head = resolved.Hash().String()
+
+	upstream, err := g.upstreamRef()
+	if err != nil || upstream == "" {
+		return status, err
+	}
+	status.Upstream = upstream.Short()
+
+	ref, err := g.repo.Reference(upstream, true)
+	if err != nil {
+		// The upstream branch was configured but never fetched, or deleted
+		return status, nil
+	}
+
+	local, err := g.repo.CommitObject(resolved.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD commit: %w", err)
+	}
+	other, err := g.repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upstream commit: %w", err)
+	}
+
+	status.Ahead, status.Behind, err = aheadBehind(ctx, local, other)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare with %s: %w", status.Upstream, err)
+	}
+
+	return status, nil
+}
+
+// Branches returns the local branches sorted by name
+func (g *GitRepository) Branches(ctx context.Context) ([]Branch, error) {
+	if g.repo == nil {
+		return nil, errNotInitialized
+	}
+
+	cfg, err := g.repo.Config()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git config: %w", err)
+	}
+
+	current := ""
+	if head, err := g.repo.Reference(plumbing.HEAD, false); err == nil && head.Type() == plumbing.SymbolicReference {
+		current = head.Target().Short()
+	}
+
+	refs, err := g.repo.Branches()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	var branches []Branch
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		name := ref.Name().Short()
+		c, err := g.repo.CommitObject(ref.Hash())
+		if err != nil {
+			return fmt.Errorf("failed to get the commit of %s: %w", name, err)
+		}
+
+		branch := Branch{Name: name, Commit: newCommitInfo(c), Current: name == current}
+		if upstream := branchUpstream(cfg, name); upstream != "" {
+			branch.Upstream = upstream.Short()
+		}
+		branches = append(branches, branch)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(branches, func(i, j int) bool { return branches[i].Name < branches[j].Name })
+	return branches, nil
+}
+
+// aheadBehind counts the commits reachable only from local and only from upstream.
+// Like git, both histories are walked newest first, marking each commit with the sides
+// it is reachable from, until the commits left to visit are shared and older than every
+// commit that is still exclusive to one side.
+func aheadBehind(ctx context.Context, local, upstream *object.Commit) (int, int, error) {
+	const fromLocal, fromUpstream, fromBoth = 1, 2, 3
+
+	flags := map[plumbing.Hash]int{}
+	commits := map[plumbing.Hash]*object.Commit{}
+	var queue []*object.Commit
+
+	mark := func(c *object.Commit, flag int) {
+		if flags[c.Hash]|flag == flags[c.Hash] {
+			return
+		}
+		flags[c.Hash] |= flag
+		commits[c.Hash] = c
+		// A commit is visited again when it gains a flag so its parents receive it too
+		queue = append(queue, c)
+	}
+
+	settled := func() bool {
+		var newestQueued time.Time
+		for _, c := range queue {
+			if flags[c.Hash] != fromBoth {
+				return false
+			}
+			if c.Committer.When.After(newestQueued) {
+				newestQueued = c.Committer.When
+			}
+		}
+		for hash, flag := range flags {
+			if flag != fromBoth && !commits[hash].Committer.When.After(newestQueued) {
+				return false
+			}
+		}
+		return true
+	}
+
+	mark(local, fromLocal)
+	mark(upstream, fromUpstream)
+
+	for len(queue) > 0 && !settled() {
+		if err := ctx.Err(); err != nil {
+			return 0, 0, err
+		}
+
+		newest := 0
+		for i, c := range queue {
+			if c.Committer.When.After(queue[newest].Committer.When) {
+				newest = i
+			}
+		}
+		c := queue[newest]
+		queue = append(queue[:newest], queue[newest+1:]...)
+
+		flag := flags[c.Hash]
+		err := c.Parents().ForEach(func(p *object.Commit) error {
+			mark(p, flag)
+			return nil
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	ahead, behind := 0, 0
+	for _, flag := range flags {
+		switch flag {
+		case fromLocal:
+			ahead++
+		case fromUpstream:
+			behind++
+		}
+	}
+
+	return ahead, behind, nil
+}
@@ -0,0 +1,95 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBranchStatus(t *testing.T) {
+	setTestIdentity(t)
+
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	status, err := repo.BranchStatus(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, &BranchStatus{Branch: "master"}, status, "an unborn branch has no commits")
+
+	commitFile(t, repoPath, "base.txt", "base", "chore: base")
+	runGit(t, repoPath, "branch", "upstream")
+	runGit(t, repoPath, "branch", "--set-upstream-to=upstream")
+
+	commitFile(t, repoPath, "a.txt", "a", "feat: a")
+	commitFile(t, repoPath, "b.txt", "b", "feat: b")
+	runGit(t, repoPath, "checkout", "-q", "upstream")
+	commitFile(t, repoPath, "c.txt", "c", "feat: c")
+	runGit(t, repoPath, "checkout", "-q", "master")
+	// Merging history that is shared with the upstream must not count as ahead
+	runGit(t, repoPath, "merge", "-q", "--no-edit", "upstream")
+
+	status, err = repo.BranchStatus(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "master", status.Branch)
+	assert.False(t, status.Detached)
+	assert.Equal(t, "upstream", status.Upstream)
+	assert.Equal(t, 3, status.Ahead)
+	assert.Equal(t, 0, status.Behind)
+
+	runGit(t, repoPath, "checkout", "-q", "upstream")
+	commitFile(t, repoPath, "d.txt", "d", "feat: d")
+	runGit(t, repoPath, "checkout", "-q", "master")
+
+	status, err = repo.BranchStatus(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 3, status.Ahead)
+	assert.Equal(t, 1, status.Behind)
+
+	runGit(t, repoPath, "checkout", "-q", "--detach")
+	status, err = repo.BranchStatus(t.Context())
+	require.NoError(t, err)
+	assert.True(t, status.Detached)
+	assert.Empty(t, status.Branch)
+	assert.Empty(t, status.Upstream)
+	assert.Len(t, status.Head, 40)
+}
+
+func TestBranches(t *testing.T) {
+	setTestIdentity(t)
+
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	branches, err := repo.Branches(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, branches, "an unborn branch is not listed")
+
+	commitFile(t, repoPath, "base.txt", "base", "chore: base")
+	runGit(t, repoPath, "branch", "upstream")
+	runGit(t, repoPath, "branch", "--set-upstream-to=upstream")
+	runGit(t, repoPath, "checkout", "-q", "-b", "feature")
+	commitFile(t, repoPath, "a.txt", "a", "feat: a")
+
+	branches, err = repo.Branches(t.Context())
+	require.NoError(t, err)
+	require.Len(t, branches, 3)
+
+	assert.Equal(t, "feature", branches[0].Name)
+	assert.True(t, branches[0].Current)
+	assert.Equal(t, "feat: a", branches[0].Commit.Subject)
+	assert.Empty(t, branches[0].Upstream)
+
+	assert.Equal(t, "master", branches[1].Name)
+	assert.False(t, branches[1].Current)
+	assert.Equal(t, "chore: base", branches[1].Commit.Subject)
+	assert.Equal(t, "upstream", branches[1].Upstream)
+
+	assert.Equal(t, "upstream", branches[2].Name)
+}
@@ -1,9 +1,12 @@
 package git
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // GitFile represents a file in git status
@@ -12,14 +15,56 @@ type GitFile struct {
 	Path   string
 }
 
+// IndexState returns the state of the file in the index, such as "modified"
+func (f GitFile) IndexState() string {
+	if len(f.Status) < 1 {
+		return ""
+	}
+	return StateName(f.Status[0])
+}
+
+// WorktreeState returns the state of the file in the working tree, such as "modified"
+func (f GitFile) WorktreeState() string {
+	if len(f.Status) < 2 {
+		return ""
+	}
+	return StateName(f.Status[1])
+}
+
+// StateName describes one letter of a two-letter status code
+func StateName(code byte) string {
+	switch code {
+	case 'M':
+		return "modified"
+	case 'A':
+		return "added"
+	case 'D':
+		return "deleted"
+	case 'R':
+		return "renamed"
+	case 'C':
+		return "copied"
+	case 'T':
+		return "typechange"
+	case 'U':
+		return "unmerged"
+	case '?':
+		return "untracked"
+	case '!':
+		return "ignored"
+	default:
+		return "unmodified"
+	}
+}
+
 // GetStatus is a fallback implementation that uses the git command-line tool.
 // It parses the output of "git status --porcelain" to get the status of files in the repository.
 // This should only be used when the go-git implementation fails.
-func GetStatus() ([]GitFile, error) {
-	cmd := exec.Command("git", "status", "--porcelain")
-	output, err := cmd.Output()
+func GetStatus(ctx context.Context) ([]GitFile, error) {
+	cmd := exec.CommandContext(ctx, "git", "status", "--porcelain")
+	output, err := runCommand(cmd, cmd.Output)
 	if err != nil {
-		return nil, fmt.Errorf("fallback git status failed: %w", err)
+		return nil, classify(fmt.Errorf("fallback git status failed: %w", err), output)
 	}
 
 	files := []GitFile{}
@@ -42,15 +87,32 @@ func GetStatus() ([]GitFile, error) {
 // StageFiles is a fallback implementation that uses the git command-line tool.
 // It stages the specified files using "git add".
 // This should only be used when the go-git implementation fails.
-func StageFiles(paths []string) error {
+func StageFiles(ctx context.Context, paths []string) error {
 	if len(paths) == 0 {
 		return nil
 	}
 
 	args := append([]string{"add", "--"}, paths...)
-	cmd := exec.Command("git", args...)
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("fallback git add failed: %w\nOutput: %s", err, output)
+	cmd := exec.CommandContext(ctx, "git", args...)
+	if output, err := runCommand(cmd, cmd.CombinedOutput); err != nil {
+		return classify(fmt.Errorf("fallback git add failed: %w\nOutput: %s", err, output), output)
+	}
+
+	return nil
+}
+
+// UnstageFiles is a fallback implementation that uses the git command-line tool.
+// It resets the specified paths in the index to HEAD using "git reset".
+// This should only be used when the go-git implementation fails.
+func UnstageFiles(ctx context.Context, paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+
+	args := append([]string{"reset", "-q", "--"}, paths...)
+	cmd := exec.CommandContext(ctx, "git", args...)
+	if output, err := runCommand(cmd, cmd.CombinedOutput); err != nil {
+		return classify(fmt.Errorf("fallback git reset failed: %w\nOutput: %s", err, output), output)
 	}
 
 	return nil
@@ -59,16 +121,180 @@ func StageFiles(paths []string) error {
 // Commit is a fallback implementation that uses the git command-line tool.
 // It creates a commit with the specified type and message.
 // This should only be used when the go-git implementation fails.
-func Commit(commitType, message string) error {
+func Commit(ctx context.Context, commitType, message string) error {
 	if commitType == "" || message == "" {
 		return fmt.Errorf("commit type and message cannot be empty")
 	}
 
 	fullMessage := fmt.Sprintf("%s: %s", commitType, message)
 
-	cmd := exec.Command("git", "commit", "-m", fullMessage)
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("fallback git commit failed: %w\nOutput: %s", err, output)
+	cmd := exec.CommandContext(ctx, "git", "commit", "-m", fullMessage)
+	if output, err := runCommand(cmd, cmd.CombinedOutput); err != nil {
+		return classifyCommit(ctx, fmt.Errorf("fallback git commit failed: %w\nOutput: %s", err, output), output, "")
+	}
+
+	return nil
+}
+
+// CommitFixup is a fallback implementation that uses the git command-line tool.
+// It commits the staged changes with a fixup! or amend! message for target.
+// This should only be used when the go-git implementation fails.
+func CommitFixup(ctx context.Context, kind FixupKind, target CommitInfo, message string) error {
+	fullMessage, err := FixupMessage(kind, target, message)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "commit", "-m", fullMessage)
+	if output, err := runCommand(cmd, cmd.CombinedOutput); err != nil {
+		return classifyCommit(ctx, fmt.Errorf("fallback git commit failed: %w\nOutput: %s", err, output), output, "")
+	}
+
+	return nil
+}
+
+// ListRemotes is a fallback implementation that uses the git command-line tool.
+// It parses the output of "git remote -v" into fetch and push URLs per remote.
+// This should only be used when the go-git implementation fails.
+func ListRemotes(ctx context.Context) ([]Remote, error) {
+	cmd := exec.CommandContext(ctx, "git", "remote", "-v")
+	output, err := runCommand(cmd, cmd.Output)
+	if err != nil {
+		return nil, fmt.Errorf("fallback git remote failed: %w", err)
+	}
+
+	remotes := []Remote{}
+	index := map[string]int{}
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+
+		name, url, kind := fields[0], fields[1], fields[2]
+		i, ok := index[name]
+		if !ok {
+			i = len(remotes)
+			index[name] = i
+			remotes = append(remotes, Remote{Name: name})
+		}
+
+		switch kind {
+		case "(fetch)":
+			remotes[i].FetchURL = url
+		case "(push)":
+			remotes[i].PushURLs = append(remotes[i].PushURLs, url)
+		}
+	}
+
+	return remotes, nil
+}
+
+// AddRemote is a fallback implementation that uses the git command-line tool.
+// It creates a remote using "git remote add".
+// This should only be used when the go-git implementation fails.
+func AddRemote(ctx context.Context, name, url string) error {
+	return runRemoteCommand(ctx, "add", name, url)
+}
+
+// RenameRemote is a fallback implementation that uses the git command-line tool.
+// It renames a remote using "git remote rename".
+// This should only be used when the go-git implementation fails.
+func RenameRemote(ctx context.Context, oldName, newName string) error {
+	return runRemoteCommand(ctx, "rename", oldName, newName)
+}
+
+// RemoveRemote is a fallback implementation that uses the git command-line tool.
+// It deletes a remote using "git remote remove".
+// This should only be used when the go-git implementation fails.
+func RemoveRemote(ctx context.Context, name string) error {
+	return runRemoteCommand(ctx, "remove", name)
+}
+
+// SetRemoteURL is a fallback implementation that uses the git command-line tool.
+// It changes the fetch or push URL of a remote using "git remote set-url".
+// This should only be used when the go-git implementation fails.
+func SetRemoteURL(ctx context.Context, name, url string, push bool) error {
+	if push {
+		return runRemoteCommand(ctx, "set-url", "--push", name, url)
+	}
+	return runRemoteCommand(ctx, "set-url", name, url)
+}
+
+// runRemoteCommand runs a "git remote" subcommand and wraps its output on failure
+func runRemoteCommand(ctx context.Context, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"remote"}, args...)...)
+	if output, err := runCommand(cmd, cmd.CombinedOutput); err != nil {
+		return fmt.Errorf("fallback git remote %s failed: %w\nOutput: %s", args[0], err, output)
+	}
+
+	return nil
+}
+
+// StageResolved is a fallback implementation that uses the git command-line tool.
+// It marks a conflicted path as resolved using "git add -A", which also records deletions.
+// This should only be used when the go-git implementation fails.
+func StageResolved(ctx context.Context, path string) error {
+	cmd := exec.CommandContext(ctx, "git", "add", "-A", "--", path)
+	if output, err := runCommand(cmd, cmd.CombinedOutput); err != nil {
+		return fmt.Errorf("fallback git add failed: %w\nOutput: %s", err, output)
+	}
+
+	return nil
+}
+
+// ListTags is a fallback implementation that uses the git command-line tool.
+// It lists tags and the commits they point to using "git for-each-ref".
+// This should only be used when the go-git implementation fails.
+func ListTags(ctx context.Context) ([]Tag, error) {
+	format := "%(refname:short)%00%(objecttype)%00%(*objectname)%00%(objectname)%00%(taggername)%00%(creatordate:unix)%00%(contents:subject)"
+	cmd := exec.CommandContext(ctx, "git", "for-each-ref", "--format="+format, "refs/tags")
+	output, err := runCommand(cmd, cmd.Output)
+	if err != nil {
+		return nil, fmt.Errorf("fallback git for-each-ref failed: %w", err)
+	}
+
+	tags := []Tag{}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 7 {
+			continue
+		}
+
+		tag := Tag{Name: fields[0], Hash: fields[3], Tagger: fields[4], Message: fields[6]}
+		if fields[1] == "tag" {
+			tag.Annotated = true
+			tag.Hash = fields[2]
+		}
+		if seconds, err := strconv.ParseInt(fields[5], 10, 64); err == nil {
+			tag.When = time.Unix(seconds, 0)
+		}
+		tags = append(tags, tag)
+	}
+
+	sortTags(tags)
+	return tags, nil
+}
+
+// CreateTag is a fallback implementation that uses the git command-line tool.
+// It creates an annotated tag at HEAD using "git tag -a".
+// This should only be used when the go-git implementation fails.
+func CreateTag(ctx context.Context, name, message string) error {
+	cmd := exec.CommandContext(ctx, "git", "tag", "-a", name, "-m", message)
+	if output, err := runCommand(cmd, cmd.CombinedOutput); err != nil {
+		return fmt.Errorf("fallback git tag failed: %w\nOutput: %s", err, output)
+	}
+
+	return nil
+}
+
+// DeleteTag is a fallback implementation that uses the git command-line tool.
+// It deletes a tag using "git tag -d".
+// This should only be used when the go-git implementation fails.
+func DeleteTag(ctx context.Context, name string) error {
+	cmd := exec.CommandContext(ctx, "git", "tag", "-d", name)
+	if output, err := runCommand(cmd, cmd.CombinedOutput); err != nil {
+		return fmt.Errorf("fallback git tag -d failed: %w\nOutput: %s", err, output)
 	}
 
 	return nil
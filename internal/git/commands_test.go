@@ -69,7 +69,7 @@ func TestGetStatus(t *testing.T) {
 			}
 
 			// Test the fallback implementation
-			files, err := GetStatus()
+			files, err := GetStatus(t.Context())
 			if (err != nil) != tc.wantErr {
 				t.Errorf("GetStatus() error = %v, wantErr %v", err, tc.wantErr)
 				return
@@ -81,7 +81,7 @@ func TestGetStatus(t *testing.T) {
 				t.Fatalf("Failed to create GitRepository: %v", err)
 			}
 
-			goGitFiles, err := repo.Status()
+			goGitFiles, err := repo.Status(t.Context())
 			if (err != nil) != tc.wantErr {
 				t.Errorf("GitRepository.Status() error = %v, wantErr %v", err, tc.wantErr)
 				return
@@ -136,7 +136,7 @@ func TestStageFiles(t *testing.T) {
 			// Skip the test for nonexistent file in empty paths case
 			if len(tc.paths) == 0 {
 				// Test fallback implementation
-				err := StageFiles(tc.paths)
+				err := StageFiles(t.Context(), tc.paths)
 				if (err != nil) != tc.wantErr {
 					t.Errorf("StageFiles() error = %v, wantErr %v", err, tc.wantErr)
 				}
@@ -147,14 +147,14 @@ func TestStageFiles(t *testing.T) {
 					t.Fatalf("Failed to create GitRepository: %v", err)
 				}
 
-				err = repo.Stage(tc.paths)
+				err = repo.Stage(t.Context(), tc.paths)
 				if (err != nil) != tc.wantErr {
 					t.Errorf("GitRepository.Stage() error = %v, wantErr %v", err, tc.wantErr)
 				}
 			} else {
 				// For nonexistent file test, we can assume it will fail but implementation details may vary
 				// Test fallback implementation
-				err1 := StageFiles(tc.paths)
+				err1 := StageFiles(t.Context(), tc.paths)
 
 				// Test go-git implementation
 				repo, err := NewGitRepository(repoPath)
@@ -162,7 +162,7 @@ func TestStageFiles(t *testing.T) {
 					t.Fatalf("Failed to create GitRepository: %v", err)
 				}
 
-				err2 := repo.Stage(tc.paths)
+				err2 := repo.Stage(t.Context(), tc.paths)
 
 				// At least one of them should fail for nonexistent file
 				if (err1 == nil) && (err2 == nil) && tc.wantErr {
@@ -227,7 +227,7 @@ func TestCommit(t *testing.T) {
 			// For empty type/message tests
 			if tc.commitType == "" || tc.message == "" {
 				// Test fallback implementation
-				err := Commit(tc.commitType, tc.message)
+				err := Commit(t.Context(), tc.commitType, tc.message)
 				if (err != nil) != tc.wantErr {
 					t.Errorf("Commit() error = %v, wantErr %v", err, tc.wantErr)
 				}
@@ -238,7 +238,7 @@ func TestCommit(t *testing.T) {
 					t.Fatalf("Failed to create GitRepository: %v", err)
 				}
 
-				err = repo.Commit(tc.commitType, tc.message)
+				err = repo.Commit(t.Context(), tc.commitType, tc.message)
 				if (err != nil) != tc.wantErr {
 					t.Errorf("GitRepository.Commit() error = %v, wantErr %v", err, tc.wantErr)
 				}
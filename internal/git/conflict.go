@@ -0,0 +1,417 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+)
+
+// Conflict marker prefixes as written by git merge, rebase and cherry-pick
+const (
+	oursMarker   = "<<<<<<<"
+	baseMarker   = "|||||||"
+	splitMarker  = "======="
+	theirsMarker = ">>>>>>>"
+)
+
+// Resolution is the choice made for a single conflict hunk
+type Resolution int
+
+const (
+	// Unresolved means no side has been picked yet
+	Unresolved Resolution = iota
+	// ResolveOurs keeps the lines from the current branch
+	ResolveOurs
+	// ResolveTheirs keeps the lines from the incoming change
+	ResolveTheirs
+	// ResolveBoth keeps our lines followed by their lines
+	ResolveBoth
+	// ResolveBase keeps the lines from the common ancestor
+	ResolveBase
+)
+
+// String returns a short label for the resolution
+func (r Resolution) String() string {
+	switch r {
+	case ResolveOurs:
+		return "ours"
+	case ResolveTheirs:
+		return "theirs"
+	case ResolveBoth:
+		return "both"
+	case ResolveBase:
+		return "base"
+	default:
+		return "unresolved"
+	}
+}
+
+// ConflictHunk is one conflict region with the base, ours and theirs sections
+type ConflictHunk struct {
+	OursLabel   string
+	TheirsLabel string
+	Ours        []string
+	Base        []string
+	Theirs      []string
+	HasBase     bool
+	Resolution  Resolution
+}
+
+// Lines returns the lines the hunk resolves to, or nil when it is unresolved
+func (h *ConflictHunk) Lines() []string {
+	switch h.Resolution {
+	case ResolveOurs:
+		return h.Ours
+	case ResolveTheirs:
+		return h.Theirs
+	case ResolveBoth:
+		return append(append([]string{}, h.Ours...), h.Theirs...)
+	case ResolveBase:
+		return h.Base
+	default:
+		return nil
+	}
+}
+
+// conflictSegment is either a run of clean lines or a conflict hunk
+type conflictSegment struct {
+	Lines []string
+	Hunk  *ConflictHunk
+}
+
+// ConflictFile is a file containing conflict markers split into clean lines and hunks
+type ConflictFile struct {
+	Path     string
+	Hunks    []*ConflictHunk
+	segments []conflictSegment
+	trailing bool // Whether the original content ended with a newline
+}
+
+// ParseConflicts splits content with conflict markers into clean segments and hunks.
+// Both the default "merge" and the "diff3" marker styles are understood.
+func ParseConflicts(path, content string) (*ConflictFile, error) {
+	file := &ConflictFile{
+		Path:     path,
+		trailing: strings.HasSuffix(content, "\n"),
+	}
+
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+
+	const (
+		stateClean = iota
+		stateOurs
+		stateBase
+		stateTheirs
+	)
+
+	state := stateClean
+	var clean []string
+	var hunk *ConflictHunk
+
+	for i, line := range lines {
+		switch {
+		case state == stateClean && strings.HasPrefix(line, oursMarker):
+			if len(clean) > 0 {
+				file.segments = append(file.segments, conflictSegment{Lines: clean})
+				clean = nil
+			}
+			hunk = &ConflictHunk{OursLabel: markerLabel(line, oursMarker)}
+			state = stateOurs
+
+		case state == stateOurs && strings.HasPrefix(line, baseMarker):
+			hunk.HasBase = true
+			state = stateBase
+
+		case (state == stateOurs || state == stateBase) && line == splitMarker:
+			state = stateTheirs
+
+		case state == stateTheirs && strings.HasPrefix(line, theirsMarker):
+			hunk.TheirsLabel = markerLabel(line, theirsMarker)
+			file.Hunks = append(file.Hunks, hunk)
+			file.segments = append(file.segments, conflictSegment{Hunk: hunk})
+			hunk = nil
+			state = stateClean
+
+		case state == stateOurs:
+			hunk.Ours = append(hunk.Ours, line)
+		case state == stateBase:
+			hunk.Base = append(hunk.Base, line)
+		case state == stateTheirs:
+			hunk.Theirs = append(hunk.Theirs, line)
+
+		default:
+			if state != stateClean {
+				return nil, fmt.Errorf("malformed conflict markers in %s at line %d", path, i+1)
+			}
+			clean = append(clean, line)
+		}
+	}
+
+	if state != stateClean {
+		return nil, fmt.Errorf("unterminated conflict hunk in %s", path)
+	}
+	if len(clean) > 0 {
+		file.segments = append(file.segments, conflictSegment{Lines: clean})
+	}
+
+	return file, nil
+}
+
+// markerLabel returns the text following a conflict marker, such as "HEAD"
+func markerLabel(line, marker string) string {
+	return strings.TrimSpace(strings.TrimPrefix(line, marker))
+}
+
+// Resolved reports whether every hunk has a resolution
+func (f *ConflictFile) Resolved() bool {
+	for _, h := range f.Hunks {
+		if h.Resolution == Unresolved {
+			return false
+		}
+	}
+	return true
+}
+
+// Content renders the file with every hunk replaced by its resolution
+func (f *ConflictFile) Content() (string, error) {
+	if !f.Resolved() {
+		return "", ErrUnresolvedHunks
+	}
+
+	var out []string
+	for _, seg := range f.segments {
+		if seg.Hunk != nil {
+			out = append(out, seg.Hunk.Lines()...)
+		} else {
+			out = append(out, seg.Lines...)
+		}
+	}
+
+	content := strings.Join(out, "\n")
+	if f.trailing && len(out) > 0 {
+		content += "\n"
+	}
+	return content, nil
+}
+
+// UnmergedCode returns the porcelain XY code for a path from the stages present in the index
+func UnmergedCode(base, ours, theirs bool) string {
+	switch {
+	case base && !ours && !theirs:
+		return "DD"
+	case !base && ours && !theirs:
+		return "AU"
+	case base && ours && !theirs:
+		return "UD"
+	case !base && !ours && theirs:
+		return "UA"
+	case base && !ours && theirs:
+		return "DU"
+	case !base && ours && theirs:
+		return "AA"
+	default:
+		return "UU"
+	}
+}
+
+// IsConflicted reports whether a two-letter status code describes an unmerged path
+func IsConflicted(status string) bool {
+	switch status {
+	case "DD", "AU", "UD", "UA", "DU", "AA", "UU":
+		return true
+	}
+	return false
+}
+
+// unmergedPaths reads the index and returns the XY code of every path with conflict stages
+func (g *GitRepository) unmergedPaths() (map[string]string, error) {
+	idx, err := g.repo.Storer.Index()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+
+	type stages struct{ base, ours, theirs bool }
+	found := map[string]*stages{}
+	for _, e := range idx.Entries {
+		if e.Stage == 0 {
+			continue
+		}
+
+		s, ok := found[e.Name]
+		if !ok {
+			s = &stages{}
+			found[e.Name] = s
+		}
+
+		switch e.Stage {
+		case index.AncestorMode:
+			s.base = true
+		case index.OurMode:
+			s.ours = true
+		case index.TheirMode:
+			s.theirs = true
+		}
+	}
+
+	codes := make(map[string]string, len(found))
+	for path, s := range found {
+		codes[path] = UnmergedCode(s.base, s.ours, s.theirs)
+	}
+
+	return codes, nil
+}
+
+// LoadConflict loads the conflicted file at path and parses its hunks.
+// When the markers carry no base section it is filled in from the index stages if possible.
+func (g *GitRepository) LoadConflict(ctx context.Context, path string) (*ConflictFile, error) {
+	if g.repo == nil {
+		return nil, errNotInitialized
+	}
+
+	content, err := os.ReadFile(filepath.Join(g.path, path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	file, err := ParseConflicts(path, string(content))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, h := range file.Hunks {
+		if h.HasBase {
+			return file, nil
+		}
+	}
+
+	// Best effort: rebuild diff3 markers from the index to recover the base of each hunk
+	if rebuilt, err := g.diff3Conflicts(ctx, path); err == nil && len(rebuilt.Hunks) == len(file.Hunks) {
+		for i, h := range file.Hunks {
+			h.Base = rebuilt.Hunks[i].Base
+			h.HasBase = rebuilt.Hunks[i].HasBase
+		}
+	}
+
+	return file, nil
+}
+
+// diff3Conflicts recreates the conflict with diff3 markers from stages 1-3 of the index
+func (g *GitRepository) diff3Conflicts(ctx context.Context, path string) (*ConflictFile, error) {
+	idx, err := g.repo.Storer.Index()
+	if err != nil {
+		return nil, err
+	}
+
+	blobs := map[index.Stage]plumbing.Hash{}
+	for _, e := range idx.Entries {
+		if e.Name == path && e.Stage != 0 {
+			blobs[e.Stage] = e.Hash
+		}
+	}
+
+	tmpDir, err := os.MkdirTemp("", "go-git-tui-merge")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	files := make([]string, 0, 3)
+	for _, stage := range []index.Stage{index.OurMode, index.AncestorMode, index.TheirMode} {
+		var data []byte
+		if hash, ok := blobs[stage]; ok {
+			blob, err := g.repo.BlobObject(hash)
+			if err != nil {
+				return nil, err
+			}
+			reader, err := blob.Reader()
+			if err != nil {
+				return nil, err
+			}
+			data, err = io.ReadAll(reader)
+			reader.Close()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		name := filepath.Join(tmpDir, fmt.Sprintf("stage%d", stage))
+		if err := os.WriteFile(name, data, 0o600); err != nil {
+			return nil, err
+		}
+		files = append(files, name)
+	}
+
+	// git merge-file exits with the number of conflicts, so only a missing output is fatal
+	cmd := exec.CommandContext(ctx, "git", "merge-file", "-p", "--diff3", files[0], files[1], files[2])
+	output, err := runCommand(cmd, cmd.Output)
+	if len(output) == 0 && err != nil {
+		return nil, err
+	}
+
+	return ParseConflicts(path, string(output))
+}
+
+// ResolveConflict writes the resolved content of a conflicted file and stages it
+func (g *GitRepository) ResolveConflict(ctx context.Context, path, content string) error {
+	if g.repo == nil {
+		return errNotInitialized
+	}
+
+	if err := os.WriteFile(filepath.Join(g.path, path), []byte(content), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return g.MarkResolved(ctx, path)
+}
+
+// MarkResolved drops the conflict stages of path from the index and stages the working copy
+func (g *GitRepository) MarkResolved(ctx context.Context, path string) error {
+	if g.repo == nil {
+		return errNotInitialized
+	}
+
+	idx, err := g.repo.Storer.Index()
+	if err != nil {
+		return fmt.Errorf("failed to read index: %w", err)
+	}
+
+	entries := idx.Entries[:0]
+	for _, e := range idx.Entries {
+		if e.Name == path && e.Stage != 0 {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	idx.Entries = entries
+
+	if err := g.repo.Storer.SetIndex(idx); err != nil {
+		return fmt.Errorf("failed to write index: %w", err)
+	}
+
+	wt, err := g.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	// A path deleted on the chosen side is resolved by removing it
+	if _, err := os.Lstat(filepath.Join(g.path, path)); os.IsNotExist(err) {
+		if _, err := wt.Remove(path); err != nil && !errors.Is(err, index.ErrEntryNotFound) {
+			return fmt.Errorf("failed to stage removal of %s: %w", path, err)
+		}
+		return nil
+	}
+
+	if _, err := wt.Add(path); err != nil {
+		return fmt.Errorf("failed to stage %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+package git
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const conflictContent = `line 1
+<<<<<<< HEAD
+ours
+||||||| base
+base
+=======
+theirs
+>>>>>>> feature
+line 2
+`
+
+func TestParseConflicts(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		resolution Resolution
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "GIVEN ours resolution THEN our lines replace the hunk",
+			content:    conflictContent,
+			resolution: ResolveOurs,
+			want:       "line 1\nours\nline 2\n",
+		},
+		{
+			name:       "GIVEN theirs resolution THEN their lines replace the hunk",
+			content:    conflictContent,
+			resolution: ResolveTheirs,
+			want:       "line 1\ntheirs\nline 2\n",
+		},
+		{
+			name:       "GIVEN both resolution THEN ours is followed by theirs",
+			content:    conflictContent,
+			resolution: ResolveBoth,
+			want:       "line 1\nours\ntheirs\nline 2\n",
+		},
+		{
+			name:       "GIVEN base resolution THEN the ancestor lines are kept",
+			content:    conflictContent,
+			resolution: ResolveBase,
+			want:       "line 1\nbase\nline 2\n",
+		},
+		{
+			name:       "GIVEN an unresolved hunk THEN content cannot be rendered",
+			content:    conflictContent,
+			resolution: Unresolved,
+			wantErr:    true,
+		},
+		{
+			name:       "GIVEN an unterminated hunk THEN parsing fails",
+			content:    "<<<<<<< HEAD\nours\n=======\n",
+			resolution: ResolveOurs,
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			file, err := ParseConflicts("file.txt", tc.content)
+			if err != nil {
+				assert.True(t, tc.wantErr, "unexpected parse error: %v", err)
+				return
+			}
+
+			require.Len(t, file.Hunks, 1)
+			hunk := file.Hunks[0]
+			assert.Equal(t, "HEAD", hunk.OursLabel)
+			assert.Equal(t, "feature", hunk.TheirsLabel)
+			assert.True(t, hunk.HasBase)
+
+			hunk.Resolution = tc.resolution
+			got, err := file.Content()
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrUnresolvedHunks)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestUnmergedCode(t *testing.T) {
+	tests := []struct {
+		base, ours, theirs bool
+		want               string
+	}{
+		{true, true, true, "UU"},
+		{false, true, true, "AA"},
+		{true, false, true, "DU"},
+		{true, true, false, "UD"},
+		{true, false, false, "DD"},
+		{false, true, false, "AU"},
+		{false, false, true, "UA"},
+	}
+
+	for _, tc := range tests {
+		t.Run("GIVEN stages THEN code is "+tc.want, func(t *testing.T) {
+			got := UnmergedCode(tc.base, tc.ours, tc.theirs)
+			assert.Equal(t, tc.want, got)
+			assert.True(t, IsConflicted(got))
+		})
+	}
+}
+
+// gitCommand builds a git command in dir with a fixed test identity
+func gitCommand(dir string, args ...string) *exec.Cmd {
+	cmd := exec.Command("git", append([]string{"-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	return cmd
+}
+
+// runGit runs a git command in dir and fails the test on error
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	if output, err := gitCommand(dir, args...).CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+}
+
+func TestStatusReportsAndResolvesConflicts(t *testing.T) {
+	repoPath := setupConflictedMerge(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	files, err := repo.Status(t.Context())
+	require.NoError(t, err)
+	assert.Contains(t, files, GitFile{Status: "UU", Path: "file.txt"})
+
+	conflict, err := repo.LoadConflict(t.Context(), "file.txt")
+	require.NoError(t, err)
+	require.Len(t, conflict.Hunks, 1)
+	assert.Equal(t, []string{"b"}, conflict.Hunks[0].Base)
+
+	conflict.Hunks[0].Resolution = ResolveTheirs
+	content, err := conflict.Content()
+	require.NoError(t, err)
+	require.NoError(t, repo.ResolveConflict(t.Context(), "file.txt", content))
+
+	files, err = repo.Status(t.Context())
+	require.NoError(t, err)
+	for _, f := range files {
+		assert.False(t, IsConflicted(f.Status), "%s should be resolved", f.Path)
+	}
+}
+
+func TestStageRefusesUnmergedPaths(t *testing.T) {
+	repoPath := setupConflictedMerge(t)
+	defer cleanupTestRepo(t, repoPath)
+	writeFile(t, repoPath, "other.txt", "other\n")
+
+	stages := func() string {
+		output, err := gitCommand(repoPath, "ls-files", "--stage", "file.txt").Output()
+		require.NoError(t, err)
+		return string(output)
+	}
+	before := stages()
+	require.Equal(t, 3, strings.Count(before, "\n"), "the merge leaves stages 1, 2 and 3")
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+	service := &DefaultGitService{repo: repo}
+	t.Chdir(repoPath)
+
+	for _, paths := range [][]string{{"file.txt"}, {"other.txt", "."}} {
+		err := repo.Stage(t.Context(), paths)
+		assert.ErrorIs(t, err, ErrUnmergedPaths, "staging %v", paths)
+
+		err = service.Stage(t.Context(), paths)
+		assert.ErrorIs(t, err, ErrUnmergedPaths, "the service does not fall back to git add for %v", paths)
+	}
+	assert.Equal(t, before, stages(), "the conflict stages are left alone")
+
+	// Other paths are still staged
+	require.NoError(t, repo.Stage(t.Context(), []string{"other.txt"}))
+	assert.Contains(t, gitPorcelain(t, repoPath), GitFile{Status: "A ", Path: "other.txt"})
+}
@@ -0,0 +1,43 @@
+package git
+
+import (
+	"context"
+	"sync"
+)
+
+// withContext runs fn holding mu and returns its result, or the error of ctx as soon as
+// ctx is done. go-git cannot interrupt operations such as computing the worktree status,
+// so cancelling does not stop the work: a cancelled fn keeps running in the background,
+// its result is dropped, and it keeps mu until it is done so that the next operation on
+// the repository waits for it instead of overlapping it.
+func withContext[T any](ctx context.Context, mu *sync.Mutex, fn func() (T, error)) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+
+	type result struct {
+		value T
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		// Work that was cancelled while waiting for the lock is not started
+		if err := ctx.Err(); err != nil {
+			done <- result{zero, err}
+			return
+		}
+		value, err := fn()
+		done <- result{value, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.value, r.err
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
@@ -0,0 +1,88 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithContext(t *testing.T) {
+	t.Run("GIVEN fn finishes THEN its result is returned", func(t *testing.T) {
+		value, err := withContext(t.Context(), &sync.Mutex{}, func() (int, error) { return 42, nil })
+		require.NoError(t, err)
+		assert.Equal(t, 42, value)
+	})
+
+	t.Run("GIVEN ctx is cancelled while fn runs THEN ctx's error is returned at once", func(t *testing.T) {
+		var mu sync.Mutex
+		ctx, cancel := context.WithCancel(t.Context())
+		release := make(chan struct{})
+		defer close(release)
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+		}()
+		_, err := withContext(ctx, &mu, func() (int, error) {
+			<-release
+			return 0, errors.New("not returned")
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("GIVEN fn was abandoned THEN the next call waits for it to finish", func(t *testing.T) {
+		var mu sync.Mutex
+		ctx, cancel := context.WithCancel(t.Context())
+		started := make(chan struct{})
+		release := make(chan struct{})
+		running := false // Only used with mu held
+
+		go func() {
+			<-started
+			cancel()
+		}()
+		_, err := withContext(ctx, &mu, func() (int, error) {
+			running = true
+			close(started)
+			<-release
+			running = false
+			return 0, nil
+		})
+		require.ErrorIs(t, err, context.Canceled)
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			close(release)
+		}()
+		overlapped, err := withContext(t.Context(), &mu, func() (bool, error) { return running, nil })
+		require.NoError(t, err)
+		assert.False(t, overlapped)
+	})
+}
+
+func TestOperationsHonourCancellation(t *testing.T) {
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "a.txt"), []byte("a"), 0o644))
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	_, err = repo.Status(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = repo.GetFileDiff(ctx, "a.txt")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	assert.ErrorIs(t, repo.gitExec(ctx, "status"), context.Canceled, "exec commands are not started")
+}
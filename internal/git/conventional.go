@@ -0,0 +1,163 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CommitType is a conventional commit type offered when committing
+type CommitType struct {
+	Name        string
+	Description string
+}
+
+// CommitTypes are the types offered by the commit TUI and accepted by scripted commits
+var CommitTypes = []CommitType{
+	{Name: "feat", Description: "A new feature"},
+	{Name: "fix", Description: "A bug fix"},
+	{Name: "docs", Description: "Documentation changes"},
+	{Name: "chore", Description: "Chores and maintenance tasks"},
+	{Name: "refactor", Description: "Code refactoring without functionality change"},
+	{Name: "test", Description: "Adding or fixing tests"},
+	{Name: "style", Description: "Code style/formatting changes"},
+}
+
+// MaxDescriptionLength is the longest commit description the commit TUI accepts
+const MaxDescriptionLength = 100
+
+// IsCommitType reports whether name is one of CommitTypes
+func IsCommitType(name string) bool {
+	for _, t := range CommitTypes {
+		if t.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CommitMessage is a conventional commit message being written
+type CommitMessage struct {
+	Type        string
+	Scope       string
+	Description string
+	Body        string
+	Breaking    bool
+}
+
+// scopePattern matches scopes such as "api" or "ui/add"
+var scopePattern = regexp.MustCompile(`^[\w./-]+$`)
+
+// Validate checks the message with the same rules as the commit TUI
+func (m CommitMessage) Validate() error {
+	if m.Type == "" {
+		return errors.New("commit type is required")
+	}
+	if !IsCommitType(m.Type) {
+		names := make([]string, 0, len(CommitTypes))
+		for _, t := range CommitTypes {
+			names = append(names, t.Name)
+		}
+		return fmt.Errorf("unknown commit type %q (expected one of %s)", m.Type, strings.Join(names, ", "))
+	}
+	if m.Scope != "" && !scopePattern.MatchString(m.Scope) {
+		return fmt.Errorf("invalid scope %q: use letters, digits, '.', '/', '_' or '-'", m.Scope)
+	}
+
+	description := strings.TrimSpace(m.Description)
+	if description == "" {
+		return errors.New("commit message cannot be empty")
+	}
+	if strings.Contains(description, "\n") {
+		return errors.New("commit message must be a single line; use the body for details")
+	}
+	if len([]rune(description)) > MaxDescriptionLength {
+		return fmt.Errorf("commit message is longer than %d characters", MaxDescriptionLength)
+	}
+
+	return nil
+}
+
+// Prefix returns the part of the subject before ": ", such as "fix(api)!"
+func (m CommitMessage) Prefix() string {
+	prefix := m.Type
+	if m.Scope != "" {
+		prefix += "(" + m.Scope + ")"
+	}
+	if m.Breaking {
+		prefix += "!"
+	}
+	return prefix
+}
+
+// Text returns the description followed by the body, which is what follows the prefix
+func (m CommitMessage) Text() string {
+	text := strings.TrimSpace(m.Description)
+	if body := strings.TrimSpace(m.Body); body != "" {
+		text += "\n\n" + body
+	}
+	return text
+}
+
+// String returns the full commit message
+func (m CommitMessage) String() string {
+	return m.Prefix() + ": " + m.Text()
+}
+
+// ConventionalCommit is a commit whose message follows the conventional commits format,
+// such as "feat(parser)!: support arrays"
+type ConventionalCommit struct {
+	Type        string
+	Scope       string
+	Breaking    bool
+	Description string
+	Body        string
+	Commit      CommitInfo
+}
+
+// conventionalHeader matches "type(scope)!: description"
+var conventionalHeader = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^)]*)\))?(!)?: (.+)$`)
+
+// ParseConventional parses the message of c. It returns false when the subject
+// does not follow the conventional commits format.
+func ParseConventional(c CommitInfo) (ConventionalCommit, bool) {
+	message := strings.TrimSpace(c.Message)
+	if message == "" {
+		message = c.Subject
+	}
+	header, body, _ := strings.Cut(message, "\n")
+
+	match := conventionalHeader.FindStringSubmatch(strings.TrimSpace(header))
+	if match == nil {
+		return ConventionalCommit{}, false
+	}
+
+	body = strings.TrimSpace(body)
+	breaking := match[3] == "!"
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(line, "BREAKING CHANGE:") || strings.HasPrefix(line, "BREAKING-CHANGE:") {
+			breaking = true
+		}
+	}
+
+	return ConventionalCommit{
+		Type:        strings.ToLower(match[1]),
+		Scope:       match[2],
+		Breaking:    breaking,
+		Description: match[4],
+		Body:        body,
+		Commit:      c,
+	}, true
+}
+
+// ParseConventionalCommits parses every conventional commit in commits, skipping the others
+func ParseConventionalCommits(commits []CommitInfo) []ConventionalCommit {
+	parsed := make([]ConventionalCommit, 0, len(commits))
+	for _, c := range commits {
+		if cc, ok := ParseConventional(c); ok {
+			parsed = append(parsed, cc)
+		}
+	}
+	return parsed
+}
@@ -0,0 +1,115 @@
+package git
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConventional(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    ConventionalCommit
+		wantOK  bool
+	}{
+		{
+			name:    "GIVEN a plain feature THEN type and description are parsed",
+			message: "feat: add release command",
+			want:    ConventionalCommit{Type: "feat", Description: "add release command"},
+			wantOK:  true,
+		},
+		{
+			name:    "GIVEN a scope and a bang THEN the change is breaking",
+			message: "fix(parser)!: reject empty input",
+			want:    ConventionalCommit{Type: "fix", Scope: "parser", Breaking: true, Description: "reject empty input"},
+			wantOK:  true,
+		},
+		{
+			name:    "GIVEN a BREAKING CHANGE footer THEN the change is breaking",
+			message: "refactor: rename flags\n\nBREAKING CHANGE: --all is now --everything",
+			want: ConventionalCommit{
+				Type:        "refactor",
+				Breaking:    true,
+				Description: "rename flags",
+				Body:        "BREAKING CHANGE: --all is now --everything",
+			},
+			wantOK: true,
+		},
+		{
+			name:    "GIVEN a free-form subject THEN it is not conventional",
+			message: "Merge branch 'main'",
+			wantOK:  false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			commit := CommitInfo{Message: tc.message}
+			got, ok := ParseConventional(commit)
+			assert.Equal(t, tc.wantOK, ok)
+			if !tc.wantOK {
+				return
+			}
+			tc.want.Commit = commit
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestCommitMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		msg     CommitMessage
+		want    string
+		wantErr string
+	}{
+		{
+			name: "GIVEN type and message THEN the subject is conventional",
+			msg:  CommitMessage{Type: "fix", Description: "handle nil"},
+			want: "fix: handle nil",
+		},
+		{
+			name: "GIVEN scope, body and breaking THEN all are rendered",
+			msg:  CommitMessage{Type: "feat", Scope: "api", Description: "drop v1", Body: "Use v2.", Breaking: true},
+			want: "feat(api)!: drop v1\n\nUse v2.",
+		},
+		{
+			name:    "GIVEN an unknown type THEN it is rejected",
+			msg:     CommitMessage{Type: "feature", Description: "x"},
+			wantErr: "unknown commit type",
+		},
+		{
+			name:    "GIVEN a blank message THEN it is rejected",
+			msg:     CommitMessage{Type: "fix", Description: "  "},
+			wantErr: "cannot be empty",
+		},
+		{
+			name:    "GIVEN a scope with spaces THEN it is rejected",
+			msg:     CommitMessage{Type: "fix", Scope: "my api", Description: "x"},
+			wantErr: "invalid scope",
+		},
+		{
+			name:    "GIVEN an overlong message THEN it is rejected",
+			msg:     CommitMessage{Type: "fix", Description: strings.Repeat("x", MaxDescriptionLength+1)},
+			wantErr: "longer than",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.msg.Validate()
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, tc.msg.String())
+
+			parsed, ok := ParseConventional(CommitInfo{Message: tc.msg.String()})
+			assert.True(t, ok)
+			assert.Equal(t, tc.msg.Breaking, parsed.Breaking)
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package git
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+const (
+	// diffCacheSize is the number of file diffs kept by a repository
+	diffCacheSize = 256
+	// prefetchWorkers is the number of diffs a repository has queued ahead at the same
+	// time. They are computed one at a time since go-git is not safe for concurrent use.
+	prefetchWorkers = 4
+)
+
+// diffKey identifies the content a file diff was computed from. The diff compares the
+// HEAD blob with the working tree file, which is identified by its modification time
+// and size; a changed index entry makes the diff stale as well.
+type diffKey struct {
+	path    string
+	head    plumbing.Hash // Blob in HEAD, zero when the file is new
+	index   plumbing.Hash // Blob in the index, zero when the file is untracked
+	modTime int64         // Working tree file, zero when it was deleted
+	size    int64
+}
+
+// diffCache is a least recently used cache of file diffs, safe for concurrent use.
+// It keeps one diff per path: a diff computed for other content is replaced.
+type diffCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // Most recently used first
+	entries  map[string]*list.Element
+}
+
+type diffCacheEntry struct {
+	key  diffKey
+	diff *DiffResult
+}
+
+func newDiffCache(capacity int) *diffCache {
+	return &diffCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// get returns the diff cached for key, if its content has not changed since
+func (c *diffCache) get(key diffKey) (*DiffResult, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key.path]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*diffCacheEntry)
+	if entry.key != key {
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	return entry.diff, true
+}
+
+// add caches diff for key, evicting the least recently used diff when the cache is full
+func (c *diffCache) add(key diffKey, diff *DiffResult) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key.path]; ok {
+		elem.Value = &diffCacheEntry{key: key, diff: diff}
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key.path] = c.order.PushFront(&diffCacheEntry{key: key, diff: diff})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*diffCacheEntry).key.path)
+	}
+}
+
+// len returns the number of cached diffs
+func (c *diffCache) len() int {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// diffKey returns the key of the diff of a file as it is now
+func (g *GitRepository) diffKey(filePath string) (diffKey, error) {
+	key := diffKey{path: filePath}
+
+	head, err := g.repo.Head()
+	if err != nil && !errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return key, err
+	}
+	if err == nil {
+		commit, err := g.repo.CommitObject(head.Hash())
+		if err != nil {
+			return key, err
+		}
+		tree, err := commit.Tree()
+		if err != nil {
+			return key, err
+		}
+		entry, err := tree.FindEntry(filePath)
+		switch {
+		case err == nil:
+			key.head = entry.Hash
+		case !errors.Is(err, object.ErrEntryNotFound) && !errors.Is(err, object.ErrDirectoryNotFound):
+			return key, err
+		}
+	}
+
+	idx, err := g.repo.Storer.Index()
+	if err != nil {
+		return key, err
+	}
+	if entry, err := idx.Entry(filePath); err == nil {
+		key.index = entry.Hash
+	}
+
+	info, err := os.Lstat(filepath.Join(g.path, filePath))
+	if err != nil && !os.IsNotExist(err) {
+		return key, err
+	}
+	if err == nil {
+		key.modTime = info.ModTime().UnixNano()
+		key.size = info.Size()
+	}
+
+	return key, nil
+}
+
+// CachedFileDiff returns the diff of a file if it was computed for its current content,
+// without computing it otherwise
+func (g *GitRepository) CachedFileDiff(ctx context.Context, filePath string) (*DiffResult, bool) {
+	if g.repo == nil || g.diffs == nil {
+		return nil, false
+	}
+
+	key, err := g.diffKey(filePath)
+	if err != nil {
+		return nil, false
+	}
+	return g.diffs.get(key)
+}
+
+// PrefetchDiffs computes the diffs of files into the diff cache, with at most
+// prefetchWorkers queued for all callers. Each worker holds the repository lock while it
+// computes its diff. It returns once every diff is cached or ctx is done; files whose
+// diff fails are skipped, the failure is reported when the diff is asked for.
+func (g *GitRepository) PrefetchDiffs(ctx context.Context, paths []string) error {
+	if g.repo == nil {
+		return errNotInitialized
+	}
+	if g.prefetchSlots == nil {
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		select {
+		case g.prefetchSlots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-g.prefetchSlots
+				wg.Done()
+			}()
+
+			// The slot is held until the diff is computed, so cancelled work still counts
+			g.mu.Lock()
+			defer g.mu.Unlock()
+			if ctx.Err() == nil {
+				_, _ = g.fileDiff(path)
+			}
+		}()
+	}
+
+	return nil
+}
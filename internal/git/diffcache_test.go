@@ -0,0 +1,182 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffCache(t *testing.T) {
+	cache := newDiffCache(2)
+	a := diffKey{path: "a.txt", size: 1}
+	b := diffKey{path: "b.txt", size: 1}
+	c := diffKey{path: "c.txt", size: 1}
+
+	cache.add(a, &DiffResult{Path: "a.txt"})
+	cache.add(b, &DiffResult{Path: "b.txt"})
+
+	t.Run("GIVEN a changed file THEN its diff is a miss", func(t *testing.T) {
+		changed := a
+		changed.size = 2
+		_, ok := cache.get(changed)
+		assert.False(t, ok)
+	})
+
+	t.Run("GIVEN a full cache THEN the least recently used diff is evicted", func(t *testing.T) {
+		_, ok := cache.get(a)
+		require.True(t, ok)
+
+		cache.add(c, &DiffResult{Path: "c.txt"})
+		assert.Equal(t, 2, cache.len())
+
+		_, ok = cache.get(b)
+		assert.False(t, ok, "b was used least recently")
+		_, ok = cache.get(a)
+		assert.True(t, ok)
+		_, ok = cache.get(c)
+		assert.True(t, ok)
+	})
+
+	t.Run("GIVEN a new diff for a path THEN it replaces the old one", func(t *testing.T) {
+		changed := c
+		changed.size = 3
+		cache.add(changed, &DiffResult{Path: "c.txt", Content: "new"})
+		assert.Equal(t, 2, cache.len())
+
+		diff, ok := cache.get(changed)
+		require.True(t, ok)
+		assert.Equal(t, "new", diff.Content)
+	})
+}
+
+func TestGetFileDiffCache(t *testing.T) {
+	setTestIdentity(t)
+
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+	commitFile(t, repoPath, "a.txt", "a\n", "chore: a")
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	write := func(content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(repoPath, "a.txt"), []byte(content), 0o644))
+	}
+
+	write("a\nb\n")
+	_, ok := repo.CachedFileDiff(t.Context(), "a.txt")
+	assert.False(t, ok, "nothing is cached before the first diff")
+
+	first, err := repo.GetFileDiff(t.Context(), "a.txt")
+	require.NoError(t, err)
+	cached, ok := repo.CachedFileDiff(t.Context(), "a.txt")
+	require.True(t, ok)
+	assert.Same(t, first, cached)
+
+	// Editing the file makes the cached diff stale
+	write("a\nb\nc\n")
+	_, ok = repo.CachedFileDiff(t.Context(), "a.txt")
+	assert.False(t, ok)
+	second, err := repo.GetFileDiff(t.Context(), "a.txt")
+	require.NoError(t, err)
+	assert.Equal(t, 2, second.Stats.Added)
+
+	// Committing the change makes HEAD match the file
+	runGit(t, repoPath, "commit", "-am", "feat: b and c")
+	_, err = repo.GetFileDiff(t.Context(), "a.txt")
+	assert.ErrorIs(t, err, ErrFileNotFound)
+}
+
+func TestPrefetchDiffs(t *testing.T) {
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	var paths []string
+	for i := range 10 {
+		path := fmt.Sprintf("file%d.txt", i)
+		require.NoError(t, os.WriteFile(filepath.Join(repoPath, path), []byte(path), 0o644))
+		paths = append(paths, path)
+	}
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.PrefetchDiffs(t.Context(), append(paths, "missing.txt")))
+	assert.Equal(t, len(paths), repo.diffs.len(), "failed diffs are skipped")
+	assert.Empty(t, repo.prefetchSlots, "every worker is done")
+
+	for _, path := range paths {
+		diff, ok := repo.CachedFileDiff(t.Context(), path)
+		require.True(t, ok, path)
+		assert.Equal(t, path, diff.Path)
+	}
+
+	t.Run("GIVEN a cancelled context THEN nothing is computed", func(t *testing.T) {
+		other, err := NewGitRepository(repoPath)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		assert.ErrorIs(t, other.PrefetchDiffs(ctx, paths), context.Canceled)
+		assert.Zero(t, other.diffs.len())
+	})
+}
+
+func TestConcurrentDiffsOnPackedRepository(t *testing.T) {
+	setTestIdentity(t)
+
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	var paths []string
+	for i := range 20 {
+		path := fmt.Sprintf("dir%d/file%02d.txt", i%3, i)
+		writeFile(t, repoPath, path, fmt.Sprintf("line %d\n", i))
+		paths = append(paths, path)
+	}
+	runGit(t, repoPath, "add", "-A")
+	runGit(t, repoPath, "commit", "-q", "-m", "chore: files")
+	// Objects read from packs share the caches of go-git's object storage
+	runGit(t, repoPath, "gc", "-q")
+	for _, path := range paths {
+		writeFile(t, repoPath, path, "changed\n")
+	}
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+	service := &DefaultGitService{repo: &lockedRepository{repo: repo}}
+
+	// Run with -race: prefetching, diffs and the status of a view use the repository at
+	// the same time
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, service.PrefetchDiffs(t.Context(), paths))
+	}()
+	go func() {
+		defer wg.Done()
+		for _, path := range paths {
+			diff, err := service.GetFileDiff(t.Context(), path)
+			if assert.NoError(t, err) {
+				assert.Equal(t, path, diff.Path)
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		files, err := service.Status(t.Context())
+		if assert.NoError(t, err) {
+			assert.Len(t, files, len(paths))
+		}
+	}()
+	wg.Wait()
+
+	assert.Equal(t, len(paths), repo.diffs.len())
+}
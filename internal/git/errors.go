@@ -0,0 +1,163 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+)
+
+// Errors returned by git operations. They are wrapped together with their cause, such as
+// the output of the git command or the go-git error, so test for them with errors.Is.
+var (
+	// ErrNotRepository is returned when the working directory is not inside a git repository
+	ErrNotRepository = errors.New("not a git repository")
+	// ErrCorruptRepository is returned when the repository data, such as the index, the
+	// configuration or an object, cannot be read
+	ErrCorruptRepository = errors.New("the git repository is corrupt")
+	// ErrNothingStaged is returned when committing without staged changes
+	ErrNothingStaged = errors.New("nothing staged to commit")
+	// ErrIdentityMissing is returned when committing without user.name and user.email
+	ErrIdentityMissing = errors.New("git user name and email are not configured")
+	// ErrUnmergedPaths is returned when an operation needs conflicts to be resolved first
+	ErrUnmergedPaths = errors.New("there are unmerged paths")
+	// ErrHookRejected is returned when a pre-commit or commit-msg hook fails
+	ErrHookRejected = errors.New("rejected by a git hook")
+	// ErrOperationInProgress is returned when a merge, rebase, cherry-pick or revert
+	// has to be concluded first. The error is an *InProgressError.
+	ErrOperationInProgress = errors.New("another git operation is in progress")
+	// ErrNoOperation is returned when continuing or aborting without an operation in progress
+	ErrNoOperation = errors.New("no operation in progress")
+	// ErrNoUpstream is returned when the current branch does not track a remote branch
+	ErrNoUpstream = errors.New("the current branch has no upstream")
+	// ErrFileNotFound is returned when a file has no changes in the status
+	ErrFileNotFound = errors.New("file not found in status")
+	// ErrUnresolvedHunks is returned when writing a file that still has unresolved hunks
+	ErrUnresolvedHunks = errors.New("file still has unresolved conflict hunks")
+)
+
+// errNotInitialized is returned by operations on a GitRepository that was never opened
+var errNotInitialized = fmt.Errorf("%w: repository not initialized", ErrNotRepository)
+
+// InProgressError is returned when an operation cannot start while a merge, rebase,
+// cherry-pick or revert is in progress. It matches ErrOperationInProgress.
+type InProgressError struct {
+	Action    string    // What could not be done, such as "start a rebase"
+	Operation Operation // The operation in progress
+}
+
+func (e *InProgressError) Error() string {
+	return fmt.Sprintf("cannot %s while a %s is in progress", e.Action, e.Operation)
+}
+
+// Is makes errors.Is(err, ErrOperationInProgress) true
+func (e *InProgressError) Is(target error) bool {
+	return target == ErrOperationInProgress
+}
+
+// gitMessages maps the messages git prints for known failures to their errors
+var gitMessages = []struct {
+	kind     error
+	messages []string
+}{
+	{ErrNotRepository, []string{"not a git repository"}},
+	{ErrCorruptRepository, []string{"index file", "bad config", "bad object", "is corrupt", "unable to read tree"}},
+	{ErrIdentityMissing, []string{"Author identity unknown", "Committer identity unknown", "Please tell me who you are", "empty ident name"}},
+	{ErrUnmergedPaths, []string{"unmerged files", "Unmerged paths", "resolve your current index first", "needs merge", "fix conflicts and then commit"}},
+	{ErrNothingStaged, []string{"nothing to commit", "nothing added to commit", "no changes added to commit"}},
+}
+
+// classify wraps err from a git command with the error for the failure git reported in
+// output or, for commands run with Output, on stderr. Errors that git does not describe
+// are returned unchanged.
+func classify(err error, output []byte) error {
+	text := string(output)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		text += string(exitErr.Stderr)
+	}
+
+	for _, known := range gitMessages {
+		for _, message := range known.messages {
+			if strings.Contains(text, message) {
+				return fmt.Errorf("%w: %w", known.kind, err)
+			}
+		}
+	}
+	return err
+}
+
+// corruptions are the go-git errors for repository data that cannot be read
+var corruptions = []error{
+	index.ErrMalformedSignature,
+	index.ErrInvalidChecksum,
+	plumbing.ErrObjectNotFound,
+	io.ErrUnexpectedEOF,
+}
+
+// classifyCorruption wraps err from go-git with ErrCorruptRepository when it reports
+// damaged repository data. Other errors are returned unchanged.
+func classifyCorruption(err error) error {
+	for _, corruption := range corruptions {
+		if errors.Is(err, corruption) {
+			return fmt.Errorf("%w: %w", ErrCorruptRepository, err)
+		}
+	}
+	return err
+}
+
+// commitHooks are the hooks that can reject a commit
+var commitHooks = []string{"pre-commit", "prepare-commit-msg", "commit-msg"}
+
+// hasCommitHooks reports whether the hooks directory, which is "hooks" inside the git
+// directory unless core.hooksPath says otherwise, contains a commit hook.
+// dir is the working directory for git, empty for the current directory.
+func hasCommitHooks(ctx context.Context, dir string) bool {
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--git-path", "hooks")
+	cmd.Dir = dir
+	output, err := runCommand(cmd, cmd.Output)
+	if err != nil {
+		return false
+	}
+
+	hooks := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(hooks) {
+		hooks = filepath.Join(dir, hooks)
+	}
+
+	for _, hook := range commitHooks {
+		if info, err := os.Stat(filepath.Join(hooks, hook)); err == nil && info.Mode()&0o111 != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// classifyCommit is classify for git commit, which prints nothing of its own when a
+// hook rejects the commit
+func classifyCommit(ctx context.Context, err error, output []byte, dir string) error {
+	if classified := classify(err, output); classified != err {
+		return classified
+	}
+	if hasCommitHooks(ctx, dir) {
+		return fmt.Errorf("%w: %w", ErrHookRejected, err)
+	}
+	return err
+}
+
+// HasStagedChanges reports whether any file in a status listing has changes in the index
+func HasStagedChanges(files []GitFile) bool {
+	for _, f := range files {
+		if f.Status != "??" && len(f.Status) == 2 && f.Status[0] != ' ' && !IsConflicted(f.Status) {
+			return true
+		}
+	}
+	return false
+}
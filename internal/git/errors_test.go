@@ -0,0 +1,181 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify(t *testing.T) {
+	cause := errors.New("exit status 128")
+
+	tests := []struct {
+		name   string
+		output string
+		want   error
+	}{
+		{
+			name:   "GIVEN git outside a repository THEN ErrNotRepository",
+			output: "fatal: not a git repository (or any of the parent directories): .git",
+			want:   ErrNotRepository,
+		},
+		{
+			name:   "GIVEN a damaged index THEN ErrCorruptRepository",
+			output: "fatal: .git/index: index file smaller than expected",
+			want:   ErrCorruptRepository,
+		},
+		{
+			name:   "GIVEN a commit without identity THEN ErrIdentityMissing",
+			output: "Author identity unknown\n\n*** Please tell me who you are.",
+			want:   ErrIdentityMissing,
+		},
+		{
+			name:   "GIVEN a commit with conflicts THEN ErrUnmergedPaths",
+			output: "error: Committing is not possible because you have unmerged files.",
+			want:   ErrUnmergedPaths,
+		},
+		{
+			name:   "GIVEN a commit without staged changes THEN ErrNothingStaged",
+			output: "no changes added to commit (use \"git add\" and/or \"git commit -a\")",
+			want:   ErrNothingStaged,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := classify(cause, []byte(tc.output))
+			assert.ErrorIs(t, err, tc.want)
+			assert.ErrorIs(t, err, cause, "the cause is kept")
+		})
+	}
+
+	t.Run("GIVEN an unknown failure THEN the error is unchanged", func(t *testing.T) {
+		assert.Equal(t, cause, classify(cause, []byte("fatal: something else")))
+	})
+}
+
+func TestCommitErrors(t *testing.T) {
+	setTestIdentity(t)
+
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+	commitFile(t, repoPath, "a.txt", "a", "chore: a")
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	err = repo.Commit(t.Context(), "feat", "empty")
+	assert.ErrorIs(t, err, ErrNothingStaged)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "a.txt"), []byte("b"), 0o644))
+	runGit(t, repoPath, "add", "a.txt")
+
+	// Without the environment or a global configuration there is no identity
+	t.Setenv("GIT_AUTHOR_NAME", "")
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	err = repo.Commit(t.Context(), "feat", "b")
+	assert.ErrorIs(t, err, ErrIdentityMissing)
+
+	setTestIdentity(t)
+	require.NoError(t, repo.Commit(t.Context(), "feat", "b"))
+}
+
+func TestCommitHookRejected(t *testing.T) {
+	setTestIdentity(t)
+
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+	commitFile(t, repoPath, "a.txt", "a", "chore: a")
+
+	hook := filepath.Join(repoPath, ".git", "hooks", "pre-commit")
+	require.NoError(t, os.MkdirAll(filepath.Dir(hook), 0o755))
+	require.NoError(t, os.WriteFile(hook, []byte("#!/bin/sh\necho 'lint failed'\nexit 1\n"), 0o755))
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "a.txt"), []byte("b"), 0o644))
+	runGit(t, repoPath, "add", "a.txt")
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+	service := &DefaultGitService{repo: repo}
+
+	// The fallback commits in the working directory
+	t.Chdir(repoPath)
+	err = service.Commit(t.Context(), "feat", "b")
+	assert.ErrorIs(t, err, ErrHookRejected)
+	assert.ErrorContains(t, err, "lint failed", "the hook output is kept")
+}
+
+func TestCommitUnmergedPaths(t *testing.T) {
+	repoPath := setupConflictedMerge(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, repo.Commit(t.Context(), "fix", "merge"), ErrUnmergedPaths)
+}
+
+func TestNotRepository(t *testing.T) {
+	_, err := NewGitRepository(t.TempDir())
+	assert.ErrorIs(t, err, ErrNotRepository)
+
+	_, err = findGitRepository("/")
+	assert.ErrorIs(t, err, ErrNotRepository)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestInProgressError(t *testing.T) {
+	var err error = &InProgressError{Action: "start a rebase", Operation: OperationMerge}
+
+	assert.ErrorIs(t, err, ErrOperationInProgress)
+	assert.EqualError(t, err, "cannot start a rebase while a merge is in progress")
+}
+
+func TestCorruptRepository(t *testing.T) {
+	setTestIdentity(t)
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{
+			name:    "GIVEN a truncated index THEN ErrCorruptRepository",
+			file:    "index",
+			content: "DIRC",
+		},
+		{
+			name:    "GIVEN a malformed index THEN ErrCorruptRepository",
+			file:    "index",
+			content: "garbage\n",
+		},
+		{
+			name:    "GIVEN a malformed configuration THEN ErrCorruptRepository",
+			file:    "config",
+			content: "[core\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repoPath := setupTestRepo(t)
+			defer cleanupTestRepo(t, repoPath)
+			commitFile(t, repoPath, "a.txt", "a", "chore: a")
+			require.NoError(t, os.WriteFile(filepath.Join(repoPath, ".git", tc.file), []byte(tc.content), 0o644))
+
+			// The fallback runs git in the working directory
+			t.Chdir(repoPath)
+			service, err := NewGitService()
+			if err == nil {
+				_, err = service.Status(t.Context())
+			}
+			assert.ErrorIs(t, err, ErrCorruptRepository)
+			assert.NotErrorIs(t, err, ErrNotRepository)
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// FixupKind selects how a fixup commit is folded into its target by autosquash
+type FixupKind int
+
+const (
+	// FixupCommit creates "fixup! <subject>", which keeps the target's message
+	FixupCommit FixupKind = iota
+	// AmendCommit creates "amend! <subject>", which also replaces the target's message
+	AmendCommit
+)
+
+// String returns the prefix git uses for the kind
+func (k FixupKind) String() string {
+	if k == AmendCommit {
+		return "amend!"
+	}
+	return "fixup!"
+}
+
+// fixupPrefixes are the subject prefixes recognised by "git rebase --autosquash"
+var fixupPrefixes = []string{"fixup! ", "amend! ", "squash! "}
+
+// IsFixupSubject reports whether a commit subject will be folded by autosquash
+func IsFixupSubject(subject string) bool {
+	for _, prefix := range fixupPrefixes {
+		if strings.HasPrefix(subject, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// FixupMessage builds the commit message that targets a commit for autosquash.
+// For amend commits, message is the replacement message for the target.
+func FixupMessage(kind FixupKind, target CommitInfo, message string) (string, error) {
+	subject := fmt.Sprintf("%s %s", kind, target.Subject)
+	if kind != AmendCommit {
+		return subject, nil
+	}
+
+	if strings.TrimSpace(message) == "" {
+		return "", errors.New("amend commits need a replacement message")
+	}
+	return subject + "\n\n" + message, nil
+}
+
+// upstreamRef returns the remote-tracking ref of the current branch, or "" without an upstream
+func (g *GitRepository) upstreamRef() (plumbing.ReferenceName, error) {
+	head, err := g.repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	if !head.Name().IsBranch() {
+		return "", nil
+	}
+
+	cfg, err := g.repo.Config()
+	if err != nil {
+		return "", fmt.Errorf("failed to get git config: %w", err)
+	}
+
+	return branchUpstream(cfg, head.Name().Short()), nil
+}
+
+// branchUpstream returns the upstream configured for the local branch name, empty when
+// there is none
+func branchUpstream(cfg *config.Config, name string) plumbing.ReferenceName {
+	branch, ok := cfg.Branches[name]
+	if !ok || branch.Remote == "" || branch.Merge == "" {
+		return ""
+	}
+
+	// A remote of "." tracks a local branch
+	if branch.Remote == "." {
+		return branch.Merge
+	}
+	return plumbing.NewRemoteReferenceName(branch.Remote, branch.Merge.Short())
+}
+
+// Upstream returns the remote-tracking branch of the current branch, such as "origin/main".
+// It returns an empty string when the branch has no upstream configured.
+func (g *GitRepository) Upstream(ctx context.Context) (string, error) {
+	if g.repo == nil {
+		return "", errNotInitialized
+	}
+
+	name, err := g.upstreamRef()
+	if err != nil || name == "" {
+		return "", err
+	}
+	return name.Short(), nil
+}
+
+// upstreamBase returns the merge base of HEAD and its upstream
+func (g *GitRepository) upstreamBase() (*object.Commit, error) {
+	name, err := g.upstreamRef()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, ErrNoUpstream
+	}
+
+	head, err := g.resolveCommit("HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := g.repo.Reference(name, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve upstream %s: %w", name.Short(), err)
+	}
+
+	other, err := g.repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upstream commit: %w", err)
+	}
+
+	bases, err := head.MergeBase(other)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge base with %s: %w", name.Short(), err)
+	}
+	if len(bases) == 0 {
+		return nil, fmt.Errorf("HEAD has no common history with %s", name.Short())
+	}
+
+	return bases[0], nil
+}
+
+// UnpushedCommits returns the commits on the current branch that are not on its upstream,
+// newest first. Without an upstream the most recent commits are returned instead.
+func (g *GitRepository) UnpushedCommits(ctx context.Context, limit int) ([]CommitInfo, error) {
+	if g.repo == nil {
+		return nil, errNotInitialized
+	}
+
+	if upstream, err := g.Upstream(ctx); err != nil || upstream == "" {
+		return g.Log(ctx, LogOptions{Limit: limit})
+	}
+
+	base, err := g.upstreamBase()
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := g.CommitsBetween(ctx, base.Hash.String(), "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	// Reverse into newest-first order to match Log
+	for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+		commits[i], commits[j] = commits[j], commits[i]
+	}
+	if limit > 0 && len(commits) > limit {
+		commits = commits[:limit]
+	}
+
+	return commits, nil
+}
+
+// CommitFixup commits the staged changes as a fixup or amend commit for target
+func (g *GitRepository) CommitFixup(ctx context.Context, kind FixupKind, target CommitInfo, message string) error {
+	if g.repo == nil {
+		return errNotInitialized
+	}
+
+	fullMessage, err := FixupMessage(kind, target, message)
+	if err != nil {
+		return err
+	}
+
+	wt, err := g.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	author, err := g.commitAuthor(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = wt.Commit(fullMessage, &git.CommitOptions{Author: author})
+	if err != nil {
+		return fmt.Errorf("failed to create %s commit: %w", kind, err)
+	}
+
+	return nil
+}
+
+// Autosquash folds fixup!, amend! and squash! commits into their targets by rebasing
+// the commits that are not on upstream. Like StartRebase it returns the state afterwards,
+// which is still in progress when git stopped on a conflict.
+func (g *GitRepository) Autosquash(ctx context.Context) (*RepoState, error) {
+	if g.repo == nil {
+		return nil, errNotInitialized
+	}
+
+	state, err := g.State(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if state.InProgress() {
+		return nil, &InProgressError{Action: "autosquash", Operation: state.Operation}
+	}
+
+	base, err := g.upstreamBase()
+	if err != nil {
+		return nil, err
+	}
+
+	// Accept the todo list git generates, which already has the fixups moved into place
+	runErr := g.gitExecEnv(ctx, []string{"GIT_SEQUENCE_EDITOR=true"}, "rebase", "-i", "--autosquash", base.Hash.String())
+
+	state, err = g.State(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if runErr != nil && !state.InProgress() {
+		return nil, runErr
+	}
+
+	return state, nil
+}
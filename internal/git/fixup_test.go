@@ -0,0 +1,109 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixupMessage(t *testing.T) {
+	target := CommitInfo{Subject: "feat: add parser"}
+
+	tests := []struct {
+		name    string
+		kind    FixupKind
+		message string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "GIVEN a fixup THEN only the target subject is referenced",
+			kind: FixupCommit,
+			want: "fixup! feat: add parser",
+		},
+		{
+			name:    "GIVEN an amend THEN the replacement message follows the subject",
+			kind:    AmendCommit,
+			message: "feat: add config parser",
+			want:    "amend! feat: add parser\n\nfeat: add config parser",
+		},
+		{
+			name:    "GIVEN an amend without a message THEN it is rejected",
+			kind:    AmendCommit,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FixupMessage(tc.kind, target, tc.message)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.True(t, IsFixupSubject(got))
+		})
+	}
+}
+
+func TestCommitFixupAndAutosquash(t *testing.T) {
+	setTestIdentity(t)
+
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	commitFile(t, repoPath, "base.txt", "base", "chore: base")
+	runGit(t, repoPath, "branch", "upstream")
+	runGit(t, repoPath, "branch", "--set-upstream-to=upstream")
+	commitFile(t, repoPath, "a.txt", "a", "feat: a")
+	commitFile(t, repoPath, "b.txt", "b", "feat: b")
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	upstream, err := repo.Upstream(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "upstream", upstream)
+
+	unpushed, err := repo.UnpushedCommits(t.Context(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"feat: b", "feat: a"}, subjects(unpushed))
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "a.txt"), []byte("a2"), 0o644))
+	runGit(t, repoPath, "add", "a.txt")
+	require.NoError(t, repo.CommitFixup(t.Context(), FixupCommit, unpushed[1], ""))
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "b.txt"), []byte("b2"), 0o644))
+	runGit(t, repoPath, "add", "b.txt")
+	require.NoError(t, repo.CommitFixup(t.Context(), AmendCommit, unpushed[0], "feat: b amended"))
+
+	state, err := repo.Autosquash(t.Context())
+	require.NoError(t, err)
+	assert.False(t, state.InProgress())
+
+	log, err := repo.Log(t.Context(), LogOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"feat: b amended", "feat: a", "chore: base"}, subjects(log))
+
+	content, err := os.ReadFile(filepath.Join(repoPath, "a.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "a2", string(content))
+}
+
+func TestAutosquashRequiresUpstream(t *testing.T) {
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	commitFile(t, repoPath, "a.txt", "a", "feat: a")
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	_, err = repo.Autosquash(t.Context())
+	assert.Error(t, err)
+}
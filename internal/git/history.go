@@ -0,0 +1,163 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// FileRevision is a commit that changed a file
+type FileRevision struct {
+	Commit  CommitInfo
+	Path    string // Path of the file in this commit
+	OldPath string // Path in the parent commit when this commit renamed the file
+}
+
+// FileHistory returns the commits that changed path, newest first, following renames
+func (g *GitRepository) FileHistory(ctx context.Context, path string, limit int) ([]FileRevision, error) {
+	if g.repo == nil {
+		return nil, errNotInitialized
+	}
+
+	head, err := g.resolveCommit("HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	// The filter is consulted lazily as the log is walked, so switching current to the
+	// old name at a rename makes older commits match the file under its previous path
+	current := path
+	iter, err := g.repo.Log(&git.LogOptions{
+		From:       head.Hash,
+		Order:      git.LogOrderCommitterTime,
+		PathFilter: func(p string) bool { return p == current },
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history of %s: %w", path, err)
+	}
+	defer iter.Close()
+
+	var revisions []FileRevision
+	err = iter.ForEach(func(c *object.Commit) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if limit > 0 && len(revisions) >= limit {
+			return storer.ErrStop
+		}
+
+		oldPath, err := renamedFrom(c, current)
+		if err != nil {
+			return err
+		}
+
+		revisions = append(revisions, FileRevision{Commit: newCommitInfo(c), Path: current, OldPath: oldPath})
+		if oldPath != "" {
+			current = oldPath
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history of %s: %w", path, err)
+	}
+
+	return revisions, nil
+}
+
+// renamedFrom returns the previous path of a file that commit c renamed to path, or ""
+func renamedFrom(c *object.Commit, path string) (string, error) {
+	if c.NumParents() == 0 {
+		return "", nil
+	}
+
+	parent, err := c.Parent(0)
+	if err != nil {
+		return "", fmt.Errorf("failed to get parent commit: %w", err)
+	}
+
+	// Only look for renames when the file is new in this commit
+	if _, err := parent.File(path); err == nil {
+		return "", nil
+	}
+
+	parentTree, err := parent.Tree()
+	if err != nil {
+		return "", fmt.Errorf("failed to get parent tree: %w", err)
+	}
+	tree, err := c.Tree()
+	if err != nil {
+		return "", fmt.Errorf("failed to get commit tree: %w", err)
+	}
+
+	changes, err := object.DiffTreeWithOptions(context.Background(), parentTree, tree, object.DefaultDiffTreeOptions)
+	if err != nil {
+		return "", fmt.Errorf("failed to diff commit: %w", err)
+	}
+
+	for _, change := range changes {
+		if change.To.Name == path && change.From.Name != "" && change.From.Name != path {
+			return change.From.Name, nil
+		}
+	}
+
+	return "", nil
+}
+
+// FileDiffAt returns the change a commit made to a single file
+func (g *GitRepository) FileDiffAt(ctx context.Context, hash, path string) (*DiffResult, error) {
+	diffs, err := g.CommitDiff(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, diff := range diffs {
+		if diff.Path == path {
+			return &diff, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s was not changed in %s", path, hash)
+}
+
+// RestoreFileAt overwrites dest in the working tree with the content of path at rev.
+// The change is left unstaged.
+func (g *GitRepository) RestoreFileAt(ctx context.Context, rev, path, dest string) error {
+	if g.repo == nil {
+		return errNotInitialized
+	}
+
+	commit, err := g.resolveCommit(rev)
+	if err != nil {
+		return err
+	}
+
+	file, err := commit.File(path)
+	if err != nil {
+		return fmt.Errorf("%s does not exist at %s: %w", path, rev, err)
+	}
+
+	content, err := file.Contents()
+	if err != nil {
+		return fmt.Errorf("failed to read %s at %s: %w", path, rev, err)
+	}
+
+	mode, err := file.Mode.ToOSFileMode()
+	if err != nil {
+		mode = 0o644
+	}
+
+	fullPath := filepath.Join(g.path, filepath.FromSlash(dest))
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", dest, err)
+	}
+	if err := os.WriteFile(fullPath, []byte(content), mode.Perm()); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", dest, err)
+	}
+
+	return nil
+}
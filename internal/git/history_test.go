@@ -0,0 +1,51 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileHistoryFollowsRenames(t *testing.T) {
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	content := "line 1\nline 2\nline 3\nline 4\n"
+	commitFile(t, repoPath, "old.txt", content, "feat: add old")
+	commitFile(t, repoPath, "other.txt", "x", "chore: unrelated")
+	commitFile(t, repoPath, "old.txt", content+"line 5\n", "feat: extend old")
+	runGit(t, repoPath, "mv", "old.txt", "new.txt")
+	runGit(t, repoPath, "commit", "-m", "refactor: rename")
+	commitFile(t, repoPath, "new.txt", content+"line 5\nline 6\n", "feat: extend new")
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	history, err := repo.FileHistory(t.Context(), "new.txt", 0)
+	require.NoError(t, err)
+
+	var got []string
+	for _, rev := range history {
+		got = append(got, rev.Commit.Subject+" @ "+rev.Path)
+	}
+	assert.Equal(t, []string{
+		"feat: extend new @ new.txt",
+		"refactor: rename @ new.txt",
+		"feat: extend old @ old.txt",
+		"feat: add old @ old.txt",
+	}, got)
+	assert.Equal(t, "old.txt", history[1].OldPath)
+
+	diff, err := repo.FileDiffAt(t.Context(), history[2].Commit.Hash, history[2].Path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, diff.Stats.Added)
+
+	// Restoring the oldest revision writes its content under the current name
+	require.NoError(t, repo.RestoreFileAt(t.Context(), history[3].Commit.Hash, history[3].Path, "new.txt"))
+	restored, err := os.ReadFile(filepath.Join(repoPath, "new.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, content, string(restored))
+}
@@ -0,0 +1,277 @@
+package git
+
+import (
+	"context"
+	"time"
+)
+
+// lockedRepository holds the lock of the repository it wraps during every operation, so
+// that concurrent callers, such as the views of the dashboard loading at the same time,
+// take turns using go-git. Operations that do the work in goroutines of their own take
+// the lock there.
+type lockedRepository struct {
+	repo *GitRepository
+}
+
+var _ GitRepositoryInterface = (*lockedRepository)(nil)
+
+func (r *lockedRepository) Status(ctx context.Context) ([]GitFile, error) {
+	// withContext holds the lock while the status is computed
+	return r.repo.Status(ctx)
+}
+
+func (r *lockedRepository) Stage(ctx context.Context, paths []string) error {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.Stage(ctx, paths)
+}
+
+func (r *lockedRepository) Unstage(ctx context.Context, paths []string) error {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.Unstage(ctx, paths)
+}
+
+func (r *lockedRepository) Ignore(ctx context.Context, paths []string) error {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.Ignore(ctx, paths)
+}
+
+func (r *lockedRepository) DeleteUntracked(ctx context.Context, paths []string) error {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.DeleteUntracked(ctx, paths)
+}
+
+func (r *lockedRepository) Commit(ctx context.Context, commitType, message string) error {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.Commit(ctx, commitType, message)
+}
+
+func (r *lockedRepository) GetCurrentBranch(ctx context.Context) (string, error) {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.GetCurrentBranch(ctx)
+}
+
+func (r *lockedRepository) GetFileDiff(ctx context.Context, filePath string) (*DiffResult, error) {
+	// withContext holds the lock while the diff is computed
+	return r.repo.GetFileDiff(ctx, filePath)
+}
+
+func (r *lockedRepository) CachedFileDiff(ctx context.Context, filePath string) (*DiffResult, bool) {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.CachedFileDiff(ctx, filePath)
+}
+
+func (r *lockedRepository) PrefetchDiffs(ctx context.Context, paths []string) error {
+	// Every worker holds the lock while it computes a diff
+	return r.repo.PrefetchDiffs(ctx, paths)
+}
+
+func (r *lockedRepository) Watch(ctx context.Context, debounce time.Duration) (<-chan struct{}, error) {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.Watch(ctx, debounce)
+}
+
+func (r *lockedRepository) LoadConflict(ctx context.Context, path string) (*ConflictFile, error) {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.LoadConflict(ctx, path)
+}
+
+func (r *lockedRepository) ResolveConflict(ctx context.Context, path, content string) error {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.ResolveConflict(ctx, path, content)
+}
+
+func (r *lockedRepository) MarkResolved(ctx context.Context, path string) error {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.MarkResolved(ctx, path)
+}
+
+func (r *lockedRepository) State(ctx context.Context) (*RepoState, error) {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.State(ctx)
+}
+
+func (r *lockedRepository) ContinueOperation(ctx context.Context, message string) error {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.ContinueOperation(ctx, message)
+}
+
+func (r *lockedRepository) AbortOperation(ctx context.Context) error {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.AbortOperation(ctx)
+}
+
+func (r *lockedRepository) Log(ctx context.Context, opts LogOptions) ([]CommitInfo, error) {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.Log(ctx, opts)
+}
+
+func (r *lockedRepository) CommitsBetween(ctx context.Context, base, head string) ([]CommitInfo, error) {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.CommitsBetween(ctx, base, head)
+}
+
+func (r *lockedRepository) StartRebase(ctx context.Context, base string, steps []RebaseStep) (*RepoState, error) {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.StartRebase(ctx, base, steps)
+}
+
+func (r *lockedRepository) Upstream(ctx context.Context) (string, error) {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.Upstream(ctx)
+}
+
+func (r *lockedRepository) BranchStatus(ctx context.Context) (*BranchStatus, error) {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.BranchStatus(ctx)
+}
+
+func (r *lockedRepository) Branches(ctx context.Context) ([]Branch, error) {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.Branches(ctx)
+}
+
+func (r *lockedRepository) UnpushedCommits(ctx context.Context, limit int) ([]CommitInfo, error) {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.UnpushedCommits(ctx, limit)
+}
+
+func (r *lockedRepository) CommitFixup(ctx context.Context, kind FixupKind, target CommitInfo, message string) error {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.CommitFixup(ctx, kind, target, message)
+}
+
+func (r *lockedRepository) Autosquash(ctx context.Context) (*RepoState, error) {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.Autosquash(ctx)
+}
+
+func (r *lockedRepository) Blame(ctx context.Context, path, rev string) (*BlameResult, error) {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.Blame(ctx, path, rev)
+}
+
+func (r *lockedRepository) CommitDiff(ctx context.Context, hash string) ([]DiffResult, error) {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.CommitDiff(ctx, hash)
+}
+
+func (r *lockedRepository) FileHistory(ctx context.Context, path string, limit int) ([]FileRevision, error) {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.FileHistory(ctx, path, limit)
+}
+
+func (r *lockedRepository) FileDiffAt(ctx context.Context, hash, path string) (*DiffResult, error) {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.FileDiffAt(ctx, hash, path)
+}
+
+func (r *lockedRepository) RestoreFileAt(ctx context.Context, rev, path, dest string) error {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.RestoreFileAt(ctx, rev, path, dest)
+}
+
+func (r *lockedRepository) CherryPick(ctx context.Context, commits []CommitInfo) (*PickResult, error) {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.CherryPick(ctx, commits)
+}
+
+func (r *lockedRepository) Revert(ctx context.Context, commits []CommitInfo) (*PickResult, error) {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.Revert(ctx, commits)
+}
+
+func (r *lockedRepository) Tags(ctx context.Context) ([]Tag, error) {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.Tags(ctx)
+}
+
+func (r *lockedRepository) CreateTag(ctx context.Context, name, message string) error {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.CreateTag(ctx, name, message)
+}
+
+func (r *lockedRepository) DeleteTag(ctx context.Context, name string) error {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.DeleteTag(ctx, name)
+}
+
+func (r *lockedRepository) PlanRelease(ctx context.Context) (*ReleasePlan, error) {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.PlanRelease(ctx)
+}
+
+func (r *lockedRepository) PreviousRelease(ctx context.Context, rev string) (*Tag, error) {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.PreviousRelease(ctx, rev)
+}
+
+func (r *lockedRepository) ReleaseCommits(ctx context.Context, from, to string) ([]CommitInfo, error) {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.ReleaseCommits(ctx, from, to)
+}
+
+func (r *lockedRepository) Remotes(ctx context.Context) ([]Remote, error) {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.Remotes(ctx)
+}
+
+func (r *lockedRepository) AddRemote(ctx context.Context, name, url string) error {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.AddRemote(ctx, name, url)
+}
+
+func (r *lockedRepository) RenameRemote(ctx context.Context, oldName, newName string) error {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.RenameRemote(ctx, oldName, newName)
+}
+
+func (r *lockedRepository) RemoveRemote(ctx context.Context, name string) error {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.RemoveRemote(ctx, name)
+}
+
+func (r *lockedRepository) SetRemoteURL(ctx context.Context, name, url string, push bool) error {
+	r.repo.mu.Lock()
+	defer r.repo.mu.Unlock()
+	return r.repo.SetRemoteURL(ctx, name, url, push)
+}
@@ -0,0 +1,151 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// CommitInfo is a summary of a commit for display in lists
+type CommitInfo struct {
+	Hash      string
+	ShortHash string
+	Subject   string
+	Message   string
+	Author    string
+	Email     string
+	When      time.Time
+	Parents   []string
+}
+
+// LogOptions controls which commits Log returns
+type LogOptions struct {
+	From  string // Revision to start from, HEAD when empty
+	Limit int    // Maximum number of commits, unlimited when zero
+}
+
+// newCommitInfo converts a go-git commit into a CommitInfo
+func newCommitInfo(c *object.Commit) CommitInfo {
+	hash := c.Hash.String()
+	parents := make([]string, 0, len(c.ParentHashes))
+	for _, p := range c.ParentHashes {
+		parents = append(parents, p.String())
+	}
+
+	subject, _, _ := strings.Cut(strings.TrimSpace(c.Message), "\n")
+
+	return CommitInfo{
+		Hash:      hash,
+		ShortHash: hash[:7],
+		Subject:   subject,
+		Message:   c.Message,
+		Author:    c.Author.Name,
+		Email:     c.Author.Email,
+		When:      c.Author.When,
+		Parents:   parents,
+	}
+}
+
+// resolveCommit resolves a revision such as "HEAD~2", a branch or a hash to a commit
+func (g *GitRepository) resolveCommit(rev string) (*object.Commit, error) {
+	if rev == "" {
+		rev = "HEAD"
+	}
+
+	hash, err := g.repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", rev, err)
+	}
+
+	commit, err := g.repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s: %w", rev, err)
+	}
+
+	return commit, nil
+}
+
+// Log returns commits reachable from opts.From, newest first
+func (g *GitRepository) Log(ctx context.Context, opts LogOptions) ([]CommitInfo, error) {
+	if g.repo == nil {
+		return nil, errNotInitialized
+	}
+
+	start, err := g.resolveCommit(opts.From)
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := g.repo.Log(&git.LogOptions{
+		From:  start.Hash,
+		Order: git.LogOrderCommitterTime,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log: %w", err)
+	}
+	defer iter.Close()
+
+	var commits []CommitInfo
+	err = iter.ForEach(func(c *object.Commit) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if opts.Limit > 0 && len(commits) >= opts.Limit {
+			return storer.ErrStop
+		}
+		commits = append(commits, newCommitInfo(c))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log: %w", err)
+	}
+
+	return commits, nil
+}
+
+// CommitsBetween returns the first-parent commits after base up to head, oldest first.
+// Merge commits are skipped, matching what a rebase would replay.
+func (g *GitRepository) CommitsBetween(ctx context.Context, base, head string) ([]CommitInfo, error) {
+	if g.repo == nil {
+		return nil, errNotInitialized
+	}
+
+	baseCommit, err := g.resolveCommit(base)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := g.resolveCommit(head)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []CommitInfo
+	for commit.Hash != baseCommit.Hash {
+		if commit.NumParents() <= 1 {
+			commits = append(commits, newCommitInfo(commit))
+		}
+
+		if commit.NumParents() == 0 {
+			return nil, fmt.Errorf("%s is not an ancestor of %s", base, head)
+		}
+
+		commit, err = commit.Parent(0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to walk history: %w", err)
+		}
+	}
+
+	// Reverse into oldest-first order
+	for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+		commits[i], commits[j] = commits[j], commits[i]
+	}
+
+	return commits, nil
+}
@@ -0,0 +1,276 @@
+package git
+
+import (
+	"context"
+	"log/slog"
+	"os/exec"
+	"time"
+)
+
+// logger records git operations. It discards everything until SetLogger is called so
+// that nothing is written over a TUI.
+var logger = slog.New(slog.DiscardHandler)
+
+// SetLogger sets the logger that records git operations
+func SetLogger(l *slog.Logger) {
+	logger = l
+}
+
+// Backends that run git operations
+const (
+	backendGoGit = "go-git"
+	backendExec  = "exec"
+)
+
+// logOp records a finished git operation with its backend, duration, arguments and error.
+// Successful operations are logged at info level and failures at error level.
+func logOp(op, backend string, start time.Time, err error, args ...any) {
+	level := slog.LevelInfo
+	attrs := append([]any{"op", op, "backend", backend, "duration", time.Since(start)}, args...)
+	if err != nil {
+		level = slog.LevelError
+		attrs = append(attrs, "error", err)
+	}
+	logger.Log(context.Background(), level, "git operation", attrs...)
+}
+
+// runCommand runs a git command with run, which is one of cmd's Run, Output or
+// CombinedOutput methods, and logs it
+func runCommand(cmd *exec.Cmd, run func() ([]byte, error)) ([]byte, error) {
+	start := time.Now()
+	output, err := run()
+	logOp(cmd.Args[1], backendExec, start, err, "args", cmd.Args[1:], "dir", cmd.Dir)
+	return output, err
+}
+
+// loggedRepository logs every operation of the go-git repository it wraps
+type loggedRepository struct {
+	repo GitRepositoryInterface
+}
+
+var _ GitRepositoryInterface = (*loggedRepository)(nil)
+
+// traceOp logs a go-git operation when the method deferring it returns
+func traceOp(op string, start time.Time, err *error, args ...any) {
+	logOp(op, backendGoGit, start, *err, args...)
+}
+
+func (r *loggedRepository) Status(ctx context.Context) (result []GitFile, err error) {
+	defer traceOp("Status", time.Now(), &err)
+	return r.repo.Status(ctx)
+}
+
+func (r *loggedRepository) Stage(ctx context.Context, paths []string) (err error) {
+	defer traceOp("Stage", time.Now(), &err, "paths", paths)
+	return r.repo.Stage(ctx, paths)
+}
+
+func (r *loggedRepository) Unstage(ctx context.Context, paths []string) (err error) {
+	defer traceOp("Unstage", time.Now(), &err, "paths", paths)
+	return r.repo.Unstage(ctx, paths)
+}
+
+func (r *loggedRepository) Ignore(ctx context.Context, paths []string) (err error) {
+	defer traceOp("Ignore", time.Now(), &err, "paths", paths)
+	return r.repo.Ignore(ctx, paths)
+}
+
+func (r *loggedRepository) DeleteUntracked(ctx context.Context, paths []string) (err error) {
+	defer traceOp("DeleteUntracked", time.Now(), &err, "paths", paths)
+	return r.repo.DeleteUntracked(ctx, paths)
+}
+
+func (r *loggedRepository) Commit(ctx context.Context, commitType, message string) (err error) {
+	defer traceOp("Commit", time.Now(), &err, "commitType", commitType, "message", message)
+	return r.repo.Commit(ctx, commitType, message)
+}
+
+func (r *loggedRepository) GetCurrentBranch(ctx context.Context) (result string, err error) {
+	defer traceOp("GetCurrentBranch", time.Now(), &err)
+	return r.repo.GetCurrentBranch(ctx)
+}
+
+func (r *loggedRepository) GetFileDiff(ctx context.Context, filePath string) (result *DiffResult, err error) {
+	defer traceOp("GetFileDiff", time.Now(), &err, "filePath", filePath)
+	return r.repo.GetFileDiff(ctx, filePath)
+}
+
+// CachedFileDiff only reads the diff cache, so it is not logged
+func (r *loggedRepository) CachedFileDiff(ctx context.Context, filePath string) (*DiffResult, bool) {
+	return r.repo.CachedFileDiff(ctx, filePath)
+}
+
+func (r *loggedRepository) PrefetchDiffs(ctx context.Context, paths []string) (err error) {
+	defer traceOp("PrefetchDiffs", time.Now(), &err, "paths", len(paths))
+	return r.repo.PrefetchDiffs(ctx, paths)
+}
+
+func (r *loggedRepository) Watch(ctx context.Context, debounce time.Duration) (result <-chan struct{}, err error) {
+	defer traceOp("Watch", time.Now(), &err)
+	return r.repo.Watch(ctx, debounce)
+}
+
+func (r *loggedRepository) LoadConflict(ctx context.Context, path string) (result *ConflictFile, err error) {
+	defer traceOp("LoadConflict", time.Now(), &err, "path", path)
+	return r.repo.LoadConflict(ctx, path)
+}
+
+func (r *loggedRepository) ResolveConflict(ctx context.Context, path, content string) (err error) {
+	defer traceOp("ResolveConflict", time.Now(), &err, "path", path, "content_bytes", len(content))
+	return r.repo.ResolveConflict(ctx, path, content)
+}
+
+func (r *loggedRepository) MarkResolved(ctx context.Context, path string) (err error) {
+	defer traceOp("MarkResolved", time.Now(), &err, "path", path)
+	return r.repo.MarkResolved(ctx, path)
+}
+
+func (r *loggedRepository) State(ctx context.Context) (result *RepoState, err error) {
+	defer traceOp("State", time.Now(), &err)
+	return r.repo.State(ctx)
+}
+
+func (r *loggedRepository) ContinueOperation(ctx context.Context, message string) (err error) {
+	defer traceOp("ContinueOperation", time.Now(), &err, "message", message)
+	return r.repo.ContinueOperation(ctx, message)
+}
+
+func (r *loggedRepository) AbortOperation(ctx context.Context) (err error) {
+	defer traceOp("AbortOperation", time.Now(), &err)
+	return r.repo.AbortOperation(ctx)
+}
+
+func (r *loggedRepository) Log(ctx context.Context, opts LogOptions) (result []CommitInfo, err error) {
+	defer traceOp("Log", time.Now(), &err, "opts", opts)
+	return r.repo.Log(ctx, opts)
+}
+
+func (r *loggedRepository) CommitsBetween(ctx context.Context, base, head string) (result []CommitInfo, err error) {
+	defer traceOp("CommitsBetween", time.Now(), &err, "base", base, "head", head)
+	return r.repo.CommitsBetween(ctx, base, head)
+}
+
+func (r *loggedRepository) StartRebase(ctx context.Context, base string, steps []RebaseStep) (result *RepoState, err error) {
+	defer traceOp("StartRebase", time.Now(), &err, "base", base, "steps", len(steps))
+	return r.repo.StartRebase(ctx, base, steps)
+}
+
+func (r *loggedRepository) Upstream(ctx context.Context) (result string, err error) {
+	defer traceOp("Upstream", time.Now(), &err)
+	return r.repo.Upstream(ctx)
+}
+
+func (r *loggedRepository) BranchStatus(ctx context.Context) (result *BranchStatus, err error) {
+	defer traceOp("BranchStatus", time.Now(), &err)
+	return r.repo.BranchStatus(ctx)
+}
+
+func (r *loggedRepository) Branches(ctx context.Context) (result []Branch, err error) {
+	defer traceOp("Branches", time.Now(), &err)
+	return r.repo.Branches(ctx)
+}
+
+func (r *loggedRepository) UnpushedCommits(ctx context.Context, limit int) (result []CommitInfo, err error) {
+	defer traceOp("UnpushedCommits", time.Now(), &err, "limit", limit)
+	return r.repo.UnpushedCommits(ctx, limit)
+}
+
+func (r *loggedRepository) CommitFixup(ctx context.Context, kind FixupKind, target CommitInfo, message string) (err error) {
+	defer traceOp("CommitFixup", time.Now(), &err, "kind", kind.String(), "target", target.ShortHash, "message", message)
+	return r.repo.CommitFixup(ctx, kind, target, message)
+}
+
+func (r *loggedRepository) Autosquash(ctx context.Context) (result *RepoState, err error) {
+	defer traceOp("Autosquash", time.Now(), &err)
+	return r.repo.Autosquash(ctx)
+}
+
+func (r *loggedRepository) Blame(ctx context.Context, path, rev string) (result *BlameResult, err error) {
+	defer traceOp("Blame", time.Now(), &err, "path", path, "rev", rev)
+	return r.repo.Blame(ctx, path, rev)
+}
+
+func (r *loggedRepository) CommitDiff(ctx context.Context, hash string) (result []DiffResult, err error) {
+	defer traceOp("CommitDiff", time.Now(), &err, "hash", hash)
+	return r.repo.CommitDiff(ctx, hash)
+}
+
+func (r *loggedRepository) FileHistory(ctx context.Context, path string, limit int) (result []FileRevision, err error) {
+	defer traceOp("FileHistory", time.Now(), &err, "path", path, "limit", limit)
+	return r.repo.FileHistory(ctx, path, limit)
+}
+
+func (r *loggedRepository) FileDiffAt(ctx context.Context, hash, path string) (result *DiffResult, err error) {
+	defer traceOp("FileDiffAt", time.Now(), &err, "hash", hash, "path", path)
+	return r.repo.FileDiffAt(ctx, hash, path)
+}
+
+func (r *loggedRepository) RestoreFileAt(ctx context.Context, rev, path, dest string) (err error) {
+	defer traceOp("RestoreFileAt", time.Now(), &err, "rev", rev, "path", path, "dest", dest)
+	return r.repo.RestoreFileAt(ctx, rev, path, dest)
+}
+
+func (r *loggedRepository) CherryPick(ctx context.Context, commits []CommitInfo) (result *PickResult, err error) {
+	defer traceOp("CherryPick", time.Now(), &err, "commits", len(commits))
+	return r.repo.CherryPick(ctx, commits)
+}
+
+func (r *loggedRepository) Revert(ctx context.Context, commits []CommitInfo) (result *PickResult, err error) {
+	defer traceOp("Revert", time.Now(), &err, "commits", len(commits))
+	return r.repo.Revert(ctx, commits)
+}
+
+func (r *loggedRepository) Tags(ctx context.Context) (result []Tag, err error) {
+	defer traceOp("Tags", time.Now(), &err)
+	return r.repo.Tags(ctx)
+}
+
+func (r *loggedRepository) CreateTag(ctx context.Context, name, message string) (err error) {
+	defer traceOp("CreateTag", time.Now(), &err, "name", name, "message", message)
+	return r.repo.CreateTag(ctx, name, message)
+}
+
+func (r *loggedRepository) DeleteTag(ctx context.Context, name string) (err error) {
+	defer traceOp("DeleteTag", time.Now(), &err, "name", name)
+	return r.repo.DeleteTag(ctx, name)
+}
+
+func (r *loggedRepository) PlanRelease(ctx context.Context) (result *ReleasePlan, err error) {
+	defer traceOp("PlanRelease", time.Now(), &err)
+	return r.repo.PlanRelease(ctx)
+}
+
+func (r *loggedRepository) PreviousRelease(ctx context.Context, rev string) (result *Tag, err error) {
+	defer traceOp("PreviousRelease", time.Now(), &err, "rev", rev)
+	return r.repo.PreviousRelease(ctx, rev)
+}
+
+func (r *loggedRepository) ReleaseCommits(ctx context.Context, from, to string) (result []CommitInfo, err error) {
+	defer traceOp("ReleaseCommits", time.Now(), &err, "from", from, "to", to)
+	return r.repo.ReleaseCommits(ctx, from, to)
+}
+
+func (r *loggedRepository) Remotes(ctx context.Context) (result []Remote, err error) {
+	defer traceOp("Remotes", time.Now(), &err)
+	return r.repo.Remotes(ctx)
+}
+
+func (r *loggedRepository) AddRemote(ctx context.Context, name, url string) (err error) {
+	defer traceOp("AddRemote", time.Now(), &err, "name", name, "url", url)
+	return r.repo.AddRemote(ctx, name, url)
+}
+
+func (r *loggedRepository) RenameRemote(ctx context.Context, oldName, newName string) (err error) {
+	defer traceOp("RenameRemote", time.Now(), &err, "oldName", oldName, "newName", newName)
+	return r.repo.RenameRemote(ctx, oldName, newName)
+}
+
+func (r *loggedRepository) RemoveRemote(ctx context.Context, name string) (err error) {
+	defer traceOp("RemoveRemote", time.Now(), &err, "name", name)
+	return r.repo.RemoveRemote(ctx, name)
+}
+
+func (r *loggedRepository) SetRemoteURL(ctx context.Context, name, url string, push bool) (err error) {
+	defer traceOp("SetRemoteURL", time.Now(), &err, "name", name, "url", url, "push", push)
+	return r.repo.SetRemoteURL(ctx, name, url, push)
+}
@@ -0,0 +1,38 @@
+package git
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggedRepository(t *testing.T) {
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+	defer SetLogger(slog.New(slog.DiscardHandler))
+
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+	logged := &loggedRepository{repo: repo}
+
+	_, err = logged.Status(t.Context())
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "op=Status backend=go-git")
+
+	buf.Reset()
+	_, err = logged.GetFileDiff(t.Context(), "missing.txt")
+	require.Error(t, err)
+	assert.Contains(t, buf.String(), "level=ERROR")
+	assert.Contains(t, buf.String(), "filePath=missing.txt")
+	assert.Contains(t, buf.String(), "error=")
+
+	buf.Reset()
+	require.NoError(t, repo.gitExec(t.Context(), "status", "--porcelain"))
+	assert.Contains(t, buf.String(), "op=status backend=exec")
+}
@@ -0,0 +1,260 @@
+package git
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"unicode/utf8"
+)
+
+// Pathspec selects repository paths the way git's pathspecs do. A nil Pathspec matches everything.
+//
+// Patterns without wildcards match a path or any directory leading to it. Patterns with
+// wildcards match whole paths, with '*' crossing directory boundaries unless the :(glob)
+// magic is used, in which case "**" matches any number of directories instead.
+// Supported magic: :(top) or :/, :(exclude) or :! and :^, :(glob), :(literal) and :(icase).
+type Pathspec struct {
+	include []pathspecItem
+	exclude []pathspecItem
+}
+
+// pathspecItem is a single parsed pathspec
+type pathspecItem struct {
+	pattern string // Relative to the top of the repository
+	glob    bool
+	literal bool
+	icase   bool
+}
+
+// ParsePathspec parses pathspecs given from prefix, the slash-separated directory inside the
+// repository the command runs in ("" at the top)
+func ParsePathspec(prefix string, specs []string) (*Pathspec, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+
+	p := &Pathspec{}
+	for _, spec := range specs {
+		item, exclude, err := parsePathspecItem(prefix, spec)
+		if err != nil {
+			return nil, err
+		}
+		if exclude {
+			p.exclude = append(p.exclude, item)
+		} else {
+			p.include = append(p.include, item)
+		}
+	}
+
+	return p, nil
+}
+
+func parsePathspecItem(prefix, spec string) (pathspecItem, bool, error) {
+	item := pathspecItem{}
+	top, exclude := false, false
+	pattern := spec
+
+	switch {
+	case strings.HasPrefix(spec, ":("):
+		end := strings.IndexByte(spec, ')')
+		if end < 0 {
+			return item, false, fmt.Errorf("invalid pathspec %q: missing ')'", spec)
+		}
+		for _, magic := range strings.Split(spec[2:end], ",") {
+			switch strings.TrimSpace(magic) {
+			case "top":
+				top = true
+			case "exclude":
+				exclude = true
+			case "glob":
+				item.glob = true
+			case "literal":
+				item.literal = true
+			case "icase":
+				item.icase = true
+			default:
+				return item, false, fmt.Errorf("invalid pathspec %q: unsupported magic %q", spec, magic)
+			}
+		}
+		pattern = spec[end+1:]
+
+	case strings.HasPrefix(spec, ":"):
+		// Short magic is a run of '/', '!' and '^', optionally terminated by ':'
+		i := 1
+		for ; i < len(spec) && strings.ContainsRune("/!^", rune(spec[i])); i++ {
+			if spec[i] == '/' {
+				top = true
+			} else {
+				exclude = true
+			}
+		}
+		if i < len(spec) && spec[i] == ':' {
+			i++
+		}
+		pattern = spec[i:]
+	}
+
+	if item.glob && item.literal {
+		return item, false, fmt.Errorf("invalid pathspec %q: glob and literal cannot be combined", spec)
+	}
+
+	if !top && prefix != "" {
+		pattern = prefix + "/" + pattern
+	}
+	if pattern != "" {
+		pattern = path.Clean(pattern)
+	}
+	if pattern == ".." || strings.HasPrefix(pattern, "../") {
+		return item, false, fmt.Errorf("pathspec %q is outside the repository", spec)
+	}
+	if pattern == "." {
+		pattern = ""
+	}
+
+	item.pattern = pattern
+	if item.icase {
+		item.pattern = strings.ToLower(pattern)
+	}
+	return item, exclude, nil
+}
+
+// Match reports whether a slash-separated repository path is selected
+func (p *Pathspec) Match(name string) bool {
+	if p == nil {
+		return true
+	}
+
+	included := len(p.include) == 0
+	for _, item := range p.include {
+		if item.match(name) {
+			included = true
+			break
+		}
+	}
+	if !included {
+		return false
+	}
+
+	for _, item := range p.exclude {
+		if item.match(name) {
+			return false
+		}
+	}
+	return true
+}
+
+func (item pathspecItem) match(name string) bool {
+	if item.icase {
+		name = strings.ToLower(name)
+	}
+
+	if item.literal || !strings.ContainsAny(item.pattern, "*?[\\") {
+		return item.pattern == "" || name == item.pattern || strings.HasPrefix(name, item.pattern+"/")
+	}
+
+	return wildmatch(item.pattern, name, item.glob)
+}
+
+// MatchPattern matches a path against a gitignore-style glob: patterns without a slash
+// match the file name in any directory, other patterns match the whole path with "**"
+// matching any number of directories
+func MatchPattern(pattern, name string) bool {
+	pattern = strings.TrimPrefix(pattern, "/")
+	if !strings.Contains(pattern, "/") {
+		return wildmatch(pattern, path.Base(name), true)
+	}
+	return wildmatch(pattern, name, true)
+}
+
+// wildmatch matches name against a shell wildcard pattern. In pathname mode '*' and '?'
+// do not match '/', and "**" between slashes matches any number of directories.
+func wildmatch(pattern, name string, pathname bool) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			if pathname && strings.HasPrefix(pattern, "**") {
+				rest := strings.TrimLeft(pattern, "*")
+				if rest == "" {
+					return true
+				}
+				if rest[0] == '/' {
+					// "**/" matches zero or more leading directories
+					rest = rest[1:]
+					if wildmatch(rest, name, true) {
+						return true
+					}
+					for i := 0; i < len(name); i++ {
+						if name[i] == '/' && wildmatch(rest, name[i+1:], true) {
+							return true
+						}
+					}
+					return false
+				}
+			}
+
+			pattern = strings.TrimLeft(pattern, "*")
+			for i := 0; i <= len(name); i++ {
+				if wildmatch(pattern, name[i:], pathname) {
+					return true
+				}
+				if i < len(name) && pathname && name[i] == '/' {
+					return false
+				}
+			}
+			return false
+
+		case '?':
+			r, size := utf8.DecodeRuneInString(name)
+			if name == "" || (pathname && r == '/') {
+				return false
+			}
+			pattern, name = pattern[1:], name[size:]
+
+		case '[':
+			end := strings.IndexByte(pattern[1:], ']')
+			if end < 0 || name == "" {
+				return false
+			}
+			class := pattern[:end+2]
+			if strings.HasPrefix(class, "[!") {
+				class = "[^" + class[2:]
+			}
+			r, size := utf8.DecodeRuneInString(name)
+			if ok, err := path.Match(class, string(r)); err != nil || !ok || (pathname && r == '/') {
+				return false
+			}
+			pattern, name = pattern[end+2:], name[size:]
+
+		case '\\':
+			if len(pattern) < 2 || name == "" || name[0] != pattern[1] {
+				return false
+			}
+			pattern, name = pattern[2:], name[1:]
+
+		default:
+			if name == "" || name[0] != pattern[0] {
+				return false
+			}
+			pattern, name = pattern[1:], name[1:]
+		}
+	}
+
+	return name == ""
+}
+
+// ParseCwdPathspec parses pathspecs given relative to the working directory
+func ParseCwdPathspec(specs []string) (*Pathspec, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+
+	prefix, err := RelativePath(".")
+	if err != nil {
+		return nil, err
+	}
+	if prefix == "." {
+		prefix = ""
+	}
+
+	return ParsePathspec(prefix, specs)
+}
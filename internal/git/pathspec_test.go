@@ -0,0 +1,93 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathspecMatch(t *testing.T) {
+	paths := []string{"top.go", "docs/d.go", "src/a.go", "src/c.txt", "src/sub/b.go", "srcfile"}
+
+	tests := []struct {
+		name   string
+		prefix string
+		specs  []string
+		want   []string
+	}{
+		{
+			name:  "GIVEN a directory THEN everything below it matches",
+			specs: []string{"src"},
+			want:  []string{"src/a.go", "src/c.txt", "src/sub/b.go"},
+		},
+		{
+			name:  "GIVEN a wildcard THEN '*' crosses directories",
+			specs: []string{"src/*.go"},
+			want:  []string{"src/a.go", "src/sub/b.go"},
+		},
+		{
+			name:  "GIVEN glob magic THEN '*' stays within a directory",
+			specs: []string{":(glob)src/*.go"},
+			want:  []string{"src/a.go"},
+		},
+		{
+			name:  "GIVEN '**' and an exclude THEN excluded paths are dropped",
+			specs: []string{":(glob)**/*.go", ":!docs"},
+			want:  []string{"top.go", "src/a.go", "src/sub/b.go"},
+		},
+		{
+			name:  "GIVEN only an exclude THEN everything else matches",
+			specs: []string{":(exclude)src"},
+			want:  []string{"top.go", "docs/d.go", "srcfile"},
+		},
+		{
+			name:   "GIVEN a prefix THEN specs are relative to it unless they use top",
+			prefix: "src",
+			specs:  []string{"*.txt", ":/docs"},
+			want:   []string{"docs/d.go", "src/c.txt"},
+		},
+		{
+			name:   "GIVEN '..' from a subdirectory THEN it resolves against the prefix",
+			prefix: "src/sub",
+			specs:  []string{"../a.go"},
+			want:   []string{"src/a.go"},
+		},
+		{
+			name:  "GIVEN icase and literal THEN case is ignored and wildcards are plain text",
+			specs: []string{":(icase,literal)SRC/A.GO"},
+			want:  []string{"src/a.go"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			spec, err := ParsePathspec(tc.prefix, tc.specs)
+			require.NoError(t, err)
+
+			var got []string
+			for _, p := range paths {
+				if spec.Match(p) {
+					got = append(got, p)
+				}
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParsePathspecErrors(t *testing.T) {
+	for _, spec := range []string{":(unknown)x", ":(glob", "../outside"} {
+		_, err := ParsePathspec("", []string{spec})
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	assert.True(t, MatchPattern("*.go", "internal/git/pathspec.go"))
+	assert.False(t, MatchPattern("*.go", "README.md"))
+	assert.True(t, MatchPattern("internal/**/*_test.go", "internal/git/pathspec_test.go"))
+	assert.True(t, MatchPattern("internal/**/*_test.go", "internal/x_test.go"))
+	assert.False(t, MatchPattern("internal/*.go", "internal/git/pathspec.go"))
+	assert.True(t, MatchPattern("[!a]*.md", "README.md"))
+}
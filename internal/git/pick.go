@@ -0,0 +1,101 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// PickResult reports how far a cherry-pick or revert of several commits got
+type PickResult struct {
+	Applied int        // Number of commits applied before git stopped
+	State   *RepoState // In progress when git stopped on a conflict at commit Applied
+}
+
+// RevertMessage returns the conventional commit message for reverting c
+func RevertMessage(c CommitInfo) string {
+	return fmt.Sprintf("revert: %s\n\nThis reverts commit %s.", c.Subject, c.Hash)
+}
+
+// CherryPick applies commits onto HEAD in the given order, one commit at a time.
+// When a commit conflicts it stops with the cherry-pick in progress; the caller
+// resolves it, continues the operation and passes the remaining commits again.
+func (g *GitRepository) CherryPick(ctx context.Context, commits []CommitInfo) (*PickResult, error) {
+	return g.applyCommits(ctx, commits, func(c CommitInfo) error {
+		return g.gitExec(ctx, "cherry-pick", c.Hash)
+	})
+}
+
+// Revert reverts commits in the given order with conventional "revert:" messages,
+// stopping on a conflict like CherryPick. The prepared message of a conflicted
+// revert is replaced so that continuing the operation keeps the conventional message.
+func (g *GitRepository) Revert(ctx context.Context, commits []CommitInfo) (*PickResult, error) {
+	msgFile := filepath.Join(g.gitDir(), "go-git-tui", "REVERT_MSG")
+	if err := os.MkdirAll(filepath.Dir(msgFile), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create revert state: %w", err)
+	}
+
+	return g.applyCommits(ctx, commits, func(c CommitInfo) error {
+		message := RevertMessage(c)
+		if err := os.WriteFile(msgFile, []byte(message+"\n"), 0o644); err != nil {
+			return fmt.Errorf("failed to write revert message: %w", err)
+		}
+
+		// git hands the editor the message file, which is overwritten with ours
+		err := g.gitExecEnv(ctx, []string{"GIT_EDITOR=cp " + shellQuote(msgFile)}, "revert", "--edit", c.Hash)
+		if err != nil && exists(filepath.Join(g.gitDir(), "REVERT_HEAD")) {
+			if writeErr := os.WriteFile(filepath.Join(g.gitDir(), "MERGE_MSG"), []byte(message+"\n"), 0o644); writeErr != nil {
+				return fmt.Errorf("failed to write revert message: %w", writeErr)
+			}
+		}
+		return err
+	})
+}
+
+// applyCommits runs apply for each commit until one fails
+func (g *GitRepository) applyCommits(ctx context.Context, commits []CommitInfo, apply func(CommitInfo) error) (*PickResult, error) {
+	if g.repo == nil {
+		return nil, errNotInitialized
+	}
+
+	state, err := g.State(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if state.InProgress() {
+		return nil, &InProgressError{Action: "apply commits", Operation: state.Operation}
+	}
+
+	result := &PickResult{State: state}
+	for _, c := range commits {
+		runErr := apply(c)
+
+		state, err := g.State(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result.State = state
+
+		if state.InProgress() {
+			return result, nil
+		}
+		if runErr != nil {
+			return result, runErr
+		}
+		result.Applied++
+	}
+
+	return result, nil
+}
+
+// CountConflicted returns the number of unmerged files in a status listing
+func CountConflicted(files []GitFile) int {
+	n := 0
+	for _, f := range files {
+		if IsConflicted(f.Status) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,79 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCherryPick(t *testing.T) {
+	setTestIdentity(t)
+
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	commitFile(t, repoPath, "base.txt", "base", "chore: base")
+	runGit(t, repoPath, "branch", "release")
+	commitFile(t, repoPath, "a.txt", "a", "fix: a")
+	commitFile(t, repoPath, "b.txt", "b", "fix: b")
+	runGit(t, repoPath, "checkout", "-q", "release")
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	commits, err := repo.CommitsBetween(t.Context(), "release", "master")
+	require.NoError(t, err)
+
+	result, err := repo.CherryPick(t.Context(), commits)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Applied)
+	assert.False(t, result.State.InProgress())
+
+	log, err := repo.Log(t.Context(), LogOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fix: b", "fix: a", "chore: base"}, subjects(log))
+}
+
+func TestRevert(t *testing.T) {
+	setTestIdentity(t)
+
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	commitFile(t, repoPath, "file.txt", "one\n", "feat: one")
+	commitFile(t, repoPath, "file.txt", "two\n", "feat: two")
+	commitFile(t, repoPath, "file.txt", "three\n", "feat: three")
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	log, err := repo.Log(t.Context(), LogOptions{})
+	require.NoError(t, err)
+	three, two := log[0], log[1]
+
+	t.Run("GIVEN a clean revert THEN the conventional message is used", func(t *testing.T) {
+		result, err := repo.Revert(t.Context(), []CommitInfo{three})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Applied)
+
+		head, err := repo.Log(t.Context(), LogOptions{Limit: 1})
+		require.NoError(t, err)
+		assert.Equal(t, RevertMessage(three), cleanMessage(head[0].Message))
+	})
+
+	t.Run("GIVEN a conflicting revert THEN it stops with the message prepared", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(repoPath, "file.txt"), []byte("four\n"), 0o644))
+		runGit(t, repoPath, "commit", "-am", "feat: four")
+
+		result, err := repo.Revert(t.Context(), []CommitInfo{two})
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Applied)
+		assert.Equal(t, OperationRevert, result.State.Operation)
+		assert.Equal(t, RevertMessage(two), result.State.Message)
+
+		require.NoError(t, repo.AbortOperation(t.Context()))
+	})
+}
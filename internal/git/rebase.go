@@ -0,0 +1,140 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RebaseAction is the todo command applied to a commit during an interactive rebase
+type RebaseAction string
+
+const (
+	RebasePick   RebaseAction = "pick"
+	RebaseReword RebaseAction = "reword"
+	RebaseEdit   RebaseAction = "edit"
+	RebaseSquash RebaseAction = "squash"
+	RebaseFixup  RebaseAction = "fixup"
+	RebaseDrop   RebaseAction = "drop"
+)
+
+// RebaseActions lists every supported action in the order they are offered to the user
+var RebaseActions = []RebaseAction{RebasePick, RebaseReword, RebaseEdit, RebaseSquash, RebaseFixup, RebaseDrop}
+
+// RebaseStep is one line of the rebase todo list
+type RebaseStep struct {
+	Action  RebaseAction
+	Commit  CommitInfo
+	Message string // New message for reword steps
+}
+
+// NewRebasePlan creates a todo list that picks every commit, oldest first
+func NewRebasePlan(commits []CommitInfo) []RebaseStep {
+	steps := make([]RebaseStep, 0, len(commits))
+	for _, c := range commits {
+		steps = append(steps, RebaseStep{Action: RebasePick, Commit: c})
+	}
+	return steps
+}
+
+// ValidateRebasePlan checks that the plan can be handed to git
+func ValidateRebasePlan(steps []RebaseStep) error {
+	for _, step := range steps {
+		switch step.Action {
+		case RebaseDrop:
+			continue
+		case RebaseSquash, RebaseFixup:
+			return fmt.Errorf("cannot %s %s: there is no earlier commit to fold it into", step.Action, step.Commit.ShortHash)
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// rebaseStateDir holds files that must outlive a rebase that stops, such as reword messages
+func (g *GitRepository) rebaseStateDir() string {
+	return filepath.Join(g.gitDir(), "go-git-tui", "rebase")
+}
+
+// FormatRebaseTodo renders the plan in git's todo format. Reword messages are applied
+// with an exec line that amends the picked commit from a file in msgDir.
+func FormatRebaseTodo(steps []RebaseStep, msgDir string) (string, map[string]string) {
+	var sb strings.Builder
+	messages := map[string]string{}
+
+	for _, step := range steps {
+		if step.Action == RebaseReword && step.Message != "" {
+			msgFile := filepath.Join(msgDir, step.Commit.Hash+".msg")
+			messages[msgFile] = step.Message
+			fmt.Fprintf(&sb, "pick %s %s\n", step.Commit.Hash, step.Commit.Subject)
+			fmt.Fprintf(&sb, "exec git commit --amend --allow-empty -F %s\n", shellQuote(msgFile))
+			continue
+		}
+		fmt.Fprintf(&sb, "%s %s %s\n", step.Action, step.Commit.Hash, step.Commit.Subject)
+	}
+
+	return sb.String(), messages
+}
+
+// StartRebase runs "git rebase -i" onto base with the given plan as its todo list.
+// It returns the repository state afterwards, which is still in progress when git
+// stopped for an edit step or a conflict.
+func (g *GitRepository) StartRebase(ctx context.Context, base string, steps []RebaseStep) (*RepoState, error) {
+	if g.repo == nil {
+		return nil, errNotInitialized
+	}
+
+	if err := ValidateRebasePlan(steps); err != nil {
+		return nil, err
+	}
+
+	state, err := g.State(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if state.InProgress() {
+		return nil, &InProgressError{Action: "start a rebase", Operation: state.Operation}
+	}
+
+	msgDir := g.rebaseStateDir()
+	if err := os.RemoveAll(msgDir); err != nil {
+		return nil, fmt.Errorf("failed to clean rebase state: %w", err)
+	}
+	if err := os.MkdirAll(msgDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create rebase state: %w", err)
+	}
+
+	todo, messages := FormatRebaseTodo(steps, msgDir)
+	for path, message := range messages {
+		if err := os.WriteFile(path, []byte(message+"\n"), 0o644); err != nil {
+			return nil, fmt.Errorf("failed to write reword message: %w", err)
+		}
+	}
+
+	todoFile := filepath.Join(msgDir, "todo")
+	if err := os.WriteFile(todoFile, []byte(todo), 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write rebase todo: %w", err)
+	}
+
+	// git appends the path of its own todo file, which is overwritten with ours
+	editor := "cp " + shellQuote(todoFile)
+	runErr := g.gitExecEnv(ctx, []string{"GIT_SEQUENCE_EDITOR=" + editor}, "rebase", "-i", base)
+
+	state, err = g.State(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if runErr != nil && !state.InProgress() {
+		return nil, runErr
+	}
+
+	return state, nil
+}
+
+// shellQuote wraps s in single quotes for use in a POSIX shell command
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
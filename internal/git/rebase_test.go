@@ -0,0 +1,157 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// commitFile writes a file and commits it with the given message
+func commitFile(t *testing.T, dir, name, content, message string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	runGit(t, dir, "add", name)
+	runGit(t, dir, "commit", "-m", message)
+}
+
+// setTestIdentity makes git commands spawned by the code under test use a fixed identity
+func setTestIdentity(t *testing.T) {
+	t.Helper()
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+}
+
+func subjects(commits []CommitInfo) []string {
+	out := make([]string, 0, len(commits))
+	for _, c := range commits {
+		out = append(out, c.Subject)
+	}
+	return out
+}
+
+func TestLogAndCommitsBetween(t *testing.T) {
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	commitFile(t, repoPath, "a.txt", "a", "feat: a")
+	commitFile(t, repoPath, "b.txt", "b", "feat: b")
+	commitFile(t, repoPath, "c.txt", "c", "fix: c")
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	commits, err := repo.Log(t.Context(), LogOptions{Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fix: c", "feat: b"}, subjects(commits))
+
+	between, err := repo.CommitsBetween(t.Context(), "HEAD~2", "HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"feat: b", "fix: c"}, subjects(between))
+}
+
+func TestValidateRebasePlan(t *testing.T) {
+	commit := CommitInfo{ShortHash: "abc1234"}
+
+	tests := []struct {
+		name    string
+		steps   []RebaseStep
+		wantErr bool
+	}{
+		{
+			name:  "GIVEN a pick followed by a fixup THEN the plan is valid",
+			steps: []RebaseStep{{Action: RebasePick, Commit: commit}, {Action: RebaseFixup, Commit: commit}},
+		},
+		{
+			name:    "GIVEN a leading squash THEN the plan is rejected",
+			steps:   []RebaseStep{{Action: RebaseSquash, Commit: commit}, {Action: RebasePick, Commit: commit}},
+			wantErr: true,
+		},
+		{
+			name:    "GIVEN a fixup after only dropped commits THEN the plan is rejected",
+			steps:   []RebaseStep{{Action: RebaseDrop, Commit: commit}, {Action: RebaseFixup, Commit: commit}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateRebasePlan(tc.steps)
+			assert.Equal(t, tc.wantErr, err != nil, "error = %v", err)
+		})
+	}
+}
+
+func TestStartRebase(t *testing.T) {
+	setTestIdentity(t)
+
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	commitFile(t, repoPath, "base.txt", "base", "chore: base")
+	commitFile(t, repoPath, "a.txt", "a", "feat: a")
+	commitFile(t, repoPath, "b.txt", "b", "feat: b")
+	commitFile(t, repoPath, "c.txt", "c", "feat: c")
+	commitFile(t, repoPath, "a.txt", "a2", "fix: a typo")
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	commits, err := repo.CommitsBetween(t.Context(), "HEAD~4", "HEAD")
+	require.NoError(t, err)
+	require.Len(t, commits, 4)
+
+	// Reorder c before b, reword b, fold the typo fix into a and drop nothing
+	steps := []RebaseStep{
+		{Action: RebasePick, Commit: commits[0]},
+		{Action: RebaseFixup, Commit: commits[3]},
+		{Action: RebasePick, Commit: commits[2]},
+		{Action: RebaseReword, Commit: commits[1], Message: "feat: b reworded"},
+	}
+
+	state, err := repo.StartRebase(t.Context(), "HEAD~4", steps)
+	require.NoError(t, err)
+	assert.False(t, state.InProgress())
+
+	log, err := repo.Log(t.Context(), LogOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"feat: b reworded", "feat: c", "feat: a", "chore: base"}, subjects(log))
+
+	content, err := os.ReadFile(filepath.Join(repoPath, "a.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "a2", string(content))
+}
+
+func TestStartRebaseStopsForEdit(t *testing.T) {
+	setTestIdentity(t)
+
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	commitFile(t, repoPath, "base.txt", "base", "chore: base")
+	commitFile(t, repoPath, "a.txt", "a", "feat: a")
+	commitFile(t, repoPath, "b.txt", "b", "feat: b")
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	commits, err := repo.CommitsBetween(t.Context(), "HEAD~2", "HEAD")
+	require.NoError(t, err)
+
+	state, err := repo.StartRebase(t.Context(), "HEAD~2", []RebaseStep{
+		{Action: RebaseEdit, Commit: commits[0]},
+		{Action: RebasePick, Commit: commits[1]},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, OperationRebase, state.Operation)
+
+	require.NoError(t, repo.ContinueOperation(t.Context(), ""))
+
+	state, err = repo.State(t.Context())
+	require.NoError(t, err)
+	assert.False(t, state.InProgress())
+}
@@ -0,0 +1,427 @@
+package git
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Version is a semantic version such as v1.4.2
+type Version struct {
+	Prefix     string // "v" or empty, kept so new tags match existing ones
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+}
+
+// ParseVersion parses a semver tag name. It returns false for other names.
+func ParseVersion(name string) (Version, bool) {
+	v := Version{}
+	rest := name
+	if strings.HasPrefix(rest, "v") {
+		v.Prefix, rest = "v", rest[1:]
+	}
+
+	// Build metadata does not affect precedence and is ignored
+	rest, _, _ = strings.Cut(rest, "+")
+	rest, v.Prerelease, _ = strings.Cut(rest, "-")
+
+	parts := strings.Split(rest, ".")
+	if len(parts) != 3 {
+		return Version{}, false
+	}
+
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || (len(part) > 1 && part[0] == '0') {
+			return Version{}, false
+		}
+		numbers[i] = n
+	}
+	v.Major, v.Minor, v.Patch = numbers[0], numbers[1], numbers[2]
+
+	return v, true
+}
+
+// String formats the version as a tag name
+func (v Version) String() string {
+	s := fmt.Sprintf("%s%d.%d.%d", v.Prefix, v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	return s
+}
+
+// Less reports whether v has lower precedence than other
+func (v Version) Less(other Version) bool {
+	if v.Major != other.Major {
+		return v.Major < other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor < other.Minor
+	}
+	if v.Patch != other.Patch {
+		return v.Patch < other.Patch
+	}
+	// A pre-release sorts before the release it precedes
+	if (v.Prerelease == "") != (other.Prerelease == "") {
+		return v.Prerelease != ""
+	}
+	return comparePrerelease(v.Prerelease, other.Prerelease) < 0
+}
+
+// comparePrerelease compares two pre-release versions like semver §11: identifier by
+// identifier, numeric ones as numbers and before alphanumeric ones, and a shorter list
+// first when all of its identifiers are equal
+func comparePrerelease(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				return cmp.Compare(an, bn)
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return cmp.Compare(len(as), len(bs))
+}
+
+// Bump is the part of a version a release increments
+type Bump int
+
+const (
+	// BumpNone means there are no changes that warrant a release
+	BumpNone Bump = iota
+	BumpPatch
+	BumpMinor
+	BumpMajor
+)
+
+// String returns the name of the bump
+func (b Bump) String() string {
+	switch b {
+	case BumpPatch:
+		return "patch"
+	case BumpMinor:
+		return "minor"
+	case BumpMajor:
+		return "major"
+	default:
+		return "none"
+	}
+}
+
+// ParseBump parses "major", "minor" or "patch"
+func ParseBump(s string) (Bump, error) {
+	switch strings.ToLower(s) {
+	case "major":
+		return BumpMajor, nil
+	case "minor":
+		return BumpMinor, nil
+	case "patch":
+		return BumpPatch, nil
+	default:
+		return BumpNone, fmt.Errorf("invalid bump %q: expected major, minor or patch", s)
+	}
+}
+
+// Apply returns the version incremented by b, dropping any pre-release
+func (v Version) Apply(b Bump) Version {
+	next := Version{Prefix: v.Prefix, Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	switch b {
+	case BumpMajor:
+		next.Major, next.Minor, next.Patch = v.Major+1, 0, 0
+	case BumpMinor:
+		next.Minor, next.Patch = v.Minor+1, 0
+	case BumpPatch:
+		next.Patch = v.Patch + 1
+	default:
+		return v
+	}
+	return next
+}
+
+// NextBump computes the bump implied by conventional commits: breaking changes are
+// major, features are minor, and fixes and performance improvements are patches
+func NextBump(commits []ConventionalCommit) Bump {
+	bump := BumpNone
+	for _, c := range commits {
+		switch {
+		case c.Breaking:
+			return BumpMajor
+		case c.Type == "feat":
+			bump = max(bump, BumpMinor)
+		case c.Type == "fix" || c.Type == "perf":
+			bump = max(bump, BumpPatch)
+		}
+	}
+	return bump
+}
+
+// Tag is a tag and the commit it points to
+type Tag struct {
+	Name      string
+	Hash      string // Commit the tag points to
+	Annotated bool
+	Message   string
+	Tagger    string
+	When      time.Time
+}
+
+// Tags returns all tags, newest version or date first
+func (g *GitRepository) Tags(ctx context.Context) ([]Tag, error) {
+	if g.repo == nil {
+		return nil, errNotInitialized
+	}
+
+	iter, err := g.repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	defer iter.Close()
+
+	var tags []Tag
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		tag := Tag{Name: ref.Name().Short(), Hash: ref.Hash().String()}
+
+		if obj, err := g.repo.TagObject(ref.Hash()); err == nil {
+			commit, err := obj.Commit()
+			if err != nil {
+				// Tags of trees or blobs have no place in a release history
+				return nil
+			}
+			tag.Hash = commit.Hash.String()
+			tag.Annotated = true
+			tag.Message = strings.TrimSpace(obj.Message)
+			tag.Tagger = obj.Tagger.Name
+			tag.When = obj.Tagger.When
+		} else if commit, err := g.repo.CommitObject(ref.Hash()); err == nil {
+			tag.When = commit.Committer.When
+		}
+
+		tags = append(tags, tag)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	sortTags(tags)
+	return tags, nil
+}
+
+// sortTags orders semver tags by descending version, followed by other tags by date
+func sortTags(tags []Tag) {
+	sort.SliceStable(tags, func(i, j int) bool {
+		vi, iok := ParseVersion(tags[i].Name)
+		vj, jok := ParseVersion(tags[j].Name)
+		switch {
+		case iok && jok:
+			return vj.Less(vi)
+		case iok != jok:
+			return iok
+		default:
+			return tags[i].When.After(tags[j].When)
+		}
+	})
+}
+
+// CreateTag creates an annotated tag at HEAD
+func (g *GitRepository) CreateTag(ctx context.Context, name, message string) error {
+	if g.repo == nil {
+		return errNotInitialized
+	}
+
+	head, err := g.repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	// The tagger is read from the user's git configuration
+	if _, err := g.repo.CreateTag(name, head.Hash(), &git.CreateTagOptions{Message: message}); err != nil {
+		return fmt.Errorf("failed to create tag %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// DeleteTag deletes a tag
+func (g *GitRepository) DeleteTag(ctx context.Context, name string) error {
+	if g.repo == nil {
+		return errNotInitialized
+	}
+
+	if err := g.repo.DeleteTag(name); err != nil {
+		return fmt.Errorf("failed to delete tag %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// ReleasePlan is the proposed next release computed from the commits since the last release
+type ReleasePlan struct {
+	Previous *Tag // Last semver tag reachable from HEAD, nil for a first release
+	Current  Version
+	Next     Version
+	Bump     Bump
+	Commits  []ConventionalCommit // Conventional commits since the previous release
+	Skipped  int                  // Commits since the previous release that are not conventional
+}
+
+// PlanRelease finds the last semver tag reachable from HEAD and proposes the next version
+func (g *GitRepository) PlanRelease(ctx context.Context) (*ReleasePlan, error) {
+	if g.repo == nil {
+		return nil, errNotInitialized
+	}
+
+	head, err := g.resolveCommit("HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &ReleasePlan{Current: Version{Prefix: "v"}}
+	plan.Previous, err = g.latestRelease(ctx, head, true)
+	if err != nil {
+		return nil, err
+	}
+
+	from := ""
+	if plan.Previous != nil {
+		plan.Current, _ = ParseVersion(plan.Previous.Name)
+		from = plan.Previous.Hash
+	}
+
+	commits, err := g.ReleaseCommits(ctx, from, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	plan.Commits = ParseConventionalCommits(commits)
+	plan.Skipped = len(commits) - len(plan.Commits)
+	plan.Bump = NextBump(plan.Commits)
+	plan.Next = plan.Current.Apply(plan.Bump)
+
+	return plan, nil
+}
+
+// PreviousRelease returns the newest semver release tag reachable from rev, ignoring
+// tags on rev itself. It returns nil when there is no earlier release.
+func (g *GitRepository) PreviousRelease(ctx context.Context, rev string) (*Tag, error) {
+	if g.repo == nil {
+		return nil, errNotInitialized
+	}
+
+	commit, err := g.resolveCommit(rev)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.latestRelease(ctx, commit, false)
+}
+
+// ReleaseCommits returns the commits reachable from to but not from from, oldest first,
+// like git log from..to: the commits brought in by merges are included, and from does
+// not have to be on the first-parent history of to. An empty from returns the whole
+// history of to, walked the same way.
+func (g *GitRepository) ReleaseCommits(ctx context.Context, from, to string) ([]CommitInfo, error) {
+	if g.repo == nil {
+		return nil, errNotInitialized
+	}
+
+	head, err := g.resolveCommit(to)
+	if err != nil {
+		return nil, err
+	}
+
+	// The history of from is left out, and not walked any further
+	seen := make(map[plumbing.Hash]bool)
+	if from != "" {
+		base, err := g.resolveCommit(from)
+		if err != nil {
+			return nil, err
+		}
+		err = object.NewCommitPreorderIter(base, nil, nil).ForEach(func(c *object.Commit) error {
+			seen[c.Hash] = true
+			return ctx.Err()
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to walk history: %w", err)
+		}
+	}
+
+	var commits []CommitInfo
+	err = object.NewCommitIterCTime(head, seen, nil).ForEach(func(c *object.Commit) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		commits = append(commits, newCommitInfo(c))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk history: %w", err)
+	}
+
+	for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+		commits[i], commits[j] = commits[j], commits[i]
+	}
+	return commits, nil
+}
+
+// latestRelease returns the highest semver tag without a pre-release that is reachable
+// from head. Pre-releases are skipped so that release candidates do not hide the changes
+// since the last final release.
+func (g *GitRepository) latestRelease(ctx context.Context, head *object.Commit, includeHead bool) (*Tag, error) {
+	tags, err := g.Tags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range tags {
+		version, ok := ParseVersion(tag.Name)
+		if !ok || version.Prerelease != "" {
+			continue
+		}
+		if !includeHead && tag.Hash == head.Hash.String() {
+			continue
+		}
+
+		reachable, err := g.isAncestor(tag.Hash, head)
+		if err != nil {
+			return nil, err
+		}
+		if reachable {
+			return &tag, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// isAncestor reports whether the commit hash is reachable from head
+func (g *GitRepository) isAncestor(hash string, head *object.Commit) (bool, error) {
+	commit, err := g.repo.CommitObject(plumbing.NewHash(hash))
+	if err != nil {
+		return false, fmt.Errorf("failed to get commit %s: %w", hash, err)
+	}
+	return commit.IsAncestor(head)
+}
@@ -0,0 +1,286 @@
+package git
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		name   string
+		tag    string
+		want   Version
+		wantOK bool
+	}{
+		{
+			name:   "GIVEN a v-prefixed tag THEN the prefix is kept",
+			tag:    "v1.2.3",
+			want:   Version{Prefix: "v", Major: 1, Minor: 2, Patch: 3},
+			wantOK: true,
+		},
+		{
+			name:   "GIVEN a pre-release with build metadata THEN the metadata is dropped",
+			tag:    "2.0.0-rc.1+build.5",
+			want:   Version{Major: 2, Prerelease: "rc.1"},
+			wantOK: true,
+		},
+		{
+			name:   "GIVEN a tag with two components THEN it is not a version",
+			tag:    "v1.2",
+			wantOK: false,
+		},
+		{
+			name:   "GIVEN a leading zero THEN it is not a version",
+			tag:    "v1.02.0",
+			wantOK: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := ParseVersion(tc.tag)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestVersionLess(t *testing.T) {
+	tests := []struct {
+		lower, higher string
+	}{
+		{"1.0.0-rc.2", "1.0.0-rc.10"},
+		{"1.0.0-alpha", "1.0.0-alpha.1"},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta"},
+		{"1.0.0-alpha.beta", "1.0.0-beta"},
+		{"1.0.0-beta.11", "1.0.0-rc.1"},
+		{"1.0.0-rc.1", "1.0.0"},
+		{"1.0.0", "1.0.1"},
+		{"1.9.0", "1.10.0"},
+	}
+
+	for _, tc := range tests {
+		t.Run("GIVEN "+tc.lower+" and "+tc.higher+" THEN the first sorts before the second", func(t *testing.T) {
+			lower, ok := ParseVersion(tc.lower)
+			require.True(t, ok)
+			higher, ok := ParseVersion(tc.higher)
+			require.True(t, ok)
+
+			assert.True(t, lower.Less(higher))
+			assert.False(t, higher.Less(lower))
+			assert.False(t, lower.Less(lower), "a version is not less than itself")
+		})
+	}
+}
+
+func TestNextBump(t *testing.T) {
+	v := Version{Prefix: "v", Major: 1, Minor: 4, Patch: 2}
+
+	tests := []struct {
+		name    string
+		commits []ConventionalCommit
+		want    Bump
+		next    string
+	}{
+		{
+			name:    "GIVEN only chores THEN nothing is released",
+			commits: []ConventionalCommit{{Type: "chore"}, {Type: "docs"}},
+			want:    BumpNone,
+			next:    "v1.4.2",
+		},
+		{
+			name:    "GIVEN a fix THEN the patch is bumped",
+			commits: []ConventionalCommit{{Type: "chore"}, {Type: "fix"}},
+			want:    BumpPatch,
+			next:    "v1.4.3",
+		},
+		{
+			name:    "GIVEN a feature and a fix THEN the minor is bumped",
+			commits: []ConventionalCommit{{Type: "fix"}, {Type: "feat"}},
+			want:    BumpMinor,
+			next:    "v1.5.0",
+		},
+		{
+			name:    "GIVEN a breaking change THEN the major is bumped",
+			commits: []ConventionalCommit{{Type: "feat"}, {Type: "chore", Breaking: true}},
+			want:    BumpMajor,
+			next:    "v2.0.0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NextBump(tc.commits)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.next, v.Apply(got).String())
+		})
+	}
+}
+
+func TestPlanReleaseAndTags(t *testing.T) {
+	setTestIdentity(t)
+
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	runGit(t, repoPath, "config", "user.name", "Test User")
+	runGit(t, repoPath, "config", "user.email", "test@example.com")
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	commitFile(t, repoPath, "a.txt", "a", "feat: first feature")
+
+	plan, err := repo.PlanRelease(t.Context())
+	require.NoError(t, err)
+	assert.Nil(t, plan.Previous)
+	assert.Equal(t, "v0.1.0", plan.Next.String())
+
+	require.NoError(t, repo.CreateTag(t.Context(), plan.Next.String(), "Release "+plan.Next.String()))
+	// A lightweight tag that is not a version is ignored by the plan
+	runGit(t, repoPath, "tag", "nightly")
+
+	commitFile(t, repoPath, "b.txt", "b", "fix: a bug")
+	commitFile(t, repoPath, "c.txt", "c", "update readme")
+
+	plan, err = repo.PlanRelease(t.Context())
+	require.NoError(t, err)
+	require.NotNil(t, plan.Previous)
+	assert.Equal(t, "v0.1.0", plan.Previous.Name)
+	assert.Equal(t, BumpPatch, plan.Bump)
+	assert.Equal(t, "v0.1.1", plan.Next.String())
+	assert.Len(t, plan.Commits, 1)
+	assert.Equal(t, 1, plan.Skipped)
+
+	require.NoError(t, repo.CreateTag(t.Context(), "v0.1.1", "Release v0.1.1"))
+	previous, err := repo.PreviousRelease(t.Context(), "v0.1.1")
+	require.NoError(t, err)
+	require.NotNil(t, previous)
+	assert.Equal(t, "v0.1.0", previous.Name)
+	require.NoError(t, repo.DeleteTag(t.Context(), "v0.1.1"))
+
+	tags, err := repo.Tags(t.Context())
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+	assert.Equal(t, "v0.1.0", tags[0].Name)
+	assert.True(t, tags[0].Annotated)
+	assert.Equal(t, "Release v0.1.0", tags[0].Message)
+	assert.Equal(t, "Test User", tags[0].Tagger)
+	assert.Equal(t, "nightly", tags[1].Name)
+	assert.False(t, tags[1].Annotated)
+
+	require.NoError(t, repo.DeleteTag(t.Context(), "nightly"))
+	tags, err = repo.Tags(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, tags, 1)
+}
+
+// revList returns the hashes of the commits git lists for rev
+func revList(t *testing.T, dir string, rev string) []string {
+	t.Helper()
+	output, err := gitCommand(dir, "rev-list", "--reverse", rev).Output()
+	require.NoError(t, err)
+	return strings.Fields(string(output))
+}
+
+// hashes returns the hashes of commits
+func hashes(commits []CommitInfo) []string {
+	result := make([]string, 0, len(commits))
+	for _, c := range commits {
+		result = append(result, c.Hash)
+	}
+	return result
+}
+
+func TestPlanReleaseWithMerges(t *testing.T) {
+	setTestIdentity(t)
+
+	t.Run("GIVEN a feature merged without fast-forward THEN it counts for the bump", func(t *testing.T) {
+		repoPath := setupTestRepo(t)
+		defer cleanupTestRepo(t, repoPath)
+
+		commitFile(t, repoPath, "a.txt", "a", "feat: first feature")
+		runGit(t, repoPath, "tag", "v1.0.0")
+		runGit(t, repoPath, "checkout", "-q", "-b", "feature")
+		commitFile(t, repoPath, "b.txt", "b", "feat: merged feature")
+		runGit(t, repoPath, "checkout", "-q", "-")
+		commitFile(t, repoPath, "c.txt", "c", "fix: on main")
+		runGit(t, repoPath, "merge", "-q", "--no-ff", "-m", "Merge branch 'feature'", "feature")
+
+		repo, err := NewGitRepository(repoPath)
+		require.NoError(t, err)
+
+		plan, err := repo.PlanRelease(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, BumpMinor, plan.Bump)
+		assert.Equal(t, "v1.1.0", plan.Next.String())
+		assert.Len(t, plan.Commits, 2)
+		assert.Equal(t, 1, plan.Skipped, "the merge commit is not conventional")
+
+		commits, err := repo.ReleaseCommits(t.Context(), "v1.0.0", "HEAD")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, revList(t, repoPath, "v1.0.0..HEAD"), hashes(commits))
+	})
+
+	t.Run("GIVEN a release tagged on a merged branch THEN the commits since it are planned", func(t *testing.T) {
+		repoPath := setupTestRepo(t)
+		defer cleanupTestRepo(t, repoPath)
+
+		commitFile(t, repoPath, "a.txt", "a", "chore: initial")
+		runGit(t, repoPath, "checkout", "-q", "-b", "release")
+		commitFile(t, repoPath, "b.txt", "b", "fix: released fix")
+		runGit(t, repoPath, "tag", "v1.0.0")
+		runGit(t, repoPath, "checkout", "-q", "-")
+		commitFile(t, repoPath, "c.txt", "c", "feat: after the release")
+		runGit(t, repoPath, "merge", "-q", "--no-ff", "-m", "Merge branch 'release'", "release")
+
+		repo, err := NewGitRepository(repoPath)
+		require.NoError(t, err)
+
+		plan, err := repo.PlanRelease(t.Context())
+		require.NoError(t, err)
+		require.NotNil(t, plan.Previous)
+		assert.Equal(t, "v1.0.0", plan.Previous.Name)
+		assert.Equal(t, "v1.1.0", plan.Next.String())
+		require.Len(t, plan.Commits, 1, "the released fix is left out")
+		assert.Equal(t, "after the release", plan.Commits[0].Description)
+
+		commits, err := repo.ReleaseCommits(t.Context(), "v1.0.0", "HEAD")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, revList(t, repoPath, "v1.0.0..HEAD"), hashes(commits))
+	})
+}
+
+func TestReleaseCommitsWithMerges(t *testing.T) {
+	setTestIdentity(t)
+
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	commitFile(t, repoPath, "a.txt", "a", "feat: first feature")
+	runGit(t, repoPath, "checkout", "-q", "-b", "feature")
+	commitFile(t, repoPath, "b.txt", "b", "feat: merged feature")
+	runGit(t, repoPath, "checkout", "-q", "-")
+	commitFile(t, repoPath, "c.txt", "c", "fix: on main")
+	runGit(t, repoPath, "merge", "-q", "--no-ff", "-m", "Merge branch 'feature'", "feature")
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	for _, from := range []string{"", "HEAD~1"} {
+		t.Run("GIVEN from "+from+" THEN the commits brought in by the merge are listed", func(t *testing.T) {
+			commits, err := repo.ReleaseCommits(t.Context(), from, "HEAD")
+			require.NoError(t, err)
+
+			rev := "HEAD"
+			if from != "" {
+				rev = from + "..HEAD"
+			}
+			assert.ElementsMatch(t, revList(t, repoPath, rev), hashes(commits))
+			assert.Contains(t, subjects(commits), "feat: merged feature")
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// Remote describes a configured remote with its fetch and push URLs
+type Remote struct {
+	Name     string
+	FetchURL string
+	PushURLs []string
+}
+
+const (
+	remoteSection = "remote"
+	pushURLKey    = "pushurl"
+)
+
+// Remotes returns the configured remotes sorted by name
+func (g *GitRepository) Remotes(ctx context.Context) ([]Remote, error) {
+	if g.repo == nil {
+		return nil, errNotInitialized
+	}
+
+	cfg, err := g.repo.Config()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git config: %w", err)
+	}
+
+	remotes := make([]Remote, 0, len(cfg.Remotes))
+	for name, rc := range cfg.Remotes {
+		remote := Remote{Name: name}
+		if len(rc.URLs) > 0 {
+			remote.FetchURL = rc.URLs[0]
+		}
+
+		// git pushes to pushurl entries when present, otherwise to every url
+		remote.PushURLs = cfg.Raw.Section(remoteSection).Subsection(name).OptionAll(pushURLKey)
+		if len(remote.PushURLs) == 0 {
+			remote.PushURLs = append([]string{}, rc.URLs...)
+		}
+
+		remotes = append(remotes, remote)
+	}
+
+	sort.Slice(remotes, func(i, j int) bool {
+		return remotes[i].Name < remotes[j].Name
+	})
+
+	return remotes, nil
+}
+
+// AddRemote creates a new remote with the default fetch refspec
+func (g *GitRepository) AddRemote(ctx context.Context, name, url string) error {
+	if g.repo == nil {
+		return errNotInitialized
+	}
+
+	_, err := g.repo.CreateRemote(&config.RemoteConfig{
+		Name: name,
+		URLs: []string{url},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add remote %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// RenameRemote renames a remote, rewriting its refspecs, tracking branches and remote refs
+func (g *GitRepository) RenameRemote(ctx context.Context, oldName, newName string) error {
+	if g.repo == nil {
+		return errNotInitialized
+	}
+
+	cfg, err := g.repo.Config()
+	if err != nil {
+		return fmt.Errorf("failed to get git config: %w", err)
+	}
+
+	rc, ok := cfg.Remotes[oldName]
+	if !ok {
+		return fmt.Errorf("failed to rename remote %s: %w", oldName, git.ErrRemoteNotFound)
+	}
+	if _, exists := cfg.Remotes[newName]; exists {
+		return fmt.Errorf("failed to rename remote %s: %w", oldName, git.ErrRemoteExists)
+	}
+
+	oldPrefix := "refs/remotes/" + oldName + "/"
+	newPrefix := "refs/remotes/" + newName + "/"
+
+	renamed := &config.RemoteConfig{
+		Name:   newName,
+		URLs:   rc.URLs,
+		Mirror: rc.Mirror,
+	}
+	for _, spec := range rc.Fetch {
+		renamed.Fetch = append(renamed.Fetch, config.RefSpec(strings.ReplaceAll(spec.String(), oldPrefix, newPrefix)))
+	}
+	if err := renamed.Validate(); err != nil {
+		return fmt.Errorf("failed to rename remote %s: %w", oldName, err)
+	}
+
+	pushURLs := cfg.Raw.Section(remoteSection).Subsection(oldName).OptionAll(pushURLKey)
+
+	delete(cfg.Remotes, oldName)
+	cfg.Remotes[newName] = renamed
+
+	for _, branch := range cfg.Branches {
+		if branch.Remote == oldName {
+			branch.Remote = newName
+		}
+	}
+
+	if err := g.repo.SetConfig(cfg); err != nil {
+		return fmt.Errorf("failed to write git config: %w", err)
+	}
+
+	// Push URLs only live in the raw config, so carry them over once the new remote exists
+	if len(pushURLs) > 0 {
+		if err := g.setPushURLs(newName, pushURLs...); err != nil {
+			return err
+		}
+	}
+
+	return g.renameRemoteRefs(oldPrefix, newPrefix)
+}
+
+// renameRemoteRefs moves every remote-tracking reference from one prefix to another
+func (g *GitRepository) renameRemoteRefs(oldPrefix, newPrefix string) error {
+	refs, err := g.repo.References()
+	if err != nil {
+		return fmt.Errorf("failed to list references: %w", err)
+	}
+
+	var toMove []*plumbing.Reference
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if strings.HasPrefix(ref.Name().String(), oldPrefix) {
+			toMove = append(toMove, ref)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list references: %w", err)
+	}
+
+	for _, ref := range toMove {
+		newName := plumbing.ReferenceName(newPrefix + strings.TrimPrefix(ref.Name().String(), oldPrefix))
+
+		var moved *plumbing.Reference
+		if ref.Type() == plumbing.SymbolicReference {
+			target := plumbing.ReferenceName(strings.Replace(ref.Target().String(), oldPrefix, newPrefix, 1))
+			moved = plumbing.NewSymbolicReference(newName, target)
+		} else {
+			moved = plumbing.NewHashReference(newName, ref.Hash())
+		}
+
+		if err := g.repo.Storer.SetReference(moved); err != nil {
+			return fmt.Errorf("failed to move %s: %w", ref.Name(), err)
+		}
+		if err := g.repo.Storer.RemoveReference(ref.Name()); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", ref.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// RemoveRemote deletes a remote and its remote-tracking references
+func (g *GitRepository) RemoveRemote(ctx context.Context, name string) error {
+	if g.repo == nil {
+		return errNotInitialized
+	}
+
+	if err := g.repo.DeleteRemote(name); err != nil {
+		return fmt.Errorf("failed to remove remote %s: %w", name, err)
+	}
+
+	refs, err := g.repo.References()
+	if err != nil {
+		return fmt.Errorf("failed to list references: %w", err)
+	}
+
+	prefix := "refs/remotes/" + name + "/"
+	var stale []plumbing.ReferenceName
+	_ = refs.ForEach(func(ref *plumbing.Reference) error {
+		if strings.HasPrefix(ref.Name().String(), prefix) {
+			stale = append(stale, ref.Name())
+		}
+		return nil
+	})
+
+	for _, refName := range stale {
+		if err := g.repo.Storer.RemoveReference(refName); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", refName, err)
+		}
+	}
+
+	return nil
+}
+
+// SetRemoteURL replaces the fetch URL of a remote, or its push URL when push is true
+func (g *GitRepository) SetRemoteURL(ctx context.Context, name, url string, push bool) error {
+	if g.repo == nil {
+		return errNotInitialized
+	}
+
+	if push {
+		return g.setPushURLs(name, url)
+	}
+
+	cfg, err := g.repo.Config()
+	if err != nil {
+		return fmt.Errorf("failed to get git config: %w", err)
+	}
+
+	rc, ok := cfg.Remotes[name]
+	if !ok {
+		return fmt.Errorf("failed to set url of %s: %w", name, git.ErrRemoteNotFound)
+	}
+
+	if len(rc.URLs) == 0 {
+		rc.URLs = []string{url}
+	} else {
+		rc.URLs[0] = url
+	}
+
+	if err := g.repo.SetConfig(cfg); err != nil {
+		return fmt.Errorf("failed to write git config: %w", err)
+	}
+
+	return nil
+}
+
+// setPushURLs replaces the pushurl entries of an existing remote
+func (g *GitRepository) setPushURLs(name string, urls ...string) error {
+	cfg, err := g.repo.Config()
+	if err != nil {
+		return fmt.Errorf("failed to get git config: %w", err)
+	}
+
+	if _, ok := cfg.Remotes[name]; !ok {
+		return fmt.Errorf("failed to set push url of %s: %w", name, git.ErrRemoteNotFound)
+	}
+
+	// The parsed remote shares its raw subsection with cfg.Raw, so the option survives marshalling
+	cfg.Raw.Section(remoteSection).Subsection(name).SetOption(pushURLKey, urls...)
+
+	if err := g.repo.SetConfig(cfg); err != nil {
+		return fmt.Errorf("failed to write git config: %w", err)
+	}
+
+	return nil
+}
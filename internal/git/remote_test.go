@@ -0,0 +1,125 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteManagement(t *testing.T) {
+	tests := []struct {
+		name    string
+		run     func(repo *GitRepository) error
+		want    []Remote
+		wantErr bool
+	}{
+		{
+			name: "GIVEN a new remote THEN it is listed with matching fetch and push URLs",
+			run: func(repo *GitRepository) error {
+				return repo.AddRemote(t.Context(), "origin", "https://example.com/origin.git")
+			},
+			want: []Remote{
+				{Name: "origin", FetchURL: "https://example.com/origin.git", PushURLs: []string{"https://example.com/origin.git"}},
+			},
+		},
+		{
+			name: "GIVEN an existing remote name THEN adding it again fails",
+			run: func(repo *GitRepository) error {
+				if err := repo.AddRemote(t.Context(), "origin", "https://example.com/a.git"); err != nil {
+					return err
+				}
+				return repo.AddRemote(t.Context(), "origin", "https://example.com/b.git")
+			},
+			wantErr: true,
+		},
+		{
+			name: "GIVEN a push URL THEN fetch URL is kept and push URL is replaced",
+			run: func(repo *GitRepository) error {
+				if err := repo.AddRemote(t.Context(), "origin", "https://example.com/origin.git"); err != nil {
+					return err
+				}
+				return repo.SetRemoteURL(t.Context(), "origin", "git@example.com:origin.git", true)
+			},
+			want: []Remote{
+				{Name: "origin", FetchURL: "https://example.com/origin.git", PushURLs: []string{"git@example.com:origin.git"}},
+			},
+		},
+		{
+			name: "GIVEN a renamed remote THEN the new name keeps its URLs",
+			run: func(repo *GitRepository) error {
+				if err := repo.AddRemote(t.Context(), "origin", "https://example.com/fork.git"); err != nil {
+					return err
+				}
+				if err := repo.SetRemoteURL(t.Context(), "origin", "git@example.com:fork.git", true); err != nil {
+					return err
+				}
+				return repo.RenameRemote(t.Context(), "origin", "fork")
+			},
+			want: []Remote{
+				{Name: "fork", FetchURL: "https://example.com/fork.git", PushURLs: []string{"git@example.com:fork.git"}},
+			},
+		},
+		{
+			name: "GIVEN a removed remote THEN it is no longer listed",
+			run: func(repo *GitRepository) error {
+				if err := repo.AddRemote(t.Context(), "upstream", "https://example.com/upstream.git"); err != nil {
+					return err
+				}
+				return repo.RemoveRemote(t.Context(), "upstream")
+			},
+			want: []Remote{},
+		},
+		{
+			name: "GIVEN an unknown remote THEN renaming it fails",
+			run: func(repo *GitRepository) error {
+				return repo.RenameRemote(t.Context(), "missing", "other")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repoPath := setupTestRepo(t)
+			defer cleanupTestRepo(t, repoPath)
+
+			repo, err := NewGitRepository(repoPath)
+			require.NoError(t, err)
+
+			err = tc.run(repo)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			remotes, err := repo.Remotes(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, remotes)
+		})
+	}
+}
+
+func TestRenameRemoteMovesTrackingRefs(t *testing.T) {
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+	require.NoError(t, repo.AddRemote(t.Context(), "origin", "https://example.com/origin.git"))
+
+	hash := plumbing.NewHash("0123456789abcdef0123456789abcdef01234567")
+	require.NoError(t, repo.repo.Storer.SetReference(
+		plumbing.NewHashReference("refs/remotes/origin/main", hash)))
+
+	require.NoError(t, repo.RenameRemote(t.Context(), "origin", "upstream"))
+
+	_, err = repo.repo.Reference("refs/remotes/origin/main", false)
+	assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+
+	ref, err := repo.repo.Reference("refs/remotes/upstream/main", false)
+	require.NoError(t, err)
+	assert.Equal(t, hash, ref.Hash())
+}
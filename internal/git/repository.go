@@ -1,110 +1,161 @@
 package git
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 )
 
 // GitRepositoryInterface defines the operations that can be performed on a git repository
 type GitRepositoryInterface interface {
-	Status() ([]GitFile, error)
-	Stage(paths []string) error
-	Commit(commitType, message string) error
-	GetCurrentBranch() (string, error)
-	GetFileDiff(filePath string) (*DiffResult, error)
+	Status(ctx context.Context) ([]GitFile, error)
+	Stage(ctx context.Context, paths []string) error
+	Unstage(ctx context.Context, paths []string) error
+	Ignore(ctx context.Context, paths []string) error
+	DeleteUntracked(ctx context.Context, paths []string) error
+	Commit(ctx context.Context, commitType, message string) error
+	GetCurrentBranch(ctx context.Context) (string, error)
+	GetFileDiff(ctx context.Context, filePath string) (*DiffResult, error)
+	CachedFileDiff(ctx context.Context, filePath string) (*DiffResult, bool)
+	PrefetchDiffs(ctx context.Context, paths []string) error
+	Watch(ctx context.Context, debounce time.Duration) (<-chan struct{}, error)
+	LoadConflict(ctx context.Context, path string) (*ConflictFile, error)
+	ResolveConflict(ctx context.Context, path, content string) error
+	MarkResolved(ctx context.Context, path string) error
+	State(ctx context.Context) (*RepoState, error)
+	ContinueOperation(ctx context.Context, message string) error
+	AbortOperation(ctx context.Context) error
+	Log(ctx context.Context, opts LogOptions) ([]CommitInfo, error)
+	CommitsBetween(ctx context.Context, base, head string) ([]CommitInfo, error)
+	StartRebase(ctx context.Context, base string, steps []RebaseStep) (*RepoState, error)
+	Upstream(ctx context.Context) (string, error)
+	BranchStatus(ctx context.Context) (*BranchStatus, error)
+	Branches(ctx context.Context) ([]Branch, error)
+	UnpushedCommits(ctx context.Context, limit int) ([]CommitInfo, error)
+	CommitFixup(ctx context.Context, kind FixupKind, target CommitInfo, message string) error
+	Autosquash(ctx context.Context) (*RepoState, error)
+	Blame(ctx context.Context, path, rev string) (*BlameResult, error)
+	CommitDiff(ctx context.Context, hash string) ([]DiffResult, error)
+	FileHistory(ctx context.Context, path string, limit int) ([]FileRevision, error)
+	FileDiffAt(ctx context.Context, hash, path string) (*DiffResult, error)
+	RestoreFileAt(ctx context.Context, rev, path, dest string) error
+	CherryPick(ctx context.Context, commits []CommitInfo) (*PickResult, error)
+	Revert(ctx context.Context, commits []CommitInfo) (*PickResult, error)
+	Tags(ctx context.Context) ([]Tag, error)
+	CreateTag(ctx context.Context, name, message string) error
+	DeleteTag(ctx context.Context, name string) error
+	PlanRelease(ctx context.Context) (*ReleasePlan, error)
+	PreviousRelease(ctx context.Context, rev string) (*Tag, error)
+	ReleaseCommits(ctx context.Context, from, to string) ([]CommitInfo, error)
+	Remotes(ctx context.Context) ([]Remote, error)
+	AddRemote(ctx context.Context, name, url string) error
+	RenameRemote(ctx context.Context, oldName, newName string) error
+	RemoveRemote(ctx context.Context, name string) error
+	SetRemoteURL(ctx context.Context, name, url string, push bool) error
 }
 
 // GitRepository represents a repository managed by go-git
 type GitRepository struct {
 	repo *git.Repository
 	path string
+
+	// go-git is not safe for concurrent use: mu is held while repo is used, see
+	// lockedRepository and withContext
+	mu sync.Mutex
+
+	diffs         *diffCache    // Diffs of the files in the status, by content
+	prefetchSlots chan struct{} // Bounds the diffs computed ahead by PrefetchDiffs
+
+	untrackedCacheOnce sync.Once
+	untrackedCache     bool // Status runs git status, see untrackedCacheEnabled
 }
 
 // NewGitRepository creates a new GitRepository instance
 func NewGitRepository(path string) (*GitRepository, error) {
 	// Open the repository at the given path
 	repo, err := git.PlainOpen(path)
-	if err != nil {
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		return nil, fmt.Errorf("%w: %s: %w", ErrNotRepository, path, err)
+	}
+	if errors.Is(err, os.ErrPermission) {
 		return nil, fmt.Errorf("failed to open git repository: %w", err)
 	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to open git repository: %w", ErrCorruptRepository, err)
+	}
 
 	return &GitRepository{
-		repo: repo,
-		path: path,
+		repo:          repo,
+		path:          path,
+		diffs:         newDiffCache(diffCacheSize),
+		prefetchSlots: make(chan struct{}, prefetchWorkers),
 	}, nil
 }
 
-// Status gets the repository status
-func (g *GitRepository) Status() ([]GitFile, error) {
+// Status gets the repository status, sorted by path
+func (g *GitRepository) Status(ctx context.Context) ([]GitFile, error) {
 	if g.repo == nil {
-		return nil, errors.New("repository not initialized")
+		return nil, errNotInitialized
 	}
 
-	wt, err := g.repo.Worktree()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get worktree: %w", err)
-	}
+	return withContext(ctx, &g.mu, func() ([]GitFile, error) {
+		return g.status(ctx)
+	})
+}
 
-	status, err := wt.Status()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get status: %w", err)
-	}
-
-	files := make([]GitFile, 0, len(status))
-	for file, fileStatus := range status {
-		statusStr := ""
-
-		if fileStatus.Staging == git.Untracked && fileStatus.Worktree == git.Untracked {
-			statusStr = "??"
-		} else {
-			staging := " "
-			switch fileStatus.Staging {
-			case git.Added:
-				staging = "A"
-			case git.Modified:
-				staging = "M"
-			case git.Deleted:
-				staging = "D"
-			case git.Renamed:
-				staging = "R"
-			}
-
-			worktree := " "
-			switch fileStatus.Worktree {
-			case git.Added:
-				worktree = "A"
-			case git.Modified:
-				worktree = "M"
-			case git.Deleted:
-				worktree = "D"
-			case git.Untracked:
-				worktree = "?"
-			}
-
-			statusStr = staging + worktree
+// status computes the status for Status, with g.mu held
+func (g *GitRepository) status(ctx context.Context) ([]GitFile, error) {
+	// Only git can use the untracked cache it keeps in the index. The setting is read
+	// once, a cancelled first call must not turn it off for good.
+	g.untrackedCacheOnce.Do(func() {
+		g.untrackedCache = g.untrackedCacheEnabled(context.WithoutCancel(ctx))
+	})
+	if g.untrackedCache {
+		if files, err := g.gitStatus(ctx); err == nil {
+			return files, nil
 		}
+	}
 
-		files = append(files, GitFile{
-			Status: statusStr,
-			Path:   file,
-		})
+	files, err := g.indexStatus(ctx)
+	if err != nil {
+		return nil, classifyCorruption(fmt.Errorf("failed to get status: %w", err))
 	}
 
 	return files, nil
 }
 
-// Stage adds files to the staging area
-func (g *GitRepository) Stage(paths []string) error {
+// Stage adds files to the staging area. Unmerged paths are refused: adding them would
+// overwrite the conflict stages without resolving anything, see MarkResolved.
+func (g *GitRepository) Stage(ctx context.Context, paths []string) error {
 	if g.repo == nil {
-		return errors.New("repository not initialized")
+		return errNotInitialized
+	}
+
+	unmerged, err := g.unmergedPaths()
+	if err != nil {
+		return err
+	}
+	for name := range unmerged {
+		if slices.Contains(paths, ".") || underAny(name, paths) {
+			return fmt.Errorf("%w: cannot stage %s", ErrUnmergedPaths, name)
+		}
+	}
+
+	// Each go-git Add hashes the whole working tree to compute the status, git add
+	// updates the index once for all the paths
+	if len(paths) > 1 {
+		return g.gitExec(ctx, append([]string{"add", "--"}, paths...)...)
 	}
 
 	wt, err := g.repo.Worktree()
@@ -123,9 +174,9 @@ func (g *GitRepository) Stage(paths []string) error {
 }
 
 // Commit creates a new commit with the given message
-func (g *GitRepository) Commit(commitType, message string) error {
+func (g *GitRepository) Commit(ctx context.Context, commitType, message string) error {
 	if g.repo == nil {
-		return errors.New("repository not initialized")
+		return errNotInitialized
 	}
 
 	wt, err := g.repo.Worktree()
@@ -136,20 +187,13 @@ func (g *GitRepository) Commit(commitType, message string) error {
 	// Format the commit message
 	fullMessage := fmt.Sprintf("%s: %s", commitType, message)
 
-	// Get the configuration to retrieve the user's name and email
-	config, err := g.repo.Config()
+	author, err := g.commitAuthor(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get git config: %w", err)
+		return err
 	}
 
 	// Create the commit
-	commit, err := wt.Commit(fullMessage, &git.CommitOptions{
-		Author: &object.Signature{
-			Name:  config.User.Name,
-			Email: config.User.Email,
-			When:  time.Now(),
-		},
-	})
+	commit, err := wt.Commit(fullMessage, &git.CommitOptions{Author: author})
 
 	if err != nil {
 		return fmt.Errorf("failed to create commit: %w", err)
@@ -164,10 +208,49 @@ func (g *GitRepository) Commit(commitType, message string) error {
 	return nil
 }
 
+// commitAuthor checks that the index can be committed and returns the author to commit
+// it as. go-git neither refuses commits without changes nor runs hooks, so repositories
+// with commit hooks are left to the git command line.
+func (g *GitRepository) commitAuthor(ctx context.Context) (*object.Signature, error) {
+	if hasCommitHooks(ctx, g.path) {
+		return nil, errors.New("commit hooks are only run by the git command line")
+	}
+
+	files, err := g.status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if n := CountConflicted(files); n > 0 {
+		return nil, fmt.Errorf("%w: %d conflicted file(s)", ErrUnmergedPaths, n)
+	}
+	if !HasStagedChanges(files) {
+		return nil, ErrNothingStaged
+	}
+
+	cfg, err := g.repo.ConfigScoped(config.GlobalScope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git config: %w", err)
+	}
+
+	// The environment overrides the configuration like it does for git
+	author := &object.Signature{Name: cfg.User.Name, Email: cfg.User.Email, When: time.Now()}
+	if name := os.Getenv("GIT_AUTHOR_NAME"); name != "" {
+		author.Name = name
+	}
+	if email := os.Getenv("GIT_AUTHOR_EMAIL"); email != "" {
+		author.Email = email
+	}
+	if author.Name == "" || author.Email == "" {
+		return nil, ErrIdentityMissing
+	}
+
+	return author, nil
+}
+
 // GetCurrentBranch returns the current branch name
-func (g *GitRepository) GetCurrentBranch() (string, error) {
+func (g *GitRepository) GetCurrentBranch(ctx context.Context) (string, error) {
 	if g.repo == nil {
-		return "", errors.New("repository not initialized")
+		return "", errNotInitialized
 	}
 
 	head, err := g.repo.Head()
@@ -183,35 +266,40 @@ func (g *GitRepository) GetCurrentBranch() (string, error) {
 }
 
 // GetFileDiff returns the diff content for a specific file
-func (g *GitRepository) GetFileDiff(filePath string) (*DiffResult, error) {
+func (g *GitRepository) GetFileDiff(ctx context.Context, filePath string) (*DiffResult, error) {
 	if g.repo == nil {
-		return nil, fmt.Errorf("repository not initialized")
+		return nil, errNotInitialized
 	}
 
-	wt, err := g.repo.Worktree()
+	return withContext(ctx, &g.mu, func() (*DiffResult, error) {
+		return g.fileDiff(filePath)
+	})
+}
+
+// fileDiff computes the diff of a file in the status, or returns it from the diff cache
+// when neither HEAD, the index nor the file changed since it was computed
+func (g *GitRepository) fileDiff(filePath string) (*DiffResult, error) {
+	key, err := g.diffKey(filePath)
 	if err != nil {
 		return nil, err
 	}
+	if diff, ok := g.diffs.get(key); ok {
+		return diff, nil
+	}
 
-	// Get the working tree status for the file
-	status, err := wt.Status()
+	// Files that are not in HEAD, untracked ones included, are diffed as new files
+	diff, err := g.diffBetweenHeadAndWorktree(filePath)
 	if err != nil {
 		return nil, err
 	}
 
-	fileStatus, ok := status[filePath]
-	if !ok {
-		return nil, fmt.Errorf("file %s not found in status", filePath)
-	}
-
-	// Check if this is an untracked file
-	if fileStatus.Worktree == git.Untracked {
-		// For untracked files, return all content as added
-		return g.diffForNewFile(filePath)
+	// A file that matches HEAD in the index and the working tree has no changes
+	if key.head == key.index && !diff.IsBinary && diff.Stats == (DiffStats{}) {
+		return nil, fmt.Errorf("%w: %s", ErrFileNotFound, filePath)
 	}
 
-	// For tracked files, get diff between HEAD and working tree
-	return g.diffBetweenHeadAndWorktree(filePath)
+	g.diffs.add(key, diff)
+	return diff, nil
 }
 
 // diffForNewFile creates a diff for untracked files
@@ -1,16 +1,60 @@
 package git
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 )
 
 // GitService is an interface for git operations
 type GitService interface {
-	Status() ([]GitFile, error)
-	Stage(paths []string) error
-	Commit(commitType, message string) error
-	GetFileDiff(path string) (*DiffResult, error)
+	Status(ctx context.Context) ([]GitFile, error)
+	Stage(ctx context.Context, paths []string) error
+	Unstage(ctx context.Context, paths []string) error
+	Ignore(ctx context.Context, paths []string) error
+	DeleteUntracked(ctx context.Context, paths []string) error
+	Commit(ctx context.Context, commitType, message string) error
+	GetFileDiff(ctx context.Context, path string) (*DiffResult, error)
+	CachedFileDiff(ctx context.Context, path string) (*DiffResult, bool)
+	PrefetchDiffs(ctx context.Context, paths []string) error
+	Watch(ctx context.Context, debounce time.Duration) (<-chan struct{}, error)
+	LoadConflict(ctx context.Context, path string) (*ConflictFile, error)
+	ResolveConflict(ctx context.Context, path, content string) error
+	MarkResolved(ctx context.Context, path string) error
+	State(ctx context.Context) (*RepoState, error)
+	ContinueOperation(ctx context.Context, message string) error
+	AbortOperation(ctx context.Context) error
+	Log(ctx context.Context, opts LogOptions) ([]CommitInfo, error)
+	CommitsBetween(ctx context.Context, base, head string) ([]CommitInfo, error)
+	StartRebase(ctx context.Context, base string, steps []RebaseStep) (*RepoState, error)
+	Upstream(ctx context.Context) (string, error)
+	BranchStatus(ctx context.Context) (*BranchStatus, error)
+	Branches(ctx context.Context) ([]Branch, error)
+	UnpushedCommits(ctx context.Context, limit int) ([]CommitInfo, error)
+	CommitFixup(ctx context.Context, kind FixupKind, target CommitInfo, message string) error
+	Autosquash(ctx context.Context) (*RepoState, error)
+	Blame(ctx context.Context, path, rev string) (*BlameResult, error)
+	CommitDiff(ctx context.Context, hash string) ([]DiffResult, error)
+	FileHistory(ctx context.Context, path string, limit int) ([]FileRevision, error)
+	FileDiffAt(ctx context.Context, hash, path string) (*DiffResult, error)
+	RestoreFileAt(ctx context.Context, rev, path, dest string) error
+	CherryPick(ctx context.Context, commits []CommitInfo) (*PickResult, error)
+	Revert(ctx context.Context, commits []CommitInfo) (*PickResult, error)
+	Tags(ctx context.Context) ([]Tag, error)
+	CreateTag(ctx context.Context, name, message string) error
+	DeleteTag(ctx context.Context, name string) error
+	PlanRelease(ctx context.Context) (*ReleasePlan, error)
+	PreviousRelease(ctx context.Context, rev string) (*Tag, error)
+	ReleaseCommits(ctx context.Context, from, to string) ([]CommitInfo, error)
+	Remotes(ctx context.Context) ([]Remote, error)
+	AddRemote(ctx context.Context, name, url string) error
+	RenameRemote(ctx context.Context, oldName, newName string) error
+	RemoveRemote(ctx context.Context, name string) error
+	SetRemoteURL(ctx context.Context, name, url string, push bool) error
 }
 
 // DefaultGitService provides an implementation of GitService interface
@@ -35,40 +79,285 @@ func NewGitService() (*DefaultGitService, error) {
 	}
 
 	return &DefaultGitService{
-		repo: repo,
+		repo: &loggedRepository{repo: &lockedRepository{repo: repo}},
 	}, nil
 }
 
-func (s *DefaultGitService) Status() ([]GitFile, error) {
-	files, err := s.repo.Status()
+func (s *DefaultGitService) Status(ctx context.Context) ([]GitFile, error) {
+	files, err := s.repo.Status(ctx)
 	if err != nil {
 		// Fall back to exec implementation if go-git fails
-		return GetStatus()
+		return GetStatus(ctx)
 	}
 	return files, nil
 }
 
-func (s *DefaultGitService) Stage(paths []string) error {
-	err := s.repo.Stage(paths)
+func (s *DefaultGitService) Stage(ctx context.Context, paths []string) error {
+	err := s.repo.Stage(ctx, paths)
+	if errors.Is(err, ErrUnmergedPaths) {
+		// git add would mark the conflicts resolved, leaving the markers in the files
+		return err
+	}
 	if err != nil {
 		// Fall back to exec implementation if go-git fails
-		return StageFiles(paths)
+		return StageFiles(ctx, paths)
 	}
 	return nil
 }
 
-func (s *DefaultGitService) Commit(commitType, message string) error {
-	err := s.repo.Commit(commitType, message)
+// Unstage resets files or folders in the index to HEAD
+func (s *DefaultGitService) Unstage(ctx context.Context, paths []string) error {
+	err := s.repo.Unstage(ctx, paths)
 	if err != nil {
 		// Fall back to exec implementation if go-git fails
-		return Commit(commitType, message)
+		return UnstageFiles(ctx, paths)
+	}
+	return nil
+}
+
+// Ignore adds files or folders to the .gitignore at the root of the working tree
+func (s *DefaultGitService) Ignore(ctx context.Context, paths []string) error {
+	return s.repo.Ignore(ctx, paths)
+}
+
+// DeleteUntracked deletes untracked files from the working tree
+func (s *DefaultGitService) DeleteUntracked(ctx context.Context, paths []string) error {
+	return s.repo.DeleteUntracked(ctx, paths)
+}
+
+func (s *DefaultGitService) Commit(ctx context.Context, commitType, message string) error {
+	err := s.repo.Commit(ctx, commitType, message)
+	if err != nil {
+		// Fall back to exec implementation if go-git fails
+		return Commit(ctx, commitType, message)
 	}
 	return nil
 }
 
 // GetFileDiff is a convenience method on the service
-func (s *DefaultGitService) GetFileDiff(path string) (*DiffResult, error) {
-	return s.repo.GetFileDiff(path)
+func (s *DefaultGitService) GetFileDiff(ctx context.Context, path string) (*DiffResult, error) {
+	return s.repo.GetFileDiff(ctx, path)
+}
+
+// CachedFileDiff returns the diff of a file without computing it, if it is cached
+func (s *DefaultGitService) CachedFileDiff(ctx context.Context, path string) (*DiffResult, bool) {
+	return s.repo.CachedFileDiff(ctx, path)
+}
+
+// PrefetchDiffs computes the diffs of files in the background so that GetFileDiff
+// returns them from the cache
+func (s *DefaultGitService) PrefetchDiffs(ctx context.Context, paths []string) error {
+	return s.repo.PrefetchDiffs(ctx, paths)
+}
+
+// Watch reports changes that may change the status, debounced, until ctx is done
+func (s *DefaultGitService) Watch(ctx context.Context, debounce time.Duration) (<-chan struct{}, error) {
+	return s.repo.Watch(ctx, debounce)
+}
+
+// LoadConflict parses the conflict hunks of an unmerged file
+func (s *DefaultGitService) LoadConflict(ctx context.Context, path string) (*ConflictFile, error) {
+	return s.repo.LoadConflict(ctx, path)
+}
+
+// ResolveConflict writes the resolved content of a conflicted file and marks it resolved
+func (s *DefaultGitService) ResolveConflict(ctx context.Context, path, content string) error {
+	return s.repo.ResolveConflict(ctx, path, content)
+}
+
+func (s *DefaultGitService) MarkResolved(ctx context.Context, path string) error {
+	err := s.repo.MarkResolved(ctx, path)
+	if err != nil {
+		// Fall back to exec implementation if go-git fails
+		return StageResolved(ctx, path)
+	}
+	return nil
+}
+
+// State reports the merge, rebase, cherry-pick or revert in progress, if any
+func (s *DefaultGitService) State(ctx context.Context) (*RepoState, error) {
+	return s.repo.State(ctx)
+}
+
+// ContinueOperation commits the in-progress operation with the given message
+func (s *DefaultGitService) ContinueOperation(ctx context.Context, message string) error {
+	return s.repo.ContinueOperation(ctx, message)
+}
+
+// AbortOperation abandons the in-progress operation
+func (s *DefaultGitService) AbortOperation(ctx context.Context) error {
+	return s.repo.AbortOperation(ctx)
+}
+
+// Log returns commit summaries, newest first
+func (s *DefaultGitService) Log(ctx context.Context, opts LogOptions) ([]CommitInfo, error) {
+	return s.repo.Log(ctx, opts)
+}
+
+// CommitsBetween returns the commits after base up to head, oldest first
+func (s *DefaultGitService) CommitsBetween(ctx context.Context, base, head string) ([]CommitInfo, error) {
+	return s.repo.CommitsBetween(ctx, base, head)
+}
+
+// StartRebase runs an interactive rebase onto base with the given todo list
+func (s *DefaultGitService) StartRebase(ctx context.Context, base string, steps []RebaseStep) (*RepoState, error) {
+	return s.repo.StartRebase(ctx, base, steps)
+}
+
+// Upstream returns the remote-tracking branch of the current branch, or "" without one
+func (s *DefaultGitService) Upstream(ctx context.Context) (string, error) {
+	return s.repo.Upstream(ctx)
+}
+
+// BranchStatus returns the current branch, its upstream and how far they have diverged
+func (s *DefaultGitService) BranchStatus(ctx context.Context) (*BranchStatus, error) {
+	return s.repo.BranchStatus(ctx)
+}
+
+// Branches returns the local branches sorted by name
+func (s *DefaultGitService) Branches(ctx context.Context) ([]Branch, error) {
+	return s.repo.Branches(ctx)
+}
+
+// UnpushedCommits returns the commits that are not on upstream, newest first
+func (s *DefaultGitService) UnpushedCommits(ctx context.Context, limit int) ([]CommitInfo, error) {
+	return s.repo.UnpushedCommits(ctx, limit)
+}
+
+func (s *DefaultGitService) CommitFixup(ctx context.Context, kind FixupKind, target CommitInfo, message string) error {
+	err := s.repo.CommitFixup(ctx, kind, target, message)
+	if err != nil {
+		// Fall back to exec implementation if go-git fails
+		return CommitFixup(ctx, kind, target, message)
+	}
+	return nil
+}
+
+// Autosquash folds fixup and amend commits into their targets before pushing
+func (s *DefaultGitService) Autosquash(ctx context.Context) (*RepoState, error) {
+	return s.repo.Autosquash(ctx)
+}
+
+// Blame annotates each line of path at rev with the commit that last changed it
+func (s *DefaultGitService) Blame(ctx context.Context, path, rev string) (*BlameResult, error) {
+	return s.repo.Blame(ctx, path, rev)
+}
+
+// CommitDiff returns the per-file changes of a commit against its first parent
+func (s *DefaultGitService) CommitDiff(ctx context.Context, hash string) ([]DiffResult, error) {
+	return s.repo.CommitDiff(ctx, hash)
+}
+
+// FileHistory returns the commits that changed path, newest first, following renames
+func (s *DefaultGitService) FileHistory(ctx context.Context, path string, limit int) ([]FileRevision, error) {
+	return s.repo.FileHistory(ctx, path, limit)
+}
+
+// FileDiffAt returns the change a commit made to a single file
+func (s *DefaultGitService) FileDiffAt(ctx context.Context, hash, path string) (*DiffResult, error) {
+	return s.repo.FileDiffAt(ctx, hash, path)
+}
+
+// RestoreFileAt overwrites dest in the working tree with path as it was at rev
+func (s *DefaultGitService) RestoreFileAt(ctx context.Context, rev, path, dest string) error {
+	return s.repo.RestoreFileAt(ctx, rev, path, dest)
+}
+
+// CherryPick applies commits onto HEAD, stopping on the first conflict
+func (s *DefaultGitService) CherryPick(ctx context.Context, commits []CommitInfo) (*PickResult, error) {
+	return s.repo.CherryPick(ctx, commits)
+}
+
+// Revert reverts commits with conventional messages, stopping on the first conflict
+func (s *DefaultGitService) Revert(ctx context.Context, commits []CommitInfo) (*PickResult, error) {
+	return s.repo.Revert(ctx, commits)
+}
+
+func (s *DefaultGitService) Tags(ctx context.Context) ([]Tag, error) {
+	tags, err := s.repo.Tags(ctx)
+	if err != nil {
+		// Fall back to exec implementation if go-git fails
+		return ListTags(ctx)
+	}
+	return tags, nil
+}
+
+func (s *DefaultGitService) CreateTag(ctx context.Context, name, message string) error {
+	err := s.repo.CreateTag(ctx, name, message)
+	if err != nil {
+		// Fall back to exec implementation if go-git fails
+		return CreateTag(ctx, name, message)
+	}
+	return nil
+}
+
+func (s *DefaultGitService) DeleteTag(ctx context.Context, name string) error {
+	err := s.repo.DeleteTag(ctx, name)
+	if err != nil {
+		// Fall back to exec implementation if go-git fails
+		return DeleteTag(ctx, name)
+	}
+	return nil
+}
+
+// PlanRelease proposes the next version from the conventional commits since the last release
+func (s *DefaultGitService) PlanRelease(ctx context.Context) (*ReleasePlan, error) {
+	return s.repo.PlanRelease(ctx)
+}
+
+// PreviousRelease returns the newest release tag reachable from rev, excluding tags on rev
+func (s *DefaultGitService) PreviousRelease(ctx context.Context, rev string) (*Tag, error) {
+	return s.repo.PreviousRelease(ctx, rev)
+}
+
+// ReleaseCommits returns the commits after from up to to, oldest first
+func (s *DefaultGitService) ReleaseCommits(ctx context.Context, from, to string) ([]CommitInfo, error) {
+	return s.repo.ReleaseCommits(ctx, from, to)
+}
+
+func (s *DefaultGitService) Remotes(ctx context.Context) ([]Remote, error) {
+	remotes, err := s.repo.Remotes(ctx)
+	if err != nil {
+		// Fall back to exec implementation if go-git fails
+		return ListRemotes(ctx)
+	}
+	return remotes, nil
+}
+
+func (s *DefaultGitService) AddRemote(ctx context.Context, name, url string) error {
+	err := s.repo.AddRemote(ctx, name, url)
+	if err != nil {
+		// Fall back to exec implementation if go-git fails
+		return AddRemote(ctx, name, url)
+	}
+	return nil
+}
+
+func (s *DefaultGitService) RenameRemote(ctx context.Context, oldName, newName string) error {
+	err := s.repo.RenameRemote(ctx, oldName, newName)
+	if err != nil {
+		// Fall back to exec implementation if go-git fails
+		return RenameRemote(ctx, oldName, newName)
+	}
+	return nil
+}
+
+func (s *DefaultGitService) RemoveRemote(ctx context.Context, name string) error {
+	err := s.repo.RemoveRemote(ctx, name)
+	if err != nil {
+		// Fall back to exec implementation if go-git fails
+		return RemoveRemote(ctx, name)
+	}
+	return nil
+}
+
+func (s *DefaultGitService) SetRemoteURL(ctx context.Context, name, url string, push bool) error {
+	err := s.repo.SetRemoteURL(ctx, name, url, push)
+	if err != nil {
+		// Fall back to exec implementation if go-git fails
+		return SetRemoteURL(ctx, name, url, push)
+	}
+	return nil
 }
 
 func findGitRepository(startPath string) (string, error) {
@@ -80,8 +369,34 @@ func findGitRepository(startPath string) (string, error) {
 
 		parentPath := filepath.Dir(path)
 		if parentPath == path {
-			return "", os.ErrNotExist
+			return "", fmt.Errorf("%w: %s: %w", ErrNotRepository, startPath, os.ErrNotExist)
 		}
 		path = parentPath
 	}
 }
+
+// RelativePath converts a path given relative to the working directory into the
+// slash-separated path of the file inside the repository
+func RelativePath(path string) (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	root, err := findGitRepository(cwd)
+	if err != nil {
+		return "", err
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside the repository", path)
+	}
+
+	return filepath.ToSlash(rel), nil
+}
@@ -0,0 +1,250 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Operation identifies a multi-step git operation that is waiting for the user
+type Operation int
+
+const (
+	// OperationNone means no merge, rebase, cherry-pick or revert is in progress
+	OperationNone Operation = iota
+	// OperationMerge means MERGE_HEAD exists
+	OperationMerge
+	// OperationRebase means a rebase-merge or rebase-apply directory exists
+	OperationRebase
+	// OperationCherryPick means CHERRY_PICK_HEAD exists
+	OperationCherryPick
+	// OperationRevert means REVERT_HEAD exists
+	OperationRevert
+)
+
+// String returns the operation name as shown in git status
+func (o Operation) String() string {
+	switch o {
+	case OperationMerge:
+		return "merge"
+	case OperationRebase:
+		return "rebase"
+	case OperationCherryPick:
+		return "cherry-pick"
+	case OperationRevert:
+		return "revert"
+	default:
+		return "none"
+	}
+}
+
+// RepoState describes the operation in progress and the data git prepared for it
+type RepoState struct {
+	Operation Operation
+	Head      string // Commit being merged, picked or reverted
+	Message   string // Prepared commit message with comment lines removed
+	Step      int    // Current rebase step, when known
+	Total     int    // Total rebase steps, when known
+}
+
+// InProgress reports whether an operation is waiting to be continued or aborted
+func (s *RepoState) InProgress() bool {
+	return s != nil && s.Operation != OperationNone
+}
+
+// Summary returns a one-line description suitable for a banner
+func (s *RepoState) Summary() string {
+	if !s.InProgress() {
+		return ""
+	}
+
+	head := s.Head
+	if len(head) > 7 {
+		head = head[:7]
+	}
+
+	switch s.Operation {
+	case OperationRebase:
+		if s.Total > 0 {
+			return fmt.Sprintf("REBASING (%d/%d)", s.Step, s.Total)
+		}
+		return "REBASING"
+	case OperationMerge:
+		return fmt.Sprintf("MERGING %s", head)
+	case OperationCherryPick:
+		return fmt.Sprintf("CHERRY-PICKING %s", head)
+	case OperationRevert:
+		return fmt.Sprintf("REVERTING %s", head)
+	}
+	return ""
+}
+
+// gitDir returns the git directory, following the "gitdir:" file used by worktrees and submodules
+func (g *GitRepository) gitDir() string {
+	dotGit := filepath.Join(g.path, ".git")
+
+	info, err := os.Stat(dotGit)
+	if err != nil || info.IsDir() {
+		return dotGit
+	}
+
+	content, err := os.ReadFile(dotGit)
+	if err != nil {
+		return dotGit
+	}
+
+	dir := strings.TrimSpace(strings.TrimPrefix(string(content), "gitdir:"))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(g.path, dir)
+	}
+	return dir
+}
+
+// State inspects the git directory for an in-progress merge, rebase, cherry-pick or revert
+func (g *GitRepository) State(ctx context.Context) (*RepoState, error) {
+	if g.repo == nil {
+		return nil, errNotInitialized
+	}
+
+	dir := g.gitDir()
+	state := &RepoState{}
+
+	switch {
+	case exists(filepath.Join(dir, "rebase-merge")):
+		state.Operation = OperationRebase
+		state.Step = readInt(filepath.Join(dir, "rebase-merge", "msgnum"))
+		state.Total = readInt(filepath.Join(dir, "rebase-merge", "end"))
+		state.Head = readTrimmed(filepath.Join(dir, "rebase-merge", "stopped-sha"))
+		state.Message = cleanMessage(readTrimmed(filepath.Join(dir, "rebase-merge", "message")))
+	case exists(filepath.Join(dir, "rebase-apply")):
+		state.Operation = OperationRebase
+		state.Step = readInt(filepath.Join(dir, "rebase-apply", "next"))
+		state.Total = readInt(filepath.Join(dir, "rebase-apply", "last"))
+	case exists(filepath.Join(dir, "MERGE_HEAD")):
+		state.Operation = OperationMerge
+		state.Head = readTrimmed(filepath.Join(dir, "MERGE_HEAD"))
+	case exists(filepath.Join(dir, "CHERRY_PICK_HEAD")):
+		state.Operation = OperationCherryPick
+		state.Head = readTrimmed(filepath.Join(dir, "CHERRY_PICK_HEAD"))
+	case exists(filepath.Join(dir, "REVERT_HEAD")):
+		state.Operation = OperationRevert
+		state.Head = readTrimmed(filepath.Join(dir, "REVERT_HEAD"))
+	}
+
+	if state.InProgress() && state.Message == "" {
+		state.Message = cleanMessage(readTrimmed(filepath.Join(dir, "MERGE_MSG")))
+	}
+
+	return state, nil
+}
+
+// ContinueOperation finishes the in-progress operation, committing with message when it is not empty
+func (g *GitRepository) ContinueOperation(ctx context.Context, message string) error {
+	state, err := g.State(ctx)
+	if err != nil {
+		return err
+	}
+
+	if message == "" {
+		message = state.Message
+	}
+
+	// Without an editor git uses the prepared message, so write the edited one there first
+	if message != "" {
+		target := filepath.Join(g.gitDir(), "MERGE_MSG")
+		if state.Operation == OperationRebase && exists(filepath.Join(g.gitDir(), "rebase-merge")) {
+			target = filepath.Join(g.gitDir(), "rebase-merge", "message")
+		}
+		if err := os.WriteFile(target, []byte(message+"\n"), 0o644); err != nil {
+			return fmt.Errorf("failed to write commit message: %w", err)
+		}
+	}
+
+	switch state.Operation {
+	case OperationMerge:
+		return g.gitExec(ctx, "commit", "--no-edit")
+	case OperationRebase:
+		return g.gitExec(ctx, "rebase", "--continue")
+	case OperationCherryPick:
+		return g.gitExec(ctx, "cherry-pick", "--continue")
+	case OperationRevert:
+		return g.gitExec(ctx, "revert", "--continue")
+	default:
+		return ErrNoOperation
+	}
+}
+
+// AbortOperation abandons the in-progress operation and restores the previous HEAD
+func (g *GitRepository) AbortOperation(ctx context.Context) error {
+	state, err := g.State(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch state.Operation {
+	case OperationMerge:
+		return g.gitExec(ctx, "merge", "--abort")
+	case OperationRebase:
+		return g.gitExec(ctx, "rebase", "--abort")
+	case OperationCherryPick:
+		return g.gitExec(ctx, "cherry-pick", "--abort")
+	case OperationRevert:
+		return g.gitExec(ctx, "revert", "--abort")
+	default:
+		return ErrNoOperation
+	}
+}
+
+// gitExec runs a git command in the repository with editors disabled so it never blocks on input
+func (g *GitRepository) gitExec(ctx context.Context, args ...string) error {
+	return g.gitExecEnv(ctx, nil, args...)
+}
+
+// gitExecEnv runs a git command like gitExec with additional environment variables
+func (g *GitRepository) gitExecEnv(ctx context.Context, env []string, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = g.path
+	cmd.Env = append(append(os.Environ(), "GIT_EDITOR=true"), env...)
+	if output, err := runCommand(cmd, cmd.CombinedOutput); err != nil {
+		return classify(fmt.Errorf("git %s failed: %w\nOutput: %s", args[0], err, output), output)
+	}
+
+	return nil
+}
+
+// cleanMessage strips git's comment lines and surrounding blank lines from a prepared message
+func cleanMessage(message string) string {
+	var lines []string
+	for _, line := range strings.Split(message, "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// exists reports whether a file or directory exists
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// readTrimmed returns the whitespace-trimmed content of a file, or "" if it cannot be read
+func readTrimmed(path string) string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(content))
+}
+
+// readInt returns the integer stored in a file, or 0 if it cannot be read
+func readInt(path string) int {
+	n, _ := strconv.Atoi(readTrimmed(path))
+	return n
+}
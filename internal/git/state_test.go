@@ -0,0 +1,111 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupConflictedMerge creates a repository that is stopped in a conflicted merge of "feature"
+func setupConflictedMerge(t *testing.T) string {
+	t.Helper()
+
+	repoPath := setupTestRepo(t)
+	write := func(content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(repoPath, "file.txt"), []byte(content), 0o644))
+	}
+
+	write("a\nb\nc\n")
+	runGit(t, repoPath, "add", ".")
+	runGit(t, repoPath, "commit", "-m", "init")
+	runGit(t, repoPath, "checkout", "-b", "feature")
+	write("a\nfeature\nc\n")
+	runGit(t, repoPath, "commit", "-am", "feature")
+	runGit(t, repoPath, "checkout", "-")
+	write("a\nmain\nc\n")
+	runGit(t, repoPath, "commit", "-am", "main")
+
+	// The merge is expected to stop with a conflict
+	_ = gitCommand(repoPath, "merge", "feature").Run()
+
+	return repoPath
+}
+
+func TestState(t *testing.T) {
+	tests := []struct {
+		name      string
+		setup     func(t *testing.T) string
+		wantOp    Operation
+		wantInMsg string
+	}{
+		{
+			name:   "GIVEN a clean repository THEN no operation is in progress",
+			setup:  setupTestRepo,
+			wantOp: OperationNone,
+		},
+		{
+			name:      "GIVEN a conflicted merge THEN merge is reported with the prepared message",
+			setup:     setupConflictedMerge,
+			wantOp:    OperationMerge,
+			wantInMsg: "Merge branch 'feature'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repoPath := tc.setup(t)
+			defer cleanupTestRepo(t, repoPath)
+
+			repo, err := NewGitRepository(repoPath)
+			require.NoError(t, err)
+
+			state, err := repo.State(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantOp, state.Operation)
+			assert.Equal(t, tc.wantOp != OperationNone, state.InProgress())
+			assert.Contains(t, state.Message, tc.wantInMsg)
+			assert.NotContains(t, state.Message, "#")
+		})
+	}
+}
+
+func TestContinueAndAbortOperation(t *testing.T) {
+	t.Run("GIVEN a conflicted merge WHEN aborted THEN no operation remains", func(t *testing.T) {
+		repoPath := setupConflictedMerge(t)
+		defer cleanupTestRepo(t, repoPath)
+
+		repo, err := NewGitRepository(repoPath)
+		require.NoError(t, err)
+		require.NoError(t, repo.AbortOperation(t.Context()))
+
+		state, err := repo.State(t.Context())
+		require.NoError(t, err)
+		assert.False(t, state.InProgress())
+	})
+
+	t.Run("GIVEN a resolved merge WHEN continued THEN a merge commit is created", func(t *testing.T) {
+		repoPath := setupConflictedMerge(t)
+		defer cleanupTestRepo(t, repoPath)
+
+		repo, err := NewGitRepository(repoPath)
+		require.NoError(t, err)
+		require.NoError(t, repo.ResolveConflict(t.Context(), "file.txt", "a\nresolved\nc\n"))
+
+		setTestIdentity(t)
+		require.NoError(t, repo.ContinueOperation(t.Context(), "Merge feature into main"))
+
+		state, err := repo.State(t.Context())
+		require.NoError(t, err)
+		assert.False(t, state.InProgress())
+
+		head, err := repo.repo.Head()
+		require.NoError(t, err)
+		commit, err := repo.repo.CommitObject(head.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, 2, commit.NumParents())
+		assert.Equal(t, "Merge feature into main\n", commit.Message)
+	})
+}
@@ -0,0 +1,14 @@
+//go:build !unix
+
+package git
+
+import (
+	"os"
+
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+)
+
+// inodeMatches always reports true: git does not record inodes on this platform
+func inodeMatches(e *index.Entry, info os.FileInfo) bool {
+	return true
+}
@@ -0,0 +1,20 @@
+//go:build unix
+
+package git
+
+import (
+	"os"
+	"syscall"
+
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+)
+
+// inodeMatches reports whether a file is still the inode recorded in the index. Indexes
+// written without inodes, such as with core.checkStat=minimal, match any file.
+func inodeMatches(e *index.Entry, info os.FileInfo) bool {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok || e.Inode == 0 {
+		return true
+	}
+	return e.Inode == uint32(stat.Ino) && e.Dev == uint32(stat.Dev)
+}
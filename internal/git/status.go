@@ -0,0 +1,465 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+)
+
+// indexStatus computes the status like git status --porcelain --untracked-files=all
+// --no-renames. Unlike go-git's Worktree.Status, which hashes every file, it trusts the
+// stat data git keeps in the index and only hashes files whose modification time, size
+// or inode changed, or that were modified too close to the index to tell.
+// The files are sorted by path.
+func (g *GitRepository) indexStatus(ctx context.Context) ([]GitFile, error) {
+	idx, err := g.repo.Storer.Index()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+
+	head, err := g.headEntries(idx)
+	if err != nil {
+		return nil, err
+	}
+
+	unmerged, err := g.unmergedPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	check, err := g.newWorktreeCheck()
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make(map[string]string)
+	tracked := make(map[string]bool, len(idx.Entries))
+	for i, e := range idx.Entries {
+		if i%1024 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
+		// go-git's index.Merged is 1, the stage of merged entries is 0
+		tracked[e.Name] = true
+		if e.Stage != 0 {
+			continue
+		}
+
+		staging, worktree := byte(' '), byte(' ')
+		if head != nil {
+			if h, ok := head[e.Name]; !ok {
+				staging = 'A'
+			} else if h.Hash != e.Hash || h.Mode != e.Mode {
+				staging = 'M'
+			}
+		}
+
+		switch {
+		case e.IntentToAdd:
+			staging, worktree = ' ', 'A'
+		case !e.SkipWorktree:
+			worktree = check.code(e)
+		}
+
+		if staging != ' ' || worktree != ' ' {
+			codes[e.Name] = string([]byte{staging, worktree})
+		}
+	}
+
+	for name := range head {
+		if !tracked[name] {
+			codes[name] = "D "
+		}
+	}
+
+	// Index stages are only set for conflicts, whose code is taken from them
+	for name, code := range unmerged {
+		codes[name] = code
+	}
+
+	untracked, err := g.untrackedFiles(ctx, tracked)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]GitFile, 0, len(codes)+len(untracked))
+	for name, code := range codes {
+		files = append(files, GitFile{Status: code, Path: name})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	// A file removed from the index only is both a staged deletion and untracked; like
+	// git, both are listed, the tracked change first
+	for _, name := range untracked {
+		files = append(files, GitFile{Status: "??", Path: name})
+	}
+	sort.SliceStable(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	return files, nil
+}
+
+// headEntries returns the files in the HEAD tree by path, an empty map when HEAD is
+// unborn, or nil when the index still matches HEAD according to its cache tree
+func (g *GitRepository) headEntries(idx *index.Index) (map[string]object.TreeEntry, error) {
+	ref, err := g.repo.Head()
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return map[string]object.TreeEntry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	commit, err := g.repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	// git records the tree the index would be written as; go-git drops the record when
+	// it writes the index, so a recorded tree is current
+	if idx.Cache != nil && len(idx.Cache.Entries) > 0 {
+		root := idx.Cache.Entries[0]
+		if root.Path == "" && root.Entries >= 0 && root.Hash == commit.TreeHash {
+			return nil, nil
+		}
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]object.TreeEntry)
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+	for {
+		name, entry, err := walker.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if entry.Mode != filemode.Dir {
+			entries[name] = entry
+		}
+	}
+
+	return entries, nil
+}
+
+// worktreeCheck compares index entries with the files in the working tree
+type worktreeCheck struct {
+	root       string
+	indexMtime time.Time // Files modified since may have changed without a stat change
+	fileMode   bool      // core.fileMode: the executable bit is tracked
+}
+
+func (g *GitRepository) newWorktreeCheck() (*worktreeCheck, error) {
+	check := &worktreeCheck{root: g.path, fileMode: true}
+
+	if fs, ok := g.repo.Storer.(*filesystem.Storage); ok {
+		info, err := fs.Filesystem().Stat("index")
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read index: %w", err)
+		}
+		if err == nil {
+			check.indexMtime = info.ModTime()
+		}
+	}
+
+	cfg, err := g.repo.Config()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git config: %w", err)
+	}
+	if strings.EqualFold(cfg.Raw.Section("core").Option("filemode"), "false") {
+		check.fileMode = false
+	}
+
+	return check, nil
+}
+
+// code returns the worktree column of the status of a tracked file
+func (c *worktreeCheck) code(e *index.Entry) byte {
+	file := filepath.Join(c.root, filepath.FromSlash(e.Name))
+	info, err := os.Lstat(file)
+	if err != nil || info.IsDir() {
+		if e.Mode == filemode.Submodule && err == nil {
+			// Submodules are checked out as directories and are not looked into
+			return ' '
+		}
+		return 'D'
+	}
+
+	mode, err := filemode.NewFromOSFileMode(info.Mode())
+	if err != nil {
+		return 'M'
+	}
+	if mode != e.Mode && (c.fileMode || !mode.IsRegular() || !e.Mode.IsRegular()) {
+		return 'M'
+	}
+
+	// An entry written in the same instant as the index may hide a later change of the
+	// same size, so git's "racy" entries are hashed like changed ones
+	if statMatches(e, info) && e.ModifiedAt.Before(c.indexMtime) {
+		return ' '
+	}
+
+	hash, err := hashWorktreeFile(file, info)
+	if err != nil || hash != e.Hash {
+		return 'M'
+	}
+	return ' '
+}
+
+// statMatches reports whether the stat data recorded in the index matches the file
+func statMatches(e *index.Entry, info os.FileInfo) bool {
+	if e.Size != uint32(info.Size()) {
+		return false
+	}
+
+	// The index may have been written without nanoseconds
+	mtime := info.ModTime()
+	if e.ModifiedAt.Nanosecond() == 0 {
+		mtime = mtime.Truncate(time.Second)
+	}
+	if !e.ModifiedAt.Equal(mtime) {
+		return false
+	}
+
+	return inodeMatches(e, info)
+}
+
+// hashWorktreeFile returns the blob hash of a file, or of the target of a symbolic link
+func hashWorktreeFile(file string, info os.FileInfo) (plumbing.Hash, error) {
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(file)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		return plumbing.ComputeHash(plumbing.BlobObject, []byte(filepath.ToSlash(target))), nil
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	defer f.Close()
+
+	hasher := plumbing.NewHasher(plumbing.BlobObject, info.Size())
+	if _, err := io.Copy(hasher, f); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return hasher.Sum(), nil
+}
+
+// untrackedFiles returns the files in the working tree that are neither tracked nor ignored
+func (g *GitRepository) untrackedFiles(ctx context.Context, tracked map[string]bool) ([]string, error) {
+	var untracked []string
+	err := g.walkWorktree(ctx, tracked, func(name string, entry fs.DirEntry) error {
+		if entry != nil && !entry.IsDir() && !tracked[name] {
+			untracked = append(untracked, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return untracked, nil
+}
+
+// walkWorktree calls visit for the working tree root, with a nil entry, and for every
+// directory and file in it that is tracked or not ignored by a .gitignore file or
+// info/exclude. Directories are visited before their content. Ignored directories are
+// not entered; neither are nested repositories.
+func (g *GitRepository) walkWorktree(ctx context.Context, tracked map[string]bool, visit func(name string, entry fs.DirEntry) error) error {
+	var patterns []gitignore.Pattern
+	if data, err := os.ReadFile(filepath.Join(g.gitDir(), "info", "exclude")); err == nil {
+		patterns = append(patterns, parseIgnore(data, nil)...)
+	}
+
+	// Directories holding tracked files are entered even if they match a pattern
+	trackedDirs := dirsOf(tracked)
+
+	var walk func(dir string, domain []string, patterns []gitignore.Pattern) error
+	walk = func(dir string, domain []string, patterns []gitignore.Pattern) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		full := filepath.Join(g.path, filepath.FromSlash(dir))
+		if data, err := os.ReadFile(filepath.Join(full, ".gitignore")); err == nil {
+			patterns = append(patterns[:len(patterns):len(patterns)], parseIgnore(data, domain)...)
+		}
+		matcher := gitignore.NewMatcher(patterns)
+
+		entries, err := os.ReadDir(full)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			name := path.Join(dir, entry.Name())
+			parts := append(domain[:len(domain):len(domain)], entry.Name())
+
+			if entry.IsDir() {
+				if entry.Name() == ".git" || tracked[name] {
+					continue
+				}
+				if !trackedDirs[name] {
+					if matcher.Match(parts, true) {
+						continue
+					}
+					if _, err := os.Lstat(filepath.Join(full, entry.Name(), ".git")); err == nil {
+						continue
+					}
+				}
+				if err := visit(name, entry); err != nil {
+					return err
+				}
+				if err := walk(name, parts, patterns); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if tracked[name] || !matcher.Match(parts, false) {
+				if err := visit(name, entry); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	if err := visit("", nil); err != nil {
+		return err
+	}
+	return walk("", nil, patterns)
+}
+
+// dirsOf returns the directories holding the tracked files
+func dirsOf(tracked map[string]bool) map[string]bool {
+	dirs := make(map[string]bool)
+	for name := range tracked {
+		for dir := path.Dir(name); dir != "." && !dirs[dir]; dir = path.Dir(dir) {
+			dirs[dir] = true
+		}
+	}
+	return dirs
+}
+
+// ignored reports whether walkWorktree leaves out name, a file or, with isDir, a
+// directory of the working tree: it is not tracked and it or a directory above it is
+// ignored or a nested repository. trackedDirs are the directories holding tracked files,
+// see dirsOf.
+func (g *GitRepository) ignored(name string, isDir bool, tracked, trackedDirs map[string]bool) bool {
+	if tracked[name] {
+		return false
+	}
+
+	var patterns []gitignore.Pattern
+	if data, err := os.ReadFile(filepath.Join(g.gitDir(), "info", "exclude")); err == nil {
+		patterns = append(patterns, parseIgnore(data, nil)...)
+	}
+
+	parts := strings.Split(name, "/")
+	for i := range parts {
+		domain := parts[:i]
+		dir := filepath.Join(g.path, filepath.FromSlash(path.Join(domain...)))
+		if data, err := os.ReadFile(filepath.Join(dir, ".gitignore")); err == nil {
+			patterns = append(patterns, parseIgnore(data, domain)...)
+		}
+
+		current := path.Join(parts[:i+1]...)
+		currentIsDir := i < len(parts)-1 || isDir
+		if currentIsDir && trackedDirs[current] {
+			continue
+		}
+		if gitignore.NewMatcher(patterns).Match(parts[:i+1], currentIsDir) {
+			return true
+		}
+		if currentIsDir {
+			if _, err := os.Lstat(filepath.Join(dir, parts[i], ".git")); err == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseIgnore parses the patterns of a .gitignore file in the directory domain
+func parseIgnore(data []byte, domain []string) []gitignore.Pattern {
+	var patterns []gitignore.Pattern
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, gitignore.ParsePattern(line, domain))
+	}
+	return patterns
+}
+
+// untrackedCacheEnabled reports whether git is configured to keep an untracked cache in
+// the index, which go-git cannot read: core.untrackedCache, or feature.manyFiles which
+// implies it
+func (g *GitRepository) untrackedCacheEnabled(ctx context.Context) bool {
+	cmd := exec.CommandContext(ctx, "git", "config", "--get-regexp", `^(core\.untrackedcache|feature\.manyfiles)$`)
+	cmd.Dir = g.path
+	output, err := runCommand(cmd, cmd.Output)
+	if err != nil {
+		return false
+	}
+
+	settings := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		key, value, _ := strings.Cut(line, " ")
+		settings[strings.ToLower(key)] = strings.ToLower(value)
+	}
+
+	value, ok := settings["core.untrackedcache"]
+	if !ok {
+		value = settings["feature.manyfiles"]
+	}
+	switch value {
+	case "true", "yes", "on", "1", "keep":
+		return true
+	}
+	return false
+}
+
+// gitStatus runs git status, which reads and updates the untracked cache
+func (g *GitRepository) gitStatus(ctx context.Context) ([]GitFile, error) {
+	cmd := exec.CommandContext(ctx, "git", "status", "--porcelain", "-z", "--untracked-files=all", "--no-renames")
+	cmd.Dir = g.path
+	output, err := runCommand(cmd, cmd.Output)
+	if err != nil {
+		return nil, classify(fmt.Errorf("git status failed: %w", err), output)
+	}
+
+	var files []GitFile
+	for _, record := range bytes.Split(output, []byte{0}) {
+		if len(record) > 3 {
+			files = append(files, GitFile{Status: string(record[:2]), Path: string(record[3:])})
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,249 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gitPorcelain returns the status git reports, sorted by path like Status
+func gitPorcelain(t testing.TB, dir string) []GitFile {
+	t.Helper()
+	cmd := exec.Command("git", "status", "--porcelain", "-z", "--untracked-files=all", "--no-renames")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	require.NoError(t, err)
+
+	files := []GitFile{}
+	for _, record := range strings.Split(string(output), "\x00") {
+		if len(record) > 3 {
+			files = append(files, GitFile{Status: record[:2], Path: record[3:]})
+		}
+	}
+	// git lists the untracked files after the changes, a path can be in both
+	sort.SliceStable(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files
+}
+
+// writeFile writes a file in the repository, creating its directory
+func writeFile(t testing.TB, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestStatusMatchesGit(t *testing.T) {
+	setTestIdentity(t)
+
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	for _, name := range []string{"clean.txt", "modified.txt", "staged.txt", "both.txt", "deleted.txt", "removed.txt", "exec.sh", "uncached.txt", "dir/nested.txt", "vendor/kept.txt"} {
+		writeFile(t, repoPath, name, name+"\n")
+	}
+	writeFile(t, repoPath, ".gitignore", "*.log\nvendor/\nbuild/\n")
+	runGit(t, repoPath, "add", "-A")
+	runGit(t, repoPath, "add", "-f", "vendor/kept.txt")
+	runGit(t, repoPath, "commit", "-m", "chore: initial")
+
+	writeFile(t, repoPath, "modified.txt", "changed\n")
+	writeFile(t, repoPath, "staged.txt", "staged\n")
+	runGit(t, repoPath, "add", "staged.txt")
+	writeFile(t, repoPath, "both.txt", "staged\n")
+	runGit(t, repoPath, "add", "both.txt")
+	writeFile(t, repoPath, "both.txt", "and modified\n")
+	require.NoError(t, os.Remove(filepath.Join(repoPath, "deleted.txt")))
+	runGit(t, repoPath, "rm", "-q", "removed.txt")
+	runGit(t, repoPath, "rm", "-q", "--cached", "uncached.txt")
+	require.NoError(t, os.Chmod(filepath.Join(repoPath, "exec.sh"), 0o755))
+	writeFile(t, repoPath, "added.txt", "added\n")
+	runGit(t, repoPath, "add", "added.txt")
+	writeFile(t, repoPath, "added.txt", "added and modified\n")
+	writeFile(t, repoPath, "untracked.txt", "new\n")
+	writeFile(t, repoPath, "dir/deeper/untracked.txt", "new\n")
+	writeFile(t, repoPath, "debug.log", "ignored\n")
+	writeFile(t, repoPath, "vendor/ignored.txt", "ignored\n")
+	writeFile(t, repoPath, "build/out.txt", "ignored\n")
+	require.NoError(t, os.Symlink("clean.txt", filepath.Join(repoPath, "link")))
+
+	// A same-size edit right after the index was written only shows up by hashing
+	writeFile(t, repoPath, "racy.txt", "aaaa\n")
+	runGit(t, repoPath, "add", "racy.txt")
+	writeFile(t, repoPath, "racy.txt", "bbbb\n")
+
+	// A touched but unchanged file is clean
+	future := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(repoPath, "clean.txt"), future, future))
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	files, err := repo.Status(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, gitPorcelain(t, repoPath), files)
+	assert.Contains(t, files, GitFile{Status: "D ", Path: "uncached.txt"}, "the staged deletion is kept")
+	assert.Contains(t, files, GitFile{Status: "??", Path: "uncached.txt"})
+}
+
+func TestStatusAfterGoGitWrites(t *testing.T) {
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	writeFile(t, repoPath, "a.txt", "a\n")
+	writeFile(t, repoPath, "b.txt", "b\n")
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+	require.NoError(t, repo.Stage(t.Context(), []string{"a.txt"}))
+
+	files, err := repo.Status(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []GitFile{{Status: "A ", Path: "a.txt"}, {Status: "??", Path: "b.txt"}}, files)
+	assert.Equal(t, gitPorcelain(t, repoPath), files)
+}
+
+func TestStageSeveralPaths(t *testing.T) {
+	setTestIdentity(t)
+
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+	commitFile(t, repoPath, "a.txt", "a\n", "chore: a")
+	commitFile(t, repoPath, "b.txt", "b\n", "chore: b")
+
+	writeFile(t, repoPath, "a.txt", "changed\n")
+	require.NoError(t, os.Remove(filepath.Join(repoPath, "b.txt")))
+	writeFile(t, repoPath, "dir/c.txt", "c\n")
+	writeFile(t, repoPath, "d.txt", "d\n")
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+	require.NoError(t, repo.Stage(t.Context(), []string{"a.txt", "b.txt", "dir"}))
+
+	files, err := repo.Status(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []GitFile{
+		{Status: "M ", Path: "a.txt"},
+		{Status: "D ", Path: "b.txt"},
+		{Status: "??", Path: "d.txt"},
+		{Status: "A ", Path: "dir/c.txt"},
+	}, files)
+	assert.Equal(t, gitPorcelain(t, repoPath), files)
+}
+
+func TestStatusUnmergedPaths(t *testing.T) {
+	repoPath := setupConflictedMerge(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	files, err := repo.Status(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, gitPorcelain(t, repoPath), files)
+}
+
+func TestStatusUntrackedCache(t *testing.T) {
+	setTestIdentity(t)
+
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+	commitFile(t, repoPath, "a.txt", "a\n", "chore: a")
+	writeFile(t, repoPath, "dir/new.txt", "new\n")
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+	assert.False(t, repo.untrackedCacheEnabled(t.Context()))
+
+	runGit(t, repoPath, "config", "feature.manyFiles", "true")
+	assert.True(t, repo.untrackedCacheEnabled(t.Context()))
+	runGit(t, repoPath, "config", "core.untrackedCache", "false")
+	assert.False(t, repo.untrackedCacheEnabled(t.Context()), "core.untrackedCache overrides feature.manyFiles")
+	runGit(t, repoPath, "config", "core.untrackedCache", "true")
+	assert.True(t, repo.untrackedCacheEnabled(t.Context()))
+
+	// git status fills the cache, later runs read it
+	for range 2 {
+		files, err := repo.Status(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, []GitFile{{Status: "??", Path: "dir/new.txt"}}, files)
+	}
+	assert.True(t, repo.untrackedCache)
+
+	// The setting is read once per repository
+	runGit(t, repoPath, "config", "core.untrackedCache", "false")
+	_, err = repo.Status(t.Context())
+	require.NoError(t, err)
+	assert.True(t, repo.untrackedCache)
+}
+
+// setupBenchmarkRepo commits files files and modifies a tenth of them
+func setupBenchmarkRepo(b *testing.B, files int) string {
+	b.Helper()
+	dir := b.TempDir()
+	_, err := git.PlainInit(dir, false)
+	require.NoError(b, err)
+
+	for i := range files {
+		writeFile(b, dir, fmt.Sprintf("dir%02d/file%05d.txt", i%50, i), strings.Repeat(fmt.Sprintln(i), 20))
+	}
+
+	for _, args := range [][]string{
+		{"add", "-A"},
+		{"-c", "user.name=Bench", "-c", "user.email=bench@example.com", "commit", "-q", "-m", "chore: files"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		require.NoError(b, err, string(output))
+	}
+
+	for i := 0; i < files; i += files / 10 {
+		writeFile(b, dir, fmt.Sprintf("dir%02d/file%05d.txt", i%50, i), "modified\n")
+	}
+	return dir
+}
+
+// BenchmarkStatus compares the index based status with go-git's, which hashes every
+// file, and with git status
+func BenchmarkStatus(b *testing.B) {
+	dir := setupBenchmarkRepo(b, 5000)
+
+	repo, err := NewGitRepository(dir)
+	require.NoError(b, err)
+
+	b.Run("index", func(b *testing.B) {
+		for range b.N {
+			if _, err := repo.indexStatus(b.Context()); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("go-git", func(b *testing.B) {
+		wt, err := repo.repo.Worktree()
+		require.NoError(b, err)
+		for range b.N {
+			if _, err := wt.Status(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("git", func(b *testing.B) {
+		for range b.N {
+			if _, err := repo.gitStatus(b.Context()); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
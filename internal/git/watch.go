@@ -0,0 +1,235 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// pollInterval is how often the polling fallback of Watch looks for changes
+const pollInterval = 2 * time.Second
+
+// gitDirFiles are the files in the git directory whose changes change the status
+var gitDirFiles = map[string]bool{"index": true, "HEAD": true}
+
+// Watch reports changes to the working tree, the index and HEAD on the returned channel,
+// once no other change followed for debounce. The channel is closed when ctx is done.
+// Changes are watched with fsnotify, or by polling when the platform or the limit on
+// watches does not allow watching every directory.
+func (g *GitRepository) Watch(ctx context.Context, debounce time.Duration) (<-chan struct{}, error) {
+	if g.repo == nil {
+		return nil, errNotInitialized
+	}
+
+	events := make(chan struct{}, 1)
+	watcher, err := g.watchTree(ctx)
+	if err != nil {
+		logger.Warn("watching the working tree failed, polling for changes", "error", err)
+		go g.poll(ctx, pollInterval, events)
+	} else {
+		go g.forwardEvents(ctx, watcher, events)
+	}
+
+	changes := make(chan struct{}, 1)
+	go debounceEvents(ctx, debounce, events, changes)
+	return changes, nil
+}
+
+// watchTree watches the git directory and every directory of the working tree that is
+// not ignored
+func (g *GitRepository) watchTree(ctx context.Context) (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := watcher.Add(g.gitDir()); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	err = g.walkWorktree(ctx, g.trackedPaths(), func(name string, entry fs.DirEntry) error {
+		if entry != nil && !entry.IsDir() {
+			return nil
+		}
+		return watcher.Add(filepath.Join(g.path, filepath.FromSlash(name)))
+	})
+	if err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("failed to watch %s: %w", g.path, err)
+	}
+
+	return watcher, nil
+}
+
+// forwardEvents sends the events of watcher that may change the status to events
+// until ctx is done. Changes to ignored files are left out, and ignored directories that
+// are created are not watched, so that builds in the working tree do not cause reloads.
+func (g *GitRepository) forwardEvents(ctx context.Context, watcher *fsnotify.Watcher, events chan<- struct{}) {
+	defer watcher.Close()
+
+	tracked, trackedDirs := g.trackedState()
+	gitDir := filepath.Clean(g.gitDir())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+
+			// Lock files and objects come and go while git works; the index and HEAD
+			// are renamed into place once it is done
+			if filepath.Dir(event.Name) == gitDir {
+				if !gitDirFiles[filepath.Base(event.Name)] {
+					continue
+				}
+				if filepath.Base(event.Name) == "index" {
+					tracked, trackedDirs = g.trackedState()
+				}
+			} else {
+				name, err := filepath.Rel(g.path, event.Name)
+				if err != nil || g.ignoredEvent(filepath.ToSlash(name), tracked, trackedDirs) {
+					continue
+				}
+				if event.Has(fsnotify.Create) {
+					g.watchNewDirs(watcher, event.Name, tracked, trackedDirs)
+				}
+			}
+			notify(events)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			logger.Warn("watching the working tree failed", "error", err)
+		}
+	}
+}
+
+// ignoredEvent reports whether an event on name, a path in the working tree, concerns
+// an ignored file or directory. Removed paths are ignored if they were either.
+func (g *GitRepository) ignoredEvent(name string, tracked, trackedDirs map[string]bool) bool {
+	info, err := os.Lstat(filepath.Join(g.path, filepath.FromSlash(name)))
+	if err != nil {
+		return g.ignored(name, false, tracked, trackedDirs) || g.ignored(name, true, tracked, trackedDirs)
+	}
+	return g.ignored(name, info.IsDir(), tracked, trackedDirs)
+}
+
+// watchNewDirs adds a watch for path and the directories below it if it is a directory,
+// leaving out the ignored ones like watchTree
+func (g *GitRepository) watchNewDirs(watcher *fsnotify.Watcher, path string, tracked, trackedDirs map[string]bool) {
+	_ = filepath.WalkDir(path, func(dir string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.IsDir() {
+			return nil
+		}
+		if entry.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if name, err := filepath.Rel(g.path, dir); err == nil && g.ignored(filepath.ToSlash(name), true, tracked, trackedDirs) {
+			return filepath.SkipDir
+		}
+		return watcher.Add(dir)
+	})
+}
+
+// poll sends an event to events whenever the fingerprint of the repository changes,
+// checking every interval until ctx is done
+func (g *GitRepository) poll(ctx context.Context, interval time.Duration, events chan<- struct{}) {
+	last, _ := g.fingerprint(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current, err := g.fingerprint(ctx)
+		if err == nil && current != last {
+			last = current
+			notify(events)
+		}
+	}
+}
+
+// fingerprint hashes the modification times and sizes of the index, HEAD and everything
+// in the working tree that is not ignored
+func (g *GitRepository) fingerprint(ctx context.Context) (uint64, error) {
+	h := fnv.New64a()
+	for name := range gitDirFiles {
+		if info, err := os.Stat(filepath.Join(g.gitDir(), name)); err == nil {
+			fmt.Fprintf(h, "%s %d %d\n", name, info.ModTime().UnixNano(), info.Size())
+		}
+	}
+
+	err := g.walkWorktree(ctx, g.trackedPaths(), func(name string, entry fs.DirEntry) error {
+		if entry == nil {
+			return nil
+		}
+		if info, err := entry.Info(); err == nil {
+			fmt.Fprintf(h, "%s %d %d\n", name, info.ModTime().UnixNano(), info.Size())
+		}
+		return nil
+	})
+	return h.Sum64(), err
+}
+
+// trackedState returns the paths in the index and the directories holding them. It takes
+// the repository lock since it runs beside the other operations.
+func (g *GitRepository) trackedState() (tracked, trackedDirs map[string]bool) {
+	g.mu.Lock()
+	tracked = g.trackedPaths()
+	g.mu.Unlock()
+	return tracked, dirsOf(tracked)
+}
+
+// trackedPaths returns the paths in the index, none when it cannot be read
+func (g *GitRepository) trackedPaths() map[string]bool {
+	tracked := make(map[string]bool)
+	if idx, err := g.repo.Storer.Index(); err == nil {
+		for _, e := range idx.Entries {
+			tracked[e.Name] = true
+		}
+	}
+	return tracked
+}
+
+// debounceEvents sends to changes once no event followed for debounce, and closes
+// changes when ctx is done
+func debounceEvents(ctx context.Context, debounce time.Duration, events <-chan struct{}, changes chan<- struct{}) {
+	defer close(changes)
+
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-events:
+			timer.Reset(debounce)
+		case <-timer.C:
+			notify(changes)
+		}
+	}
+}
+
+// notify sends to ch unless an earlier notification is still pending
+func notify(ch chan<- struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
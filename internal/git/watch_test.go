@@ -0,0 +1,111 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// waitForChange fails the test unless a change arrives on changes within a few seconds
+func waitForChange(t *testing.T, changes <-chan struct{}, what string) {
+	t.Helper()
+	select {
+	case _, ok := <-changes:
+		require.True(t, ok, "the channel is open")
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no change reported after %s", what)
+	}
+}
+
+// noChange fails the test if a change arrives on changes within a short while
+func noChange(t *testing.T, changes <-chan struct{}, what string) {
+	t.Helper()
+	select {
+	case <-changes:
+		t.Fatalf("a change was reported after %s", what)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestWatch(t *testing.T) {
+	setTestIdentity(t)
+
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+	commitFile(t, repoPath, "a.txt", "a\n", "chore: a")
+	writeFile(t, repoPath, ".gitignore", "build/\nnode_modules/\n*.log\n")
+	require.NoError(t, os.Mkdir(filepath.Join(repoPath, "build"), 0o755))
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	changes, err := repo.Watch(ctx, 20*time.Millisecond)
+	require.NoError(t, err)
+
+	writeFile(t, repoPath, "a.txt", "b\n")
+	waitForChange(t, changes, "editing a file")
+
+	runGit(t, repoPath, "add", "a.txt")
+	waitForChange(t, changes, "staging a file")
+
+	// Directories created while watching are watched too
+	require.NoError(t, os.MkdirAll(filepath.Join(repoPath, "new", "deeper"), 0o755))
+	waitForChange(t, changes, "creating a directory")
+	writeFile(t, repoPath, "new/deeper/c.txt", "c\n")
+	waitForChange(t, changes, "writing in a new directory")
+
+	writeFile(t, repoPath, "build/out.txt", "ignored\n")
+	noChange(t, changes, "writing in an ignored directory")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(repoPath, "node_modules", "pkg"), 0o755))
+	noChange(t, changes, "creating an ignored directory")
+	writeFile(t, repoPath, "node_modules/pkg/index.js", "ignored\n")
+	noChange(t, changes, "writing in a new ignored directory")
+
+	writeFile(t, repoPath, "debug.log", "ignored\n")
+	noChange(t, changes, "writing an ignored file")
+	writeFile(t, repoPath, "new/deeper/debug.log", "ignored\n")
+	noChange(t, changes, "writing an ignored file in a new directory")
+
+	cancel()
+	_, ok := <-changes
+	require.False(t, ok, "the channel is closed once ctx is done")
+}
+
+func TestWatchPolling(t *testing.T) {
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+	writeFile(t, repoPath, "a.txt", "a\n")
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	events := make(chan struct{}, 1)
+	go repo.poll(t.Context(), 10*time.Millisecond, events)
+	noChange(t, events, "nothing changed")
+
+	writeFile(t, repoPath, "a.txt", "longer\n")
+	waitForChange(t, events, "editing a file")
+
+	require.NoError(t, repo.Stage(t.Context(), []string{"a.txt"}))
+	waitForChange(t, events, "staging a file")
+}
+
+func TestDebounceEvents(t *testing.T) {
+	events := make(chan struct{})
+	changes := make(chan struct{}, 1)
+	go debounceEvents(t.Context(), 50*time.Millisecond, events, changes)
+
+	// A burst of events is reported once it is over
+	for range 10 {
+		events <- struct{}{}
+		time.Sleep(5 * time.Millisecond)
+	}
+	waitForChange(t, changes, "a burst of events")
+	noChange(t, changes, "the burst was reported")
+}
@@ -0,0 +1,189 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Unstage resets the index entries of paths, files or folders, to HEAD like
+// git reset -- paths. Files that are not in HEAD are dropped from the index, which leaves
+// them untracked.
+func (g *GitRepository) Unstage(ctx context.Context, paths []string) error {
+	if g.repo == nil {
+		return errNotInitialized
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	idx, err := g.repo.Storer.Index()
+	if err != nil {
+		return fmt.Errorf("failed to read index: %w", err)
+	}
+
+	// Conflict stages go as well, as they do with git reset
+	entries := idx.Entries[:0]
+	for _, e := range idx.Entries {
+		if !underAny(e.Name, paths) {
+			entries = append(entries, e)
+		}
+	}
+	idx.Entries = entries
+
+	// Before the first commit there is nothing to reset to
+	head, err := g.repo.Head()
+	switch {
+	case errors.Is(err, plumbing.ErrReferenceNotFound):
+	case err != nil:
+		return fmt.Errorf("failed to resolve HEAD: %w", err)
+	default:
+		commit, err := g.repo.CommitObject(head.Hash())
+		if err != nil {
+			return fmt.Errorf("failed to read HEAD commit: %w", err)
+		}
+		tree, err := commit.Tree()
+		if err != nil {
+			return fmt.Errorf("failed to read HEAD tree: %w", err)
+		}
+
+		// Without stat data the entries are hashed again by the next status
+		err = tree.Files().ForEach(func(f *object.File) error {
+			if underAny(f.Name, paths) {
+				idx.Entries = append(idx.Entries, &index.Entry{Name: f.Name, Hash: f.Hash, Mode: f.Mode})
+			}
+			return ctx.Err()
+		})
+		if err != nil {
+			return fmt.Errorf("failed to read HEAD tree: %w", err)
+		}
+	}
+
+	if err := g.repo.Storer.SetIndex(idx); err != nil {
+		return fmt.Errorf("failed to write index: %w", err)
+	}
+	return nil
+}
+
+// underAny reports whether name is one of paths or inside one of them
+func underAny(name string, paths []string) bool {
+	for _, p := range paths {
+		if name == p || strings.HasPrefix(name, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// Ignore adds paths, files or folders, to the .gitignore at the root of the working tree.
+// The patterns are anchored so that only these paths are ignored, and patterns that are
+// already there are not added again.
+func (g *GitRepository) Ignore(ctx context.Context, paths []string) error {
+	if g.repo == nil {
+		return errNotInitialized
+	}
+
+	file := filepath.Join(g.path, ".gitignore")
+	content, err := os.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read .gitignore: %w", err)
+	}
+
+	existing := make(map[string]bool)
+	for _, line := range strings.Split(string(content), "\n") {
+		existing[strings.TrimRight(line, "\r")] = true
+	}
+
+	var added bytes.Buffer
+	for _, p := range paths {
+		pattern := "/" + escapeIgnorePattern(p)
+		if info, err := os.Stat(filepath.Join(g.path, filepath.FromSlash(p))); err == nil && info.IsDir() {
+			pattern += "/"
+		}
+		if existing[pattern] {
+			continue
+		}
+		existing[pattern] = true
+		added.WriteString(pattern + "\n")
+	}
+	if added.Len() == 0 {
+		return nil
+	}
+
+	if len(content) > 0 && !bytes.HasSuffix(content, []byte("\n")) {
+		content = append(content, '\n')
+	}
+	if err := os.WriteFile(file, append(content, added.Bytes()...), 0o644); err != nil {
+		return fmt.Errorf("failed to write .gitignore: %w", err)
+	}
+	return nil
+}
+
+// escapeIgnorePattern escapes the characters that gitignore would read as a pattern,
+// and a trailing space, which it would drop
+func escapeIgnorePattern(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if strings.ContainsRune(`\*?[`, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	escaped := b.String()
+	if strings.HasSuffix(escaped, " ") {
+		escaped = escaped[:len(escaped)-1] + `\ `
+	}
+	return escaped
+}
+
+// DeleteUntracked deletes untracked files from the working tree, and the folders they
+// leave empty. Nothing is deleted when one of paths is in the index or is a folder, as
+// its content could not be restored.
+func (g *GitRepository) DeleteUntracked(ctx context.Context, paths []string) error {
+	if g.repo == nil {
+		return errNotInitialized
+	}
+
+	idx, err := g.repo.Storer.Index()
+	if err != nil {
+		return fmt.Errorf("failed to read index: %w", err)
+	}
+	tracked := make(map[string]bool, len(idx.Entries))
+	for _, e := range idx.Entries {
+		tracked[e.Name] = true
+	}
+
+	for _, p := range paths {
+		if tracked[p] {
+			return fmt.Errorf("%s is tracked, not deleting it", p)
+		}
+		if info, err := os.Lstat(filepath.Join(g.path, filepath.FromSlash(p))); err == nil && info.IsDir() {
+			return fmt.Errorf("%s is a folder, not deleting it", p)
+		}
+	}
+
+	for _, p := range paths {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := os.Remove(filepath.Join(g.path, filepath.FromSlash(p))); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to delete %s: %w", p, err)
+		}
+
+		// Removing a folder fails once it is not empty
+		for dir := filepath.Dir(filepath.FromSlash(p)); dir != "."; dir = filepath.Dir(dir) {
+			if os.Remove(filepath.Join(g.path, dir)) != nil {
+				break
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnstage(t *testing.T) {
+	setTestIdentity(t)
+
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	// Before the first commit unstaging leaves the files untracked
+	writeFile(t, repoPath, "first.txt", "first\n")
+	runGit(t, repoPath, "add", "first.txt")
+	require.NoError(t, repo.Unstage(t.Context(), []string{"first.txt"}))
+	assert.Equal(t, []GitFile{{Status: "??", Path: "first.txt"}}, gitPorcelain(t, repoPath))
+
+	for _, name := range []string{"first.txt", "dir/a.txt", "dir/b.txt", "other.txt"} {
+		writeFile(t, repoPath, name, name+"\n")
+	}
+	runGit(t, repoPath, "add", "-A")
+	runGit(t, repoPath, "commit", "-q", "-m", "chore: initial")
+
+	writeFile(t, repoPath, "dir/a.txt", "staged\n")
+	writeFile(t, repoPath, "dir/new.txt", "new\n")
+	runGit(t, repoPath, "rm", "-q", "dir/b.txt")
+	writeFile(t, repoPath, "other.txt", "staged\n")
+	runGit(t, repoPath, "add", "-A")
+	writeFile(t, repoPath, "other.txt", "staged and modified\n")
+
+	// A folder unstages everything below it, the working tree is left alone
+	require.NoError(t, repo.Unstage(t.Context(), []string{"dir", "other.txt"}))
+	assert.Equal(t, []GitFile{
+		{Status: " M", Path: "dir/a.txt"},
+		{Status: " D", Path: "dir/b.txt"},
+		{Status: "??", Path: "dir/new.txt"},
+		{Status: " M", Path: "other.txt"},
+	}, gitPorcelain(t, repoPath))
+
+	files, err := repo.Status(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, gitPorcelain(t, repoPath), files)
+}
+
+func TestIgnore(t *testing.T) {
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	writeFile(t, repoPath, ".gitignore", "*.log")
+	writeFile(t, repoPath, "build/out.txt", "out\n")
+	writeFile(t, repoPath, "notes[1].txt", "notes\n")
+	writeFile(t, repoPath, "sub/notes[1].txt", "kept\n")
+
+	require.NoError(t, repo.Ignore(t.Context(), []string{"build", "notes[1].txt"}))
+	require.NoError(t, repo.Ignore(t.Context(), []string{"build"}), "ignoring again adds nothing")
+
+	content, err := os.ReadFile(filepath.Join(repoPath, ".gitignore"))
+	require.NoError(t, err)
+	assert.Equal(t, "*.log\n/build/\n/notes\\[1].txt\n", string(content))
+	assert.Equal(t, []GitFile{
+		{Status: "??", Path: ".gitignore"},
+		{Status: "??", Path: "sub/notes[1].txt"},
+	}, gitPorcelain(t, repoPath), "only the paths themselves are ignored")
+}
+
+func TestDeleteUntracked(t *testing.T) {
+	setTestIdentity(t)
+
+	repoPath := setupTestRepo(t)
+	defer cleanupTestRepo(t, repoPath)
+
+	repo, err := NewGitRepository(repoPath)
+	require.NoError(t, err)
+
+	writeFile(t, repoPath, "tracked.txt", "tracked\n")
+	runGit(t, repoPath, "add", "tracked.txt")
+	writeFile(t, repoPath, "new/deep/a.txt", "a\n")
+	writeFile(t, repoPath, "new/b.txt", "b\n")
+	writeFile(t, repoPath, "c.txt", "c\n")
+
+	// Nothing is deleted when a path could not be restored
+	assert.ErrorContains(t, repo.DeleteUntracked(t.Context(), []string{"c.txt", "tracked.txt"}), "tracked.txt is tracked")
+	assert.ErrorContains(t, repo.DeleteUntracked(t.Context(), []string{"c.txt", "new"}), "new is a folder")
+	assert.FileExists(t, filepath.Join(repoPath, "c.txt"))
+
+	// Folders left empty are deleted with the files
+	require.NoError(t, repo.DeleteUntracked(t.Context(), []string{"new/deep/a.txt", "c.txt"}))
+	assert.NoDirExists(t, filepath.Join(repoPath, "new", "deep"))
+	assert.Equal(t, []GitFile{
+		{Status: "??", Path: "new/b.txt"},
+		{Status: "A ", Path: "tracked.txt"},
+	}, gitPorcelain(t, repoPath))
+}
@@ -0,0 +1,61 @@
+// Package logging sets up the structured log file that records git operations.
+// The TUIs own the terminal, so the log is never written to stdout or stderr.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// Level maps the number of -v flags to a log level: failures only by default,
+// every git operation with -v and everything with -vv
+func Level(verbosity int) slog.Level {
+	switch {
+	case verbosity <= 0:
+		return slog.LevelWarn
+	case verbosity == 1:
+		return slog.LevelInfo
+	default:
+		return slog.LevelDebug
+	}
+}
+
+// DefaultPath returns $XDG_STATE_HOME/go-git-tui/log, where XDG_STATE_HOME defaults
+// to ~/.local/state
+func DefaultPath() (string, error) {
+	state := os.Getenv("XDG_STATE_HOME")
+	if state == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to locate the log directory: %w", err)
+		}
+		state = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(state, "go-git-tui", "log"), nil
+}
+
+// Open appends to the log file at path, or DefaultPath when path is empty, and returns
+// a logger at the level for verbosity together with the file to close when done
+func Open(path string, verbosity int) (*slog.Logger, io.Closer, error) {
+	if path == "" {
+		var err error
+		if path, err = DefaultPath(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	handler := slog.NewTextHandler(f, &slog.HandlerOptions{Level: Level(verbosity)})
+	return slog.New(handler).With("pid", os.Getpid()), f, nil
+}
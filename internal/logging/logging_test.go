@@ -0,0 +1,45 @@
+package logging
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevel(t *testing.T) {
+	assert.Equal(t, slog.LevelWarn, Level(0))
+	assert.Equal(t, slog.LevelInfo, Level(1))
+	assert.Equal(t, slog.LevelDebug, Level(2))
+	assert.Equal(t, slog.LevelDebug, Level(5))
+}
+
+func TestDefaultPath(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/tmp/state")
+
+	path, err := DefaultPath()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/tmp/state", "go-git-tui", "log"), path)
+}
+
+func TestOpen(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	logger, closer, err := Open("", 1)
+	require.NoError(t, err)
+	logger.Debug("hidden")
+	logger.Info("git operation", "op", "Status")
+	require.NoError(t, closer.Close())
+
+	path, err := DefaultPath()
+	require.NoError(t, err)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(content), "op=Status")
+	assert.False(t, strings.Contains(string(content), "hidden"), "debug records are dropped at -v")
+}
@@ -5,5 +5,5 @@ import (
 )
 
 func RunAddTUI() error {
-	return add.Run()
+	return add.Run(nil, true)
 }
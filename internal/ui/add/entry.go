@@ -2,12 +2,19 @@ package add
 
 import (
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
 )
 
-// Run initializes and runs the add UI component in a fullscreen terminal view
-func Run() error {
+// Run initializes and runs the add UI component in a fullscreen terminal view.
+// Only changes matched by spec are listed; a nil spec lists every change. With stayOpen,
+// staging refreshes the list instead of leaving the program.
+func Run(spec *git.Pathspec, stayOpen bool) error {
+	model := New(spec)
+	model.StayOpen = stayOpen
+
 	p := tea.NewProgram(
-		New(),
+		model,
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
 	)
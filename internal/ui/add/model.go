@@ -1,54 +1,78 @@
 package add
 
 import (
-	"fmt"
-	"sync"
+	"context"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui/blame"
+	"github.com/LaansDole/go-git-tui/internal/ui/commit"
 	"github.com/LaansDole/go-git-tui/internal/ui/common"
+	"github.com/LaansDole/go-git-tui/internal/ui/conflict"
 )
 
 // FileItem represents a git status file item
 type FileItem struct {
+	Section    Section // Section the file is listed in
 	Status     string
 	Path       string
+	Depth      int // Number of folders above the file in the tree
 	IsSelected bool
 }
 
 // Title implements the list.Item interface
 func (i FileItem) Title() string {
-	prefix := "  "
+	prefix := Unselected.checkbox()
 	if i.IsSelected {
-		prefix = "✓ "
-	}
-
-	// Color code different statuses
-	statusStyle := lipgloss.NewStyle()
-	switch i.Status {
-	case "M ":
-		statusStyle = statusStyle.Foreground(lipgloss.Color("3")) // Yellow for modified
-	case "A ":
-		statusStyle = statusStyle.Foreground(lipgloss.Color("2")) // Green for added
-	case "D ":
-		statusStyle = statusStyle.Foreground(lipgloss.Color("1")) // Red for deleted
-	case "??":
-		statusStyle = statusStyle.Foreground(lipgloss.Color("4")) // Blue for untracked
+		prefix = FullySelected.checkbox()
 	}
 
 	// Format the status in brackets next to the file path
-	statusFormatted := statusStyle.Render(fmt.Sprintf("[%s]", i.Status))
+	statusFormatted := "[" + renderStatus(i.Status) + "]"
+
+	// The folders above the file are shown as rows of the tree
+	name := common.TruncatePath(path.Base(i.Path), 60, 30, 27)
+
+	return indent(i.Depth) + prefix + statusFormatted + " " + name
+}
 
-	// Use a maximum width for file paths to prevent overflow
-	path := common.TruncatePath(i.Path, 60, 30, 27)
+// statusColors are the colors of the letters of a status code, the same in both columns
+var statusColors = map[byte]lipgloss.Color{
+	'M': lipgloss.Color("3"),   // Yellow for modified
+	'A': lipgloss.Color("2"),   // Green for added
+	'D': lipgloss.Color("1"),   // Red for deleted
+	'R': lipgloss.Color("6"),   // Cyan for renamed
+	'C': lipgloss.Color("6"),   // Cyan for copied
+	'T': lipgloss.Color("5"),   // Magenta for typechange
+	'?': lipgloss.Color("4"),   // Blue for untracked
+	'!': lipgloss.Color("240"), // Gray for ignored
+}
+
+// renderStatus colors a two-letter status code letter by letter, so that every
+// combination is colored consistently. Unmerged codes are bold red as a whole.
+func renderStatus(status string) string {
+	if git.IsConflicted(status) {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true).Render(status)
+	}
 
-	return prefix + statusFormatted + " " + path
+	var b strings.Builder
+	for i := 0; i < len(status); i++ {
+		if color, ok := statusColors[status[i]]; ok {
+			b.WriteString(lipgloss.NewStyle().Foreground(color).Render(status[i : i+1]))
+		} else {
+			b.WriteByte(status[i])
+		}
+	}
+	return b.String()
 }
 
 // Description implements the list.Item interface
@@ -76,9 +100,47 @@ const (
 
 // Custom message types
 type ErrMsg struct{ error }
-type DiffLoadedMsg struct{ Diff *git.DiffResult }
 type TickMsg struct{}
-type StagingCompleteMsg struct{ Files []string }
+
+// StagingCompleteMsg reports the staged files, and whether the commit view opens next
+type StagingCompleteMsg struct {
+	Files  []string
+	Commit bool
+}
+
+// ActionCompleteMsg reports the files that were unstaged, ignored or deleted
+type ActionCompleteMsg struct {
+	Action string
+	Files  []string
+}
+
+// StatusLoadedMsg carries the repository opened at startup and its status
+type StatusLoadedMsg struct {
+	Service *git.DefaultGitService
+	State   *git.RepoState // Operation in progress, nil when there is none
+	Files   []git.GitFile
+	Err     error
+}
+
+// WatchStartedMsg carries the changes reported by the watcher started once the status is loaded
+type WatchStartedMsg struct{ Changes <-chan struct{} }
+
+// FilesChangedMsg reports that the working tree, the index or HEAD changed
+type FilesChangedMsg struct{}
+
+// StatusRefreshedMsg carries the status read again after the files changed
+type StatusRefreshedMsg struct {
+	State *git.RepoState
+	Files []git.GitFile
+	Err   error
+}
+
+// DiffLoadedMsg carries the result of the diff load started with RequestID
+type DiffLoadedMsg struct {
+	RequestID int64
+	Diff      *git.DiffResult
+	Err       error
+}
 
 // Model represents the main UI model for the git add component
 type Model struct {
@@ -87,7 +149,10 @@ type Model struct {
 	DiffViewport viewport.Model
 
 	// State
-	Selected       map[int]bool
+	Files          []git.GitFile    // Listed files, shown in sections as trees grouped by folder
+	Selected       map[ItemKey]bool // Selected files by section and path
+	Collapsed      map[ItemKey]bool // Collapsed sections and folders
+	Confirm        bool             // Waiting for y/N before deleting untracked files
 	Quitting       bool
 	CurrentDiff    *git.DiffResult
 	CurrentFile    string
@@ -97,42 +162,52 @@ type Model struct {
 	LoadingDiff    bool
 	Message        string
 	MessageTimeout int
+	State          *git.RepoState  // Merge, rebase, cherry-pick or revert in progress
+	Blame          *blame.Model    // Blame view for the current file, nil when closed
+	Conflict       *conflict.Model // Conflict view for a conflicted file, nil when closed
+	Commit         *commit.Model   // Commit view opened after staging, nil when closed
+	StayOpen       bool            // Staging refreshes the list instead of leaving the program
+	Pathspec       *git.Pathspec   // Limits the listed files, nil lists every change
+	Err            error           // Failed git operation shown with a hint until a key is pressed
+	Loading        bool            // The status is being loaded, the list is empty until then
+	Spinner        spinner.Model
 
 	// Dependencies
 	GitService  *git.DefaultGitService
 	StyleConfig StyleConfig
 
-	// Concurrency control
-	diffMutex       sync.Mutex
-	lastDiffTime    time.Time
-	minDiffDelay    time.Duration
-	lastNavTime     time.Time
+	// Untracked files to delete once confirmed
+	pendingDelete []string
+
+	// Status loading
+	loadStart  time.Time
+	loadCancel context.CancelFunc // Cancels the status load, nil once it is done
+
+	// Live refresh
+	refreshDebounce time.Duration      // Quiet period before a refresh, zero disables watching
+	watchCancel     context.CancelFunc // Stops the watcher, nil when not watching
+	changes         <-chan struct{}    // Changes reported by the watcher
+	refreshing      bool               // A refresh of the status is running
+	refreshPending  bool               // The files changed again during the refresh
+
+	// Diff loading
+	diffRequestID   int64              // Latest diff load; results of earlier loads are dropped
+	diffCancel      context.CancelFunc // Cancels the diff load in flight, nil when idle
+	prefetchCancel  context.CancelFunc // Cancels the prefetch of the diffs around the cursor
 	navDebounceTime time.Duration
-	isNavigating    bool
 	lastResizeTime  time.Time
 }
 
-// New initializes a new instance of the add UI model
-func New() *Model {
+// New initializes a new instance of the add UI model listing the changes matched by spec.
+// The repository is opened and its status loaded by the command returned from Init.
+func New(spec *git.Pathspec) *Model {
+	return NewWithService(nil, spec)
+}
+
+// NewWithService initializes an add model on an existing git service, which Init then uses
+// instead of opening the repository. A nil service opens it like New.
+func NewWithService(gitService *git.DefaultGitService, spec *git.Pathspec) *Model {
 	items := []list.Item{}
-	var gitService *git.DefaultGitService
-
-	// Get git status using internal/git package
-	gitServiceTemp, err := git.NewGitService()
-	// Only proceed to get status if service is initialized successfully
-	if err == nil {
-		gitService = gitServiceTemp
-		files, err := gitService.Status()
-		if err == nil {
-			for _, file := range files {
-				items = append(items, FileItem{
-					Status:     file.Status,
-					Path:       file.Path,
-					IsSelected: false,
-				})
-			}
-		}
-	}
 
 	// Create a custom delegate with more compact spacing
 	delegate := list.NewDefaultDelegate()
@@ -190,21 +265,55 @@ func New() *Model {
 
 	return &Model{
 		List:            l,
-		Selected:        make(map[int]bool),
+		Selected:        make(map[ItemKey]bool),
+		Collapsed:       make(map[ItemKey]bool),
 		Quitting:        false,
 		DiffViewport:    diffViewport,
+		Pathspec:        spec,
+		StayOpen:        true,
+		Loading:         true,
+		Spinner:         spinner.New(spinner.WithSpinner(spinner.Dot)),
 		GitService:      gitService,
 		StyleConfig:     NewStyleConfig(),
 		LoadingDiff:     false,
-		lastDiffTime:    time.Now(),
-		lastNavTime:     time.Now(),
-		minDiffDelay:    250 * time.Millisecond, // Minimum time between diff loads
 		navDebounceTime: 500 * time.Millisecond, // Time to wait after navigation stops before loading diff
+		refreshDebounce: 300 * time.Millisecond, // Time to wait after the files stop changing before refreshing
 		lastResizeTime:  time.Now(),
 	}
 }
 
-// Init initializes the model - implements tea.Model interface
+// Init starts loading the status - implements tea.Model interface
 func (m *Model) Init() tea.Cmd {
-	return nil
+	ctx, cancel := context.WithCancel(context.Background())
+	m.loadCancel = cancel
+	m.loadStart = time.Now()
+
+	return tea.Batch(m.Spinner.Tick, loadStatus(ctx, m.GitService))
+}
+
+// loadStatus reads the status with service, opening the repository in the working
+// directory when it is nil
+func loadStatus(ctx context.Context, service *git.DefaultGitService) tea.Cmd {
+	return func() tea.Msg {
+		if service == nil {
+			var err error
+			if service, err = git.NewGitService(); err != nil {
+				return StatusLoadedMsg{Err: err}
+			}
+		}
+
+		msg := StatusLoadedMsg{Service: service}
+		msg.State, msg.Files, msg.Err = readStatus(ctx, service)
+		return msg
+	}
+}
+
+// readStatus reads the status and the operation in progress, nil when there is none
+func readStatus(ctx context.Context, service *git.DefaultGitService) (*git.RepoState, []git.GitFile, error) {
+	var state *git.RepoState
+	if s, err := service.State(ctx); err == nil && s.InProgress() {
+		state = s
+	}
+	files, err := service.Status(ctx)
+	return state, files, err
 }
@@ -0,0 +1,312 @@
+package add
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// program runs commands concurrently like Bubble Tea does, while Update and all state
+// changes stay on the test goroutine. Run the tests with -race to check this.
+type program struct {
+	t       *testing.T
+	model   *Model
+	msgs    chan tea.Msg
+	pending int
+}
+
+func newProgram(t *testing.T, model *Model) *program {
+	return &program{t: t, model: model, msgs: make(chan tea.Msg, 64)}
+}
+
+// run executes cmd in its own goroutine and queues its message
+func (p *program) run(cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	p.pending++
+	go func() { p.msgs <- cmd() }()
+}
+
+// send updates the model with msg and runs the command it returns
+func (p *program) send(msg tea.Msg) {
+	switch msg := msg.(type) {
+	case nil:
+	case tea.BatchMsg:
+		for _, cmd := range msg {
+			p.run(cmd)
+		}
+	default:
+		_, cmd := p.model.Update(msg)
+		p.run(cmd)
+	}
+}
+
+// receive handles the next queued message, waiting for it when block is set
+func (p *program) receive(block bool) bool {
+	p.t.Helper()
+	if p.pending == 0 {
+		return false
+	}
+
+	var msg tea.Msg
+	if block {
+		select {
+		case msg = <-p.msgs:
+		case <-time.After(10 * time.Second):
+			p.t.Fatal("timed out waiting for a command")
+		}
+	} else {
+		select {
+		case msg = <-p.msgs:
+		default:
+			return false
+		}
+	}
+
+	p.pending--
+	p.send(msg)
+	return true
+}
+
+// settle handles messages until no command is running
+func (p *program) settle() {
+	for p.receive(true) {
+	}
+}
+
+// receiveUntil handles messages until done reports true, for commands that never finish
+func (p *program) receiveUntil(done func() bool) {
+	p.t.Helper()
+	for !done() {
+		if !p.receive(true) {
+			p.t.Fatal("no command is running")
+		}
+	}
+}
+
+// startProgram creates the model for the repository in the working directory and waits
+// for its status to load
+func startProgram(t *testing.T) (*Model, *program) {
+	model := New(nil)
+	// Waiting for changes never finishes, tests that need the watcher start it
+	model.refreshDebounce = 0
+	p := newProgram(t, model)
+	p.run(model.Init())
+	p.settle()
+	require.NoError(t, model.Err)
+	require.False(t, model.Loading)
+	return model, p
+}
+
+// setupRepoWithChanges creates a repository with count untracked files and makes it the
+// working directory
+func setupRepoWithChanges(t *testing.T, count int) {
+	dir := t.TempDir()
+	_, err := gogit.PlainInit(dir, false)
+	require.NoError(t, err)
+
+	for i := range count {
+		content := fmt.Sprintf("file %d\n", i)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, fmt.Sprintf("file%02d.txt", i)), []byte(content), 0o644))
+	}
+	t.Chdir(dir)
+}
+
+func TestDiffLoadingRapidNavigation(t *testing.T) {
+	setupRepoWithChanges(t, 8)
+
+	model, p := startProgram(t)
+	require.Len(t, model.Files, 8)
+	model.navDebounceTime = time.Millisecond
+
+	p.send(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	keys := []string{"s", "s", "w", "d", "s", "d", "d", "w", "s"}
+	rng := rand.New(rand.NewSource(1))
+	for range 200 {
+		p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(keys[rng.Intn(len(keys))])})
+
+		// Results arrive while the user keeps navigating
+		for p.receive(false) {
+		}
+	}
+
+	// Land on a file, wait for its diff and for every other command to finish
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	p.settle()
+
+	require.NoError(t, model.Err)
+	require.NotNil(t, model.CurrentDiff, "the diff of the last file is loaded")
+	assert.Equal(t, model.CurrentFile, model.CurrentDiff.Path, "stale diffs are dropped")
+	assert.False(t, model.LoadingDiff)
+	assert.Nil(t, model.diffCancel)
+}
+
+func TestDiffLoadingDropsStaleResults(t *testing.T) {
+	setupRepoWithChanges(t, 2)
+
+	model, p := startProgram(t)
+
+	// Start a load, then supersede it before its result arrives
+	stale := model.ShowDiff("file00.txt")
+	current := model.ShowDiff("file01.txt")
+
+	p.send(stale())
+	assert.Nil(t, model.CurrentDiff, "the superseded result is dropped")
+	assert.True(t, model.LoadingDiff, "the current load is still running")
+
+	p.send(current())
+	require.NotNil(t, model.CurrentDiff)
+	assert.Equal(t, "file01.txt", model.CurrentDiff.Path)
+	assert.False(t, model.LoadingDiff)
+
+	// A load cancelled by leaving the file is dropped as well
+	cancelled := model.ShowDiff("file00.txt")
+	model.cancelDiff()
+	p.send(cancelled())
+	assert.Equal(t, "file01.txt", model.CurrentDiff.Path)
+	assert.NoError(t, model.Err, "cancellation is not an error")
+}
+
+func TestDiffLoadingUsesPrefetchedDiffs(t *testing.T) {
+	setupRepoWithChanges(t, 4)
+
+	model, p := startProgram(t)
+	// Only a cached diff can show up before the test times out
+	model.navDebounceTime = time.Hour
+
+	// The first layout prefetches the files below the cursor
+	p.send(tea.WindowSizeMsg{Width: 120, Height: 40})
+	p.settle()
+
+	for range 3 {
+		p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+		p.settle()
+
+		require.NotNil(t, model.CurrentDiff, model.CurrentFile)
+		assert.Equal(t, model.CurrentFile, model.CurrentDiff.Path)
+	}
+}
+
+func TestLiveRefresh(t *testing.T) {
+	setupRepoWithChanges(t, 3)
+
+	model, p := startProgram(t)
+	p.send(tea.WindowSizeMsg{Width: 120, Height: 40})
+	p.settle()
+
+	// Select file01.txt and open its diff
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	p.send(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	p.settle()
+	require.NotNil(t, model.CurrentDiff)
+	require.Equal(t, "file01.txt", model.CurrentDiff.Path)
+
+	model.refreshDebounce = 10 * time.Millisecond
+	p.run(model.startWatch())
+	p.receiveUntil(func() bool { return model.changes != nil })
+	defer model.cancelWatch()
+
+	// A new file sorts before the current one, which is edited as well
+	require.NoError(t, os.WriteFile("file00a.txt", []byte("new\n"), 0o644))
+	require.NoError(t, os.WriteFile("file01.txt", []byte("edited\n"), 0o644))
+	p.receiveUntil(func() bool {
+		return len(model.Files) == 4 && !model.LoadingDiff &&
+			model.CurrentDiff != nil && strings.Contains(model.CurrentDiff.Content, "edited")
+	})
+
+	assert.Equal(t, "file01.txt", model.CurrentFile)
+	assert.Equal(t, 3, model.List.Index(), "the cursor stays on the current file")
+	assert.Equal(t, map[ItemKey]bool{{SectionUntracked, "file01.txt"}: true}, model.Selected, "the selection moves with the file")
+	assert.True(t, model.List.Items()[3].(FileItem).IsSelected)
+
+	// Removing the current file closes its diff and keeps the cursor in place
+	require.NoError(t, os.Remove("file01.txt"))
+	p.receiveUntil(func() bool { return len(model.Files) == 3 })
+
+	assert.Equal(t, 3, model.List.Index())
+	assert.Equal(t, "file02.txt", model.CurrentFile)
+	assert.Nil(t, model.CurrentDiff)
+	assert.Empty(t, model.Selected)
+}
+
+// statuses returns the status of every listed file by path
+func statuses(model *Model) map[string]string {
+	result := make(map[string]string)
+	for _, item := range model.List.Items() {
+		if fileItem, ok := item.(FileItem); ok {
+			result[fileItem.Path] = fileItem.Status
+		}
+	}
+	return result
+}
+
+func TestStayOpenStaging(t *testing.T) {
+	setupRepoWithChanges(t, 3)
+	t.Setenv("GIT_AUTHOR_NAME", "Test User")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+
+	model, p := startProgram(t)
+	p.send(tea.WindowSizeMsg{Width: 120, Height: 40})
+	p.settle()
+	require.True(t, model.StayOpen, "staying open is the default")
+
+	// Enter stages the selection and refreshes the list in place
+	p.send(tea.KeyMsg{Type: tea.KeyTab})
+	p.send(tea.KeyMsg{Type: tea.KeyEnter})
+	p.settle()
+
+	require.NoError(t, model.Err)
+	assert.False(t, model.Quitting)
+	assert.Equal(t, map[string]string{"file00.txt": "A ", "file01.txt": "??", "file02.txt": "??"}, statuses(model))
+	assert.Empty(t, model.Selected, "the staged files are unselected")
+
+	// c stages the current file and opens the commit view on the same service
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	p.settle()
+	require.NotNil(t, model.Commit)
+	assert.Same(t, model.GitService, model.Commit.GitService)
+	assert.Equal(t, "A ", statuses(model)["file01.txt"])
+
+	p.send(tea.KeyMsg{Type: tea.KeyTab})
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("add files")})
+	p.send(tea.KeyMsg{Type: tea.KeyEnter})
+	p.settle()
+	require.NoError(t, model.Commit.Err)
+	require.Equal(t, 2, model.Commit.Step, "the commit is created")
+
+	// Leaving the commit view goes back to the refreshed list
+	p.send(tea.KeyMsg{Type: tea.KeyEnter})
+	p.settle()
+	assert.Nil(t, model.Commit)
+	assert.False(t, model.Quitting)
+	assert.Equal(t, map[string]string{"file02.txt": "??"}, statuses(model))
+}
+
+func TestQuitAfterStaging(t *testing.T) {
+	setupRepoWithChanges(t, 2)
+
+	model, p := startProgram(t)
+	model.StayOpen = false
+	p.send(tea.WindowSizeMsg{Width: 120, Height: 40})
+	p.settle()
+
+	p.send(tea.KeyMsg{Type: tea.KeyEnter})
+	for p.receive(true) {
+		if model.Quitting {
+			break
+		}
+	}
+	assert.True(t, model.Quitting)
+}
@@ -0,0 +1,159 @@
+package add
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+)
+
+// Section groups the listed files by the kind of their changes, like git status
+type Section int
+
+const (
+	// SectionStaged lists the files with changes in the index
+	SectionStaged Section = iota
+	// SectionUnstaged lists the tracked files with changes in the working tree
+	SectionUnstaged
+	// SectionUntracked lists the files git does not track yet
+	SectionUntracked
+	// SectionConflicted lists the unmerged files
+	SectionConflicted
+
+	sectionCount = iota
+)
+
+// sectionNames are the titles of the sections, in Section order
+var sectionNames = [sectionCount]string{"Staged", "Unstaged", "Untracked", "Conflicted"}
+
+// String returns the title of the section
+func (s Section) String() string {
+	return sectionNames[s]
+}
+
+// help describes the keys of the actions on the files of the section
+func (s Section) help() string {
+	switch s {
+	case SectionStaged:
+		return "Enter: Unstage • c: Commit"
+	case SectionUntracked:
+		return "Enter: Stage • i: Ignore • x: Delete • c: Stage & Commit"
+	case SectionConflicted:
+		return "Enter: Resolve • r: Mark Resolved"
+	default:
+		return "Enter: Stage • c: Stage & Commit"
+	}
+}
+
+// sectionsOf returns the sections that list file. A file with changes in both the index
+// and the working tree is listed in Staged and in Unstaged.
+func sectionsOf(file git.GitFile) []Section {
+	switch {
+	case git.IsConflicted(file.Status):
+		return []Section{SectionConflicted}
+	case file.Status == "??":
+		return []Section{SectionUntracked}
+	}
+
+	var sections []Section
+	if file.Status[0] != ' ' {
+		sections = append(sections, SectionStaged)
+	}
+	if file.Status[1] != ' ' {
+		sections = append(sections, SectionUnstaged)
+	}
+	return sections
+}
+
+// inSection returns the files that section lists
+func inSection(files []git.GitFile, section Section) []git.GitFile {
+	var result []git.GitFile
+	for _, file := range files {
+		for _, s := range sectionsOf(file) {
+			if s == section {
+				result = append(result, file)
+			}
+		}
+	}
+	return result
+}
+
+// ItemKey identifies a row of the list: a file or folder of a section, or the header of
+// the section with an empty path
+type ItemKey struct {
+	Section Section
+	Path    string
+}
+
+// itemKey returns the key of a row
+func itemKey(item list.Item) (ItemKey, bool) {
+	switch item := item.(type) {
+	case FileItem:
+		return ItemKey{item.Section, item.Path}, true
+	case DirItem:
+		return ItemKey{item.Section, item.Path}, true
+	case SectionItem:
+		return ItemKey{Section: item.Section}, true
+	}
+	return ItemKey{}, false
+}
+
+// SectionItem is the header of a section, followed by the tree of its files
+type SectionItem struct {
+	Section   Section
+	Collapsed bool
+	Selection Selection
+	Files     int
+}
+
+// Title implements the list.Item interface
+func (i SectionItem) Title() string {
+	arrow := "▾ "
+	if i.Collapsed {
+		arrow = "▸ "
+	}
+
+	name := lipgloss.NewStyle().Bold(true).Render(i.Section.String())
+	count := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(fmt.Sprintf("(%d)", i.Files))
+
+	return arrow + i.Selection.checkbox() + name + " " + count
+}
+
+// Description implements the list.Item interface
+func (i SectionItem) Description() string { return "" }
+
+// FilterValue implements the list.Item interface
+func (i SectionItem) FilterValue() string { return i.Section.String() }
+
+// buildList returns the rows of the sections that list any of files: the header of each
+// section followed by the tree of its files, unless the section is collapsed
+func buildList(files []git.GitFile, collapsed, selected map[ItemKey]bool) []list.Item {
+	var items []list.Item
+	for section := range Section(sectionCount) {
+		sectionFiles := inSection(files, section)
+		if len(sectionFiles) == 0 {
+			continue
+		}
+
+		selectedFiles := 0
+		for _, file := range sectionFiles {
+			if selected[ItemKey{section, file.Path}] {
+				selectedFiles++
+			}
+		}
+
+		header := SectionItem{
+			Section:   section,
+			Collapsed: collapsed[ItemKey{Section: section}],
+			Selection: selection(selectedFiles, len(sectionFiles)),
+			Files:     len(sectionFiles),
+		}
+		items = append(items, header)
+		if !header.Collapsed {
+			items = newTree(section, sectionFiles).items(1, collapsed, selected, items)
+		}
+	}
+	return items
+}
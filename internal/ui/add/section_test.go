@@ -0,0 +1,214 @@
+package add
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+)
+
+func TestSectionsOf(t *testing.T) {
+	tests := []struct {
+		status string
+		want   []Section
+	}{
+		{"M ", []Section{SectionStaged}},
+		{" M", []Section{SectionUnstaged}},
+		{"MM", []Section{SectionStaged, SectionUnstaged}},
+		{"AM", []Section{SectionStaged, SectionUnstaged}},
+		{" D", []Section{SectionUnstaged}},
+		{"??", []Section{SectionUntracked}},
+		{"UU", []Section{SectionConflicted}},
+		{"AA", []Section{SectionConflicted}},
+	}
+
+	for _, tt := range tests {
+		t.Run("GIVEN "+tt.status+" THEN it is listed in its sections", func(t *testing.T) {
+			assert.Equal(t, tt.want, sectionsOf(git.GitFile{Status: tt.status, Path: "file"}))
+		})
+	}
+}
+
+func TestSectionActions(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := gogit.PlainInit(dir, false)
+	require.NoError(t, err)
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	for _, name := range []string{"a.txt", "b.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(name+"\n"), 0o644))
+		_, err = wt.Add(name)
+		require.NoError(t, err)
+	}
+	_, err = wt.Commit("chore: initial", &gogit.CommitOptions{
+		Author: &object.Signature{Name: "Test User", Email: "test@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("staged\n"), 0o644))
+	_, err = wt.Add("a.txt")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("modified\n"), 0o644))
+	for _, name := range []string{"junk.log", "new.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(name+"\n"), 0o644))
+	}
+	t.Chdir(dir)
+
+	model, p := startProgram(t)
+	p.send(tea.WindowSizeMsg{Width: 120, Height: 40})
+	p.settle()
+	require.Equal(t, []string{"Staged", "a.txt", "Unstaged", "b.txt", "Untracked", "junk.log", "new.txt"}, rows(model))
+	assert.Contains(t, model.View(), "Enter: Unstage")
+
+	// Enter unstages in Staged
+	p.send(tea.KeyMsg{Type: tea.KeyEnter})
+	p.settle()
+	require.NoError(t, model.Err)
+	assert.Equal(t, map[string]string{"a.txt": " M", "b.txt": " M", "junk.log": "??", "new.txt": "??"}, statuses(model))
+
+	// i ignores in Untracked
+	for model.List.Index() < 4 {
+		p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	}
+	require.Equal(t, "junk.log", model.List.SelectedItem().(FileItem).Path)
+	assert.Contains(t, model.View(), "i: Ignore")
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	p.settle()
+	require.NoError(t, model.Err)
+	assert.Equal(t, map[string]string{"a.txt": " M", "b.txt": " M", ".gitignore": "??", "new.txt": "??"}, statuses(model))
+
+	// x deletes in Untracked once confirmed
+	for model.List.Index() < 5 {
+		p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	}
+	require.Equal(t, "new.txt", model.List.SelectedItem().(FileItem).Path)
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	assert.True(t, model.Confirm)
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	p.settle()
+	assert.False(t, model.Confirm)
+	assert.FileExists(t, filepath.Join(dir, "new.txt"), "any key other than y cancels")
+
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	p.settle()
+	require.NoError(t, model.Err)
+	assert.NoFileExists(t, filepath.Join(dir, "new.txt"))
+	assert.Equal(t, map[string]string{"a.txt": " M", "b.txt": " M", ".gitignore": "??"}, statuses(model))
+
+	// Deleting and ignoring only apply to untracked files
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	require.Equal(t, "b.txt", model.List.SelectedItem().(FileItem).Path)
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	assert.False(t, model.Confirm)
+}
+
+// setupConflictedMerge creates a repository stopped in a merge of "feature" that conflicts
+// in file.txt and makes it the working directory
+func setupConflictedMerge(t *testing.T) string {
+	dir := t.TempDir()
+	command := func(args ...string) *exec.Cmd {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = dir
+		return cmd
+	}
+	run := func(args ...string) {
+		output, err := command(args...).CombinedOutput()
+		require.NoError(t, err, "git %v: %s", args, output)
+	}
+	write := func(content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "file.txt"), []byte(content), 0o644))
+	}
+
+	run("init", "-q")
+	write("a\nb\nc\n")
+	run("add", ".")
+	run("commit", "-q", "-m", "init")
+	run("checkout", "-q", "-b", "feature")
+	write("a\nfeature\nc\n")
+	run("commit", "-q", "-am", "feature")
+	run("checkout", "-q", "-")
+	write("a\nmain\nc\n")
+	run("commit", "-q", "-am", "main")
+
+	// The merge is expected to stop with a conflict
+	_ = command("merge", "feature").Run()
+
+	t.Chdir(dir)
+	return dir
+}
+
+// indexStages returns the stages of path in the index, one "<stage> <path>" line each
+func indexStages(t *testing.T, dir, path string) []string {
+	cmd := exec.Command("git", "ls-files", "--stage", "--", path)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	require.NoError(t, err)
+
+	var stages []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if fields := strings.Fields(line); len(fields) == 4 {
+			stages = append(stages, fields[2]+" "+fields[3])
+		}
+	}
+	return stages
+}
+
+func TestConflictedSection(t *testing.T) {
+	dir := setupConflictedMerge(t)
+	unmerged := []string{"1 file.txt", "2 file.txt", "3 file.txt"}
+	require.Equal(t, unmerged, indexStages(t, dir, "file.txt"))
+
+	model, p := startProgram(t)
+	p.send(tea.WindowSizeMsg{Width: 120, Height: 40})
+	p.settle()
+	require.Equal(t, []string{"Conflicted", "file.txt"}, rows(model))
+	assert.Contains(t, model.View(), "Enter: Resolve • r: Mark Resolved")
+
+	// c does not stage conflicted files
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	p.settle()
+	assert.Nil(t, model.Commit)
+	assert.Equal(t, unmerged, indexStages(t, dir, "file.txt"))
+
+	// Enter opens the conflict view on the file instead of staging it
+	p.send(tea.KeyMsg{Type: tea.KeyEnter})
+	p.settle()
+	require.NoError(t, model.Err)
+	require.NotNil(t, model.Conflict)
+	require.NotNil(t, model.Conflict.Current)
+	assert.Equal(t, "file.txt", model.Conflict.Current.Path)
+	assert.Equal(t, unmerged, indexStages(t, dir, "file.txt"), "the index keeps its stages")
+	assert.Equal(t, map[string]string{"file.txt": "UU"}, statuses(model))
+
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	p.settle()
+	assert.Nil(t, model.Conflict)
+	assert.False(t, model.Quitting)
+
+	// Files with markers left are not marked resolved
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	p.settle()
+	assert.ErrorIs(t, model.Err, git.ErrUnmergedPaths)
+	assert.Equal(t, unmerged, indexStages(t, dir, "file.txt"))
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+
+	// Once the markers are gone the file is marked resolved
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "file.txt"), []byte("a\nmerged\nc\n"), 0o644))
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	p.settle()
+	require.NoError(t, model.Err)
+	assert.Equal(t, []string{"0 file.txt"}, indexStages(t, dir, "file.txt"))
+	assert.Equal(t, map[string]string{"file.txt": "M "}, statuses(model))
+}
@@ -0,0 +1,247 @@
+package add
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui/common"
+)
+
+// Selection is the state of the checkbox of a file or folder
+type Selection int
+
+const (
+	// Unselected means neither the file nor any file below the folder is selected
+	Unselected Selection = iota
+	// PartlySelected means some, but not all, files below the folder are selected
+	PartlySelected
+	// FullySelected means the file, or every file below the folder, is selected
+	FullySelected
+)
+
+// checkbox renders the selection as a checkbox
+func (s Selection) checkbox() string {
+	switch s {
+	case FullySelected:
+		return "[x] "
+	case PartlySelected:
+		return "[-] "
+	default:
+		return "[ ] "
+	}
+}
+
+// DirItem is a folder of the file tree of a section, with the counts of the changed files
+// below it
+type DirItem struct {
+	Section    Section
+	Path       string
+	Depth      int
+	Collapsed  bool
+	Selection  Selection
+	Files      int // Changed files below the folder
+	Staged     int // Files with changes in the index
+	Unstaged   int // Files with changes in the working tree
+	Untracked  int
+	Conflicted int
+}
+
+// Title implements the list.Item interface
+func (i DirItem) Title() string {
+	arrow := "▾ "
+	if i.Collapsed {
+		arrow = "▸ "
+	}
+
+	name := common.TruncatePath(path.Base(i.Path)+"/", 60, 30, 27)
+	counts := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(i.counts())
+
+	return indent(i.Depth) + arrow + i.Selection.checkbox() + name + " " + counts
+}
+
+// counts summarizes the changed files below the folder, leaving out the kinds it has none of
+func (i DirItem) counts() string {
+	noun := "files"
+	if i.Files == 1 {
+		noun = "file"
+	}
+	parts := []string{fmt.Sprintf("%d %s", i.Files, noun)}
+
+	for _, count := range []struct {
+		n    int
+		kind string
+	}{
+		{i.Staged, "staged"},
+		{i.Unstaged, "unstaged"},
+		{i.Untracked, "untracked"},
+		{i.Conflicted, "conflicted"},
+	} {
+		if count.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", count.n, count.kind))
+		}
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+// Description implements the list.Item interface
+func (i DirItem) Description() string { return "" }
+
+// FilterValue implements the list.Item interface
+func (i DirItem) FilterValue() string { return i.Path }
+
+// indent returns the indentation of a tree row at depth
+func indent(depth int) string {
+	return strings.Repeat("  ", depth)
+}
+
+// treeNode is a folder of the changed files of a section
+type treeNode struct {
+	section Section
+	path    string
+	dirs    map[string]*treeNode
+	files   []git.GitFile
+}
+
+// newTree groups the files of section by folder
+func newTree(section Section, files []git.GitFile) *treeNode {
+	root := &treeNode{section: section, dirs: make(map[string]*treeNode)}
+	for _, file := range files {
+		node := root
+		if dir := path.Dir(file.Path); dir != "." {
+			for _, name := range strings.Split(dir, "/") {
+				child, ok := node.dirs[name]
+				if !ok {
+					child = &treeNode{section: section, path: path.Join(node.path, name), dirs: make(map[string]*treeNode)}
+					node.dirs[name] = child
+				}
+				node = child
+			}
+		}
+		node.files = append(node.files, file)
+	}
+	return root
+}
+
+// children returns the folders sorted by name, then the files sorted by path, so that the
+// tree does not depend on the order of the status
+func (n *treeNode) children() ([]*treeNode, []git.GitFile) {
+	dirs := make([]*treeNode, 0, len(n.dirs))
+	for _, dir := range n.dirs {
+		dirs = append(dirs, dir)
+	}
+	sort.Slice(dirs, func(i, j int) bool { return dirs[i].path < dirs[j].path })
+
+	files := append([]git.GitFile(nil), n.files...)
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	return dirs, files
+}
+
+// walk calls fn for every file below the folder
+func (n *treeNode) walk(fn func(git.GitFile)) {
+	for _, dir := range n.dirs {
+		dir.walk(fn)
+	}
+	for _, file := range n.files {
+		fn(file)
+	}
+}
+
+// items appends the rows of the folder's children at depth, leaving out the contents of
+// collapsed folders
+func (n *treeNode) items(depth int, collapsed, selected map[ItemKey]bool, items []list.Item) []list.Item {
+	dirs, files := n.children()
+	for _, dir := range dirs {
+		item := dir.dirItem(depth, collapsed[ItemKey{n.section, dir.path}], selected)
+		items = append(items, item)
+		if !item.Collapsed {
+			items = dir.items(depth+1, collapsed, selected, items)
+		}
+	}
+	for _, file := range files {
+		items = append(items, FileItem{
+			Section: n.section, Status: file.Status, Path: file.Path, Depth: depth,
+			IsSelected: selected[ItemKey{n.section, file.Path}],
+		})
+	}
+	return items
+}
+
+// dirItem returns the row of the folder, counting the files below it
+func (n *treeNode) dirItem(depth int, collapsed bool, selected map[ItemKey]bool) DirItem {
+	item := DirItem{Section: n.section, Path: n.path, Depth: depth, Collapsed: collapsed}
+
+	selectedFiles := 0
+	n.walk(func(file git.GitFile) {
+		item.Files++
+		if selected[ItemKey{n.section, file.Path}] {
+			selectedFiles++
+		}
+
+		switch {
+		case git.IsConflicted(file.Status):
+			item.Conflicted++
+		case file.Status == "??":
+			item.Untracked++
+		default:
+			if file.Status[0] != ' ' {
+				item.Staged++
+			}
+			if file.Status[1] != ' ' {
+				item.Unstaged++
+			}
+		}
+	})
+
+	item.Selection = selection(selectedFiles, item.Files)
+	return item
+}
+
+// selection returns the state of the checkbox of a row with selected of its files selected
+func selection(selected, files int) Selection {
+	switch selected {
+	case 0:
+		return Unselected
+	case files:
+		return FullySelected
+	default:
+		return PartlySelected
+	}
+}
+
+// paths returns the selected files below the folder. A folder that fold accepts is
+// returned instead of its files, so that the action applies to it in one go.
+func (n *treeNode) paths(selected map[ItemKey]bool, fold func(*treeNode) bool) []string {
+	var paths []string
+
+	dirs, files := n.children()
+	for _, dir := range dirs {
+		if fold(dir) {
+			paths = append(paths, dir.path)
+			continue
+		}
+		paths = append(paths, dir.paths(selected, fold)...)
+	}
+	for _, file := range files {
+		if selected[ItemKey{n.section, file.Path}] {
+			paths = append(paths, file.Path)
+		}
+	}
+	return paths
+}
+
+// exists reports whether a file below the folder is still on disk, which keeps the
+// folder itself there
+func (n *treeNode) exists() bool {
+	found := false
+	n.walk(func(file git.GitFile) {
+		found = found || !strings.ContainsRune(file.Status, 'D')
+	})
+	return found
+}
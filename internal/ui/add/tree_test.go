@@ -0,0 +1,185 @@
+package add
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+)
+
+// rows returns every row of the list: the title of a section, or the path of a folder,
+// with a trailing slash, or of a file
+func rows(model *Model) []string {
+	var result []string
+	for _, item := range model.List.Items() {
+		switch item := item.(type) {
+		case SectionItem:
+			result = append(result, item.Section.String())
+		case DirItem:
+			result = append(result, item.Path+"/")
+		case FileItem:
+			result = append(result, item.Path)
+		}
+	}
+	return result
+}
+
+func TestTree(t *testing.T) {
+	files := []git.GitFile{
+		{Status: "??", Path: "z.txt"},
+		{Status: "M ", Path: "src/ui/view.go"},
+		{Status: "MM", Path: "src/main.go"},
+		{Status: "UU", Path: "src/ui/model.go"},
+		{Status: " D", Path: "docs/old.md"},
+		{Status: "??", Path: "a.txt"},
+	}
+
+	t.Run("GIVEN files in any order THEN folders come before files, both sorted by name", func(t *testing.T) {
+		items := newTree(SectionUnstaged, files).items(0, nil, nil, nil)
+
+		var got []string
+		for _, item := range items {
+			key, _ := itemKey(item)
+			got = append(got, indent(depth(item))+key.Path)
+		}
+		assert.Equal(t, []string{
+			"docs",
+			"  docs/old.md",
+			"src",
+			"  src/ui",
+			"    src/ui/model.go",
+			"    src/ui/view.go",
+			"  src/main.go",
+			"a.txt",
+			"z.txt",
+		}, got)
+	})
+
+	t.Run("GIVEN a folder THEN its row counts the files below it by kind", func(t *testing.T) {
+		selected := map[ItemKey]bool{{SectionUnstaged, "src/main.go"}: true, {SectionStaged, "src/ui/view.go"}: true}
+		items := newTree(SectionUnstaged, files).items(0, nil, selected, nil)
+
+		src := items[2].(DirItem)
+		assert.Equal(t, DirItem{
+			Section: SectionUnstaged, Path: "src", Selection: PartlySelected,
+			Files: 3, Staged: 2, Unstaged: 1, Conflicted: 1,
+		}, src, "only the selections of the section count")
+		assert.Contains(t, src.Title(), "[-] src/")
+		assert.Contains(t, src.Title(), "(3 files, 2 staged, 1 unstaged, 1 conflicted)")
+	})
+
+	t.Run("GIVEN a collapsed folder THEN the files below it are hidden", func(t *testing.T) {
+		items := newTree(SectionUnstaged, files).items(0, map[ItemKey]bool{{SectionUnstaged, "src"}: true}, nil, nil)
+
+		require.Len(t, items, 5)
+		assert.True(t, items[2].(DirItem).Collapsed)
+		assert.Contains(t, items[2].(DirItem).Title(), "▸ ")
+		assert.Equal(t, "a.txt", items[3].(FileItem).Path)
+	})
+}
+
+func TestActionPaths(t *testing.T) {
+	model := &Model{Files: []git.GitFile{
+		{Status: " M", Path: "src/ui/view.go"},
+		{Status: " M", Path: "src/ui/model.go"},
+		{Status: " M", Path: "src/main.go"},
+		{Status: " D", Path: "docs/old.md"},
+		{Status: " M", Path: "z.txt"},
+	}}
+	selected := map[ItemKey]bool{
+		{SectionUnstaged, "src/ui/view.go"}:  true,
+		{SectionUnstaged, "src/ui/model.go"}: true,
+		{SectionUnstaged, "docs/old.md"}:     true,
+		{SectionUnstaged, "z.txt"}:           true,
+	}
+
+	t.Run("GIVEN fully selected folders THEN they are returned as a whole unless they are gone", func(t *testing.T) {
+		assert.Equal(t, []string{"docs/old.md", "src/ui", "z.txt"}, model.actionPaths(SectionUnstaged, selected, true))
+		assert.Equal(t, []string{"docs/old.md", "src/ui/model.go", "src/ui/view.go", "z.txt"}, model.actionPaths(SectionUnstaged, selected, false))
+	})
+
+	t.Run("GIVEN a folder with changes outside the section THEN its files are returned", func(t *testing.T) {
+		model.Files = append(model.Files, git.GitFile{Status: "??", Path: "src/ui/new.go"})
+		assert.Equal(t, []string{"docs/old.md", "src/ui/model.go", "src/ui/view.go", "z.txt"}, model.actionPaths(SectionUnstaged, selected, true))
+	})
+}
+
+// depth returns the depth of a file or folder row
+func depth(item list.Item) int {
+	switch item := item.(type) {
+	case FileItem:
+		return item.Depth
+	case DirItem:
+		return item.Depth
+	}
+	return 0
+}
+
+func TestFolderStaging(t *testing.T) {
+	dir := t.TempDir()
+	_, err := gogit.PlainInit(dir, false)
+	require.NoError(t, err)
+	for _, name := range []string{"lib/a.txt", "lib/sub/b.txt", "top.txt"} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(name+"\n"), 0o644))
+	}
+	t.Chdir(dir)
+
+	model, p := startProgram(t)
+	p.send(tea.WindowSizeMsg{Width: 120, Height: 40})
+	p.settle()
+	require.Equal(t, []string{"Untracked", "lib/", "lib/sub/", "lib/sub/b.txt", "lib/a.txt", "top.txt"}, rows(model))
+	assert.Equal(t, 3, model.List.Index(), "the cursor starts on the first file")
+	assert.Equal(t, "lib/sub/b.txt", model.CurrentFile)
+
+	// Toggling a folder selects every file below it, toggling it again unselects them
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	p.send(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	assert.Equal(t, map[ItemKey]bool{{SectionUntracked, "lib/a.txt"}: true, {SectionUntracked, "lib/sub/b.txt"}: true}, model.Selected)
+	p.send(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	assert.Empty(t, model.Selected)
+
+	// A folder with some files selected is partly selected and becomes fully selected
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	p.send(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	assert.Equal(t, PartlySelected, model.List.Items()[1].(DirItem).Selection)
+	assert.Equal(t, FullySelected, model.List.Items()[2].(DirItem).Selection)
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	p.send(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	assert.Equal(t, FullySelected, model.List.Items()[1].(DirItem).Selection)
+	assert.Equal(t, PartlySelected, model.List.Items()[0].(SectionItem).Selection)
+
+	// Left folds folders and the section, moving up from folded ones, right unfolds them
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	p.send(tea.KeyMsg{Type: tea.KeyLeft})
+	assert.Equal(t, []string{"Untracked", "lib/", "lib/sub/", "lib/a.txt", "top.txt"}, rows(model))
+	p.send(tea.KeyMsg{Type: tea.KeyLeft})
+	p.send(tea.KeyMsg{Type: tea.KeyLeft})
+	assert.Equal(t, []string{"Untracked", "lib/", "top.txt"}, rows(model))
+	p.send(tea.KeyMsg{Type: tea.KeyLeft})
+	p.send(tea.KeyMsg{Type: tea.KeyLeft})
+	assert.Equal(t, []string{"Untracked"}, rows(model))
+	p.send(tea.KeyMsg{Type: tea.KeyRight})
+	p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	p.send(tea.KeyMsg{Type: tea.KeyRight})
+	assert.Equal(t, []string{"Untracked", "lib/", "lib/sub/", "lib/a.txt", "top.txt"}, rows(model))
+
+	// The fully selected folder is staged in one call
+	section, paths := model.targets(true)
+	assert.Equal(t, SectionUntracked, section)
+	assert.Equal(t, []string{"lib"}, paths)
+	p.send(tea.KeyMsg{Type: tea.KeyEnter})
+	p.settle()
+	assert.Equal(t, map[string]string{"lib/a.txt": "A ", "lib/sub/b.txt": "A ", "top.txt": "??"}, statuses(model))
+	assert.Equal(t, "Staged", rows(model)[0])
+}
@@ -1,22 +1,25 @@
 package add
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"path"
 	"strings"
 	"time"
 
-	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui/blame"
+	"github.com/LaansDole/go-git-tui/internal/ui/commit"
+	"github.com/LaansDole/go-git-tui/internal/ui/conflict"
 )
 
-// DelayedDiffMsg is sent after the navigation debounce period to trigger a diff load
-type DelayedDiffMsg struct {
-	FilePath  string
-	RequestID int64 // Unique ID to track requests and avoid race conditions
-}
+// prefetchRadius is the number of files above and below the cursor whose diffs are prefetched
+const prefetchRadius = 3
 
 // DelayedResizeMsg is sent after the resize debounce period to handle a resize operation
 type DelayedResizeMsg struct {
@@ -31,19 +34,30 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Quit
 	}
 
-	// Limited message processing while loading diff
-	if m.LoadingDiff {
-		if keyMsg, ok := msg.(tea.KeyMsg); ok {
-			switch keyMsg.String() {
-			case "q", "ctrl+c", "esc":
-				m.Quitting = true
-				m.LoadingDiff = false
-				return m, tea.Quit
-			default:
-				// Allow only escape keys while loading diff
-				return m, nil
-			}
-		}
+	// The list keeps up with the files while the blame view is open
+	switch msg := msg.(type) {
+	case WatchStartedMsg:
+		m.changes = msg.Changes
+		return m, waitForChange(m.changes)
+	case FilesChangedMsg:
+		return m.handleFilesChanged()
+	case StatusRefreshedMsg:
+		return m.handleStatusRefreshed(msg)
+	}
+
+	// The commit view owns all input until it is closed
+	if m.Commit != nil {
+		return m.updateCommit(msg)
+	}
+
+	// The blame view owns all input until it is closed
+	if m.Blame != nil {
+		return m.updateBlame(msg)
+	}
+
+	// The conflict view owns all input until it is closed
+	if m.Conflict != nil {
+		return m.updateConflict(msg)
 	}
 
 	switch msg := msg.(type) {
@@ -57,6 +71,18 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return m.handleWindowResize(msg)
 
+	case StatusLoadedMsg:
+		return m.handleStatusLoaded(msg)
+
+	case spinner.TickMsg:
+		// The spinner stops once the status is loaded
+		if !m.Loading {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.Spinner, cmd = m.Spinner.Update(msg)
+		return m, cmd
+
 	case DiffLoadedMsg:
 		// Handle diff loaded message
 		return m.handleDiffLoaded(msg)
@@ -65,15 +91,6 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Handle message timeout
 		return m.handleTick()
 
-	case DelayedDiffMsg:
-		// Only load the diff if we're not currently navigating and not quitting
-		if !m.isNavigating && !m.Quitting && msg.FilePath == m.CurrentFile {
-			// Safely trigger the diff loading
-			m.LoadingDiff = true
-			return m, m.ShowDiff(msg.FilePath)
-		}
-		return m, nil
-
 	case DelayedResizeMsg:
 		if m.Quitting {
 			return m, nil
@@ -84,18 +101,49 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Handle staging complete message
 		return m.handleStagingComplete(msg)
 
+	case ActionCompleteMsg:
+		return m.handleActionComplete(msg)
+
 	case ErrMsg:
-		// Display error message and quit
-		return m, tea.Sequence(
-			tea.Printf("Error: %v", msg.error),
-			func() tea.Msg { return tea.Quit },
-		)
+		// Explain the error until a key is pressed
+		m.Err = msg.error
+		m.LoadingDiff = false
+		return m, nil
 
 	case tea.KeyMsg:
+		if m.Loading {
+			// Loading can only be cancelled, which leaves the program
+			switch msg.String() {
+			case "q", "ctrl+c", "esc":
+				m.cancelLoad()
+				m.Quitting = true
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		if m.Err != nil {
+			// Without a repository there is nothing to go back to
+			if m.GitService == nil || msg.String() == "q" || msg.String() == "ctrl+c" {
+				m.Quitting = true
+				m.cancelWatch()
+				return m, tea.Quit
+			}
+			m.Err = nil
+			return m, nil
+		}
+
+		if m.Confirm {
+			return m.handleConfirmKeys(msg)
+		}
+
 		// Handle various keyboard commands
 		switch msg.String() {
 		case "q", "ctrl+c", "esc":
 			m.Quitting = true
+			m.cancelDiff()
+			m.cancelPrefetch()
+			m.cancelWatch()
 			return m, tea.Quit
 
 		case "tab", " ":
@@ -106,6 +154,22 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Confirm staging with Enter
 			return m, m.ConfirmStaging()
 
+		case "c":
+			// Stage and write the commit message
+			return m, m.StageAndCommit()
+
+		case "r":
+			// Mark conflicted files resolved once no markers are left
+			return m, m.MarkResolved()
+
+		case "i":
+			// Ignore untracked files
+			return m, m.Ignore()
+
+		case "x":
+			// Delete untracked files, once confirmed
+			return m, m.ConfirmDelete()
+
 		case "j":
 			m.DiffViewport.LineDown(1)
 			return m, nil
@@ -126,8 +190,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, nil
 				} else {
 					// Otherwise, show diff for this file
-					m.CurrentFile = i.Path
-					m.LoadingDiff = true
+					m.setCurrentFile(i.Path)
 					m.Message = "Loading diff..."
 					m.MessageTimeout = 10
 					return m, m.ShowDiff(i.Path)
@@ -135,6 +198,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
+		case "b":
+			// Blame the current file
+			return m.openBlame()
+
 		case "g":
 			// Scroll to top (like vim)
 			m.DiffViewport.GotoTop()
@@ -145,9 +212,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.DiffViewport.GotoBottom()
 			return m, nil
 
-		case "left", "right":
-			// Intentionally ignore these keys to prevent unexpected exits
-			return m, nil
+		case "left":
+			// Fold the section or folder, or move up to the parent
+			return m, m.collapse()
+
+		case "right":
+			// Unfold the section or folder
+			return m, m.expand()
 		}
 	}
 
@@ -155,6 +226,127 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m.handleNavigationKeys(msg)
 }
 
+// openBlame opens the blame view for the file under the cursor
+func (m *Model) openBlame() (tea.Model, tea.Cmd) {
+	i, ok := m.List.SelectedItem().(FileItem)
+	if !ok || m.GitService == nil {
+		return m, nil
+	}
+
+	// Files that are not in HEAD yet have no history to blame
+	if i.Status == "??" || strings.HasPrefix(i.Status, "A") {
+		m.Message = fmt.Sprintf("%s has not been committed yet", i.Path)
+		m.MessageTimeout = 20
+		return m, tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg { return TickMsg{} })
+	}
+
+	m.Blame = blame.NewWithService(m.GitService, i.Path)
+	m.Blame.Embedded = true
+	cmds := []tea.Cmd{m.Blame.Init()}
+	if m.Width > 0 {
+		_, cmd := m.Blame.Update(tea.WindowSizeMsg{Width: m.Width, Height: m.Height})
+		cmds = append(cmds, cmd)
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// updateBlame forwards messages to the blame view and closes it when it is done
+func (m *Model) updateBlame(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case blame.ClosedMsg:
+		m.Blame = nil
+		return m, nil
+	case tea.WindowSizeMsg:
+		// Keep the file list laid out for when the blame view is closed
+		m.handleWindowResize(msg)
+	}
+
+	_, cmd := m.Blame.Update(msg)
+	if m.Blame.Quitting {
+		m.Quitting = true
+		m.cancelWatch()
+	}
+	return m, cmd
+}
+
+// openConflict opens the conflict view on path, a file of the Conflicted section
+func (m *Model) openConflict(path string) tea.Cmd {
+	m.cancelDiff()
+	m.cancelPrefetch()
+
+	m.Conflict = conflict.NewWithService(m.GitService)
+	m.Conflict.Embedded = true
+	m.Conflict.Path = path
+	cmds := []tea.Cmd{m.Conflict.Init()}
+	if m.Width > 0 {
+		_, cmd := m.Conflict.Update(tea.WindowSizeMsg{Width: m.Width, Height: m.Height})
+		cmds = append(cmds, cmd)
+	}
+	return tea.Batch(cmds...)
+}
+
+// updateConflict forwards messages to the conflict view and closes it when it is done
+func (m *Model) updateConflict(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case conflict.ClosedMsg:
+		m.Conflict = nil
+		// Resolved files moved to Staged
+		return m, m.requestRefresh()
+	case tea.WindowSizeMsg:
+		// Keep the file list laid out for when the conflict view is closed
+		m.handleWindowResize(msg)
+	}
+
+	_, cmd := m.Conflict.Update(msg)
+	if m.Conflict.Quitting {
+		m.Quitting = true
+		m.cancelWatch()
+	}
+	return m, cmd
+}
+
+// openCommit opens the commit view on the git service of the list
+func (m *Model) openCommit() tea.Cmd {
+	m.Commit = commit.NewWithService(m.GitService, git.CommitMessage{})
+	m.Commit.Embedded = true
+	cmds := []tea.Cmd{m.Commit.Init()}
+	if m.Width > 0 {
+		_, cmd := m.updateCommit(tea.WindowSizeMsg{Width: m.Width, Height: m.Height})
+		cmds = append(cmds, cmd)
+	}
+	return tea.Batch(cmds...)
+}
+
+// updateCommit forwards messages to the commit view and closes it when it is done
+func (m *Model) updateCommit(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case commit.ClosedMsg:
+		m.Commit = nil
+		if !m.StayOpen {
+			m.Quitting = true
+			m.cancelWatch()
+			return m, tea.Quit
+		}
+		// The committed files are gone from the status
+		return m, m.requestRefresh()
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			m.Quitting = true
+			m.cancelWatch()
+			return m, tea.Quit
+		}
+	case tea.WindowSizeMsg:
+		// Keep the file list laid out for when the commit view is closed
+		m.handleWindowResize(msg)
+	}
+
+	updated, cmd := m.Commit.Update(msg)
+	if c, ok := updated.(commit.Model); ok {
+		m.Commit = &c
+	}
+	return m, cmd
+}
+
 // handleWindowResize handles window resize messages
 func (m *Model) handleWindowResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	// Store dimensions
@@ -222,27 +414,228 @@ func (m *Model) handleWindowResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 		Foreground(lipgloss.Color("240")).
 		Width(DividerWidth)
 
-	// If there's at least one item, set the current file on first load
-	if len(m.List.Items()) > 0 && m.CurrentFile == "" {
-		if i, ok := m.List.SelectedItem().(FileItem); ok {
-			m.CurrentFile = i.Path
-			m.DiffViewport.SetContent("Select a file and press TAB to view the diff")
+	return m, m.selectFirstFile()
+}
+
+// selectFirstFile sets the current file once both the layout and the status are known
+func (m *Model) selectFirstFile() tea.Cmd {
+	if !m.Ready || len(m.List.Items()) == 0 || m.CurrentFile != "" {
+		return nil
+	}
+
+	// The first rows are the header of a section and folders, the cursor moves on to
+	// the first file
+	if _, ok := m.List.SelectedItem().(FileItem); !ok {
+		for index, item := range m.List.Items() {
+			if _, ok := item.(FileItem); ok {
+				m.List.Select(index)
+				break
+			}
 		}
 	}
+	i, ok := m.List.SelectedItem().(FileItem)
+	if !ok {
+		return nil
+	}
+	m.setCurrentFile(i.Path)
+	m.DiffViewport.SetContent("Select a file and press TAB to view the diff")
+	return m.prefetchDiffs()
+}
 
-	return m, nil
+// handleStatusLoaded fills the list with the status loaded at startup
+func (m *Model) handleStatusLoaded(msg StatusLoadedMsg) (tea.Model, tea.Cmd) {
+	m.cancelLoad()
+	m.Loading = false
+
+	if msg.Err != nil {
+		// Cancelling the load quits, so there is nothing to report
+		if !errors.Is(msg.Err, context.Canceled) {
+			m.Err = msg.Err
+		}
+		return m, nil
+	}
+
+	m.GitService = msg.Service
+	m.State = msg.State
+
+	cmd := m.setFiles(msg.Files)
+
+	return m, tea.Batch(cmd, m.selectFirstFile(), m.startWatch())
+}
+
+// cancelLoad cancels the status load, if it is still running
+func (m *Model) cancelLoad() {
+	if m.loadCancel != nil {
+		m.loadCancel()
+		m.loadCancel = nil
+	}
+}
+
+// startWatch starts watching the repository for changes that refresh the list
+func (m *Model) startWatch() tea.Cmd {
+	if m.refreshDebounce <= 0 || m.GitService == nil || m.watchCancel != nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.watchCancel = cancel
+
+	service, debounce := m.GitService, m.refreshDebounce
+	return func() tea.Msg {
+		changes, err := service.Watch(ctx, debounce)
+		if err != nil {
+			// The list still works, it is just not refreshed
+			return nil
+		}
+		return WatchStartedMsg{Changes: changes}
+	}
+}
+
+// waitForChange waits for the next change reported by the watcher
+func waitForChange(changes <-chan struct{}) tea.Cmd {
+	if changes == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		if _, ok := <-changes; !ok {
+			return nil
+		}
+		return FilesChangedMsg{}
+	}
+}
+
+// cancelWatch stops the watcher, if it is running
+func (m *Model) cancelWatch() {
+	if m.watchCancel != nil {
+		m.watchCancel()
+		m.watchCancel = nil
+	}
+}
+
+// handleFilesChanged refreshes the status and waits for the next change. A refresh that
+// is already running may have missed the change, so it is followed by another one.
+func (m *Model) handleFilesChanged() (tea.Model, tea.Cmd) {
+	return m, tea.Batch(waitForChange(m.changes), m.requestRefresh())
+}
+
+// requestRefresh refreshes the status, or refreshes it again once the running refresh
+// is done
+func (m *Model) requestRefresh() tea.Cmd {
+	if m.refreshing {
+		m.refreshPending = true
+		return nil
+	}
+	return m.refreshStatus()
+}
+
+// Refresh reads the status again and merges it into the list, for hosts that changed
+// the repository
+func (m *Model) Refresh() tea.Cmd {
+	if m.Loading {
+		return nil
+	}
+	return m.requestRefresh()
+}
+
+// refreshStatus reads the status again
+func (m *Model) refreshStatus() tea.Cmd {
+	if m.Quitting || m.GitService == nil {
+		return nil
+	}
+	m.refreshing = true
+
+	service := m.GitService
+	return func() tea.Msg {
+		state, files, err := readStatus(context.Background(), service)
+		return StatusRefreshedMsg{State: state, Files: files, Err: err}
+	}
+}
+
+// handleStatusRefreshed merges the refreshed status into the list
+func (m *Model) handleStatusRefreshed(msg StatusRefreshedMsg) (tea.Model, tea.Cmd) {
+	m.refreshing = false
+	if m.Quitting {
+		return m, nil
+	}
+
+	var cmds []tea.Cmd
+	if m.refreshPending {
+		m.refreshPending = false
+		cmds = append(cmds, m.refreshStatus())
+	}
+
+	if msg.Err != nil {
+		// Keep the list as it is until the next change
+		m.Message = fmt.Sprintf("Refresh failed: %v", msg.Err)
+		m.MessageTimeout = 20
+		cmds = append(cmds, tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg { return TickMsg{} }))
+		return m, tea.Batch(cmds...)
+	}
+
+	m.State = msg.State
+	cmds = append(cmds, m.mergeFiles(msg.Files))
+	return m, tea.Batch(cmds...)
+}
+
+// mergeFiles replaces the listed files with files, keeping the selections, the cursor and
+// the open diff on the same paths. When the file under the cursor is gone, the cursor
+// stays at its position; when the current file is gone, its diff is closed.
+func (m *Model) mergeFiles(files []git.GitFile) tea.Cmd {
+	cursor := m.List.Index()
+	cursorKey, onItem := itemKey(m.List.SelectedItem())
+
+	cmds := []tea.Cmd{m.setFiles(files)}
+
+	items := m.List.Items()
+	for i, item := range items {
+		if key, ok := itemKey(item); onItem && ok && key == cursorKey {
+			cursor = i
+		}
+	}
+	current := false
+	for _, file := range m.Files {
+		current = current || file.Path == m.CurrentFile
+	}
+	if len(items) > 0 {
+		m.List.Select(min(cursor, len(items)-1))
+	}
+
+	if !current {
+		m.setCurrentFile("")
+		m.CurrentDiff = nil
+		m.DiffViewport.SetContent("")
+		return tea.Batch(append(cmds, m.selectFirstFile())...)
+	}
+
+	if m.CurrentDiff != nil {
+		cmds = append(cmds, m.ShowDiff(m.CurrentFile))
+	}
+	return tea.Batch(append(cmds, m.prefetchDiffs())...)
 }
 
 // handleDiffLoaded handles when a diff is loaded
 func (m *Model) handleDiffLoaded(msg DiffLoadedMsg) (tea.Model, tea.Cmd) {
-	if m.Quitting {
+	// Results of loads that were cancelled or superseded are stale
+	if m.Quitting || msg.RequestID != m.diffRequestID {
+		return m, nil
+	}
+
+	if m.diffCancel != nil {
+		m.diffCancel()
+		m.diffCancel = nil
+	}
+	m.LoadingDiff = false
+
+	if msg.Err != nil {
+		if !errors.Is(msg.Err, context.Canceled) {
+			m.Err = msg.Err
+		}
 		return m, nil
 	}
 
 	m.CurrentDiff = msg.Diff
 	content := m.FormatDiffContent(msg.Diff)
 	m.DiffViewport.SetContent(content)
-	m.LoadingDiff = false
 	m.Message = ""
 	m.MessageTimeout = 0
 	return m, nil
@@ -262,13 +655,27 @@ func (m *Model) handleTick() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// handleStagingComplete handles when staging is complete
+// handleStagingComplete handles when staging is complete. The list is refreshed to keep
+// building up the index, unless StayOpen is off and the program exits.
 func (m *Model) handleStagingComplete(msg StagingCompleteMsg) (tea.Model, tea.Cmd) {
-	m.Quitting = true
-
 	m.Message = fmt.Sprintf("%d files staged", len(msg.Files))
 	m.MessageTimeout = 10
 
+	if msg.Commit || m.StayOpen {
+		m.clearSelection()
+		cmds := []tea.Cmd{
+			m.requestRefresh(),
+			tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg { return TickMsg{} }),
+		}
+		if msg.Commit {
+			cmds = append(cmds, m.openCommit())
+		}
+		return m, tea.Batch(cmds...)
+	}
+
+	m.Quitting = true
+	m.cancelWatch()
+
 	filesOutput := strings.Join(msg.Files, "\n")
 	finalOutput := fmt.Sprintf("\nThe following files have been staged:\n%s\n", filesOutput)
 
@@ -279,38 +686,150 @@ func (m *Model) handleStagingComplete(msg StagingCompleteMsg) (tea.Model, tea.Cm
 	)
 }
 
-// handleSelectionToggle handles toggling selection of files
-func (m *Model) handleSelectionToggle(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	idx := m.List.Index()
-	m.Selected[idx] = !m.Selected[idx]
-
-	// Update the item in the list
-	if i, ok := m.List.SelectedItem().(FileItem); ok {
-		items := []list.Item{}
-		for j, item := range m.List.Items() {
-			if j == idx {
-				i.IsSelected = m.Selected[idx]
-				items = append(items, i)
-			} else {
-				items = append(items, item)
+// setFiles lists the files matched by the pathspec in their sections, dropping the
+// selections of the files that are no longer listed there
+func (m *Model) setFiles(files []git.GitFile) tea.Cmd {
+	m.Files = nil
+	listed := make(map[ItemKey]bool)
+	for _, file := range files {
+		if m.Pathspec.Match(file.Path) {
+			m.Files = append(m.Files, file)
+			for _, section := range sectionsOf(file) {
+				listed[ItemKey{section, file.Path}] = true
 			}
 		}
-		m.List.SetItems(items)
 	}
 
-	if _, ok := m.List.SelectedItem().(FileItem); ok && msg.String() == "tab" {
-		isLastFile := m.List.Index() == len(m.List.Items())-1
+	for key := range m.Selected {
+		if !listed[key] {
+			delete(m.Selected, key)
+		}
+	}
+	return m.rebuildList()
+}
 
-		if !isLastFile {
-			m.List.Select(m.List.Index() + 1)
+// rebuildList fills the list with the sections of the files, leaving the cursor at its
+// position
+func (m *Model) rebuildList() tea.Cmd {
+	return m.List.SetItems(buildList(m.Files, m.Collapsed, m.Selected))
+}
+
+// filesBelow returns the paths of the files of the section of key that are below its
+// folder, or all of them for the header of the section
+func (m *Model) filesBelow(key ItemKey) []string {
+	var paths []string
+	for _, file := range inSection(m.Files, key.Section) {
+		if key.Path == "" || strings.HasPrefix(file.Path, key.Path+"/") {
+			paths = append(paths, file.Path)
 		}
+	}
+	return paths
+}
 
+// selectFiles selects or unselects paths in section
+func (m *Model) selectFiles(selected bool, section Section, paths ...string) {
+	if m.Selected == nil {
+		m.Selected = make(map[ItemKey]bool)
+	}
+	for _, p := range paths {
+		if selected {
+			m.Selected[ItemKey{section, p}] = true
+		} else {
+			delete(m.Selected, ItemKey{section, p})
+		}
+	}
+}
+
+// clearSelection unselects every file
+func (m *Model) clearSelection() {
+	m.Selected = make(map[ItemKey]bool)
+	m.rebuildList()
+}
+
+// handleSelectionToggle toggles the file under the cursor, or every file below the folder
+// or section under the cursor: unless all of them are selected, they all become selected
+func (m *Model) handleSelectionToggle(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch item := m.List.SelectedItem().(type) {
+	case FileItem:
+		m.selectFiles(!item.IsSelected, item.Section, item.Path)
+	case DirItem:
+		m.selectFiles(item.Selection != FullySelected, item.Section, m.filesBelow(ItemKey{item.Section, item.Path})...)
+	case SectionItem:
+		m.selectFiles(item.Selection != FullySelected, item.Section, m.filesBelow(ItemKey{Section: item.Section})...)
+	default:
 		return m, nil
 	}
+	m.rebuildList()
+
+	if msg.String() == "tab" {
+		isLastItem := m.List.Index() == len(m.List.Items())-1
+
+		if !isLastItem {
+			m.List.Select(m.List.Index() + 1)
+			if next, ok := m.List.SelectedItem().(FileItem); ok {
+				m.setCurrentFile(next.Path)
+			}
+		}
+	}
 
 	return m, nil
 }
 
+// collapse folds the section or folder under the cursor, or moves the cursor from a file
+// or a folded folder up to its parent folder or section
+func (m *Model) collapse() tea.Cmd {
+	item := m.List.SelectedItem()
+	key, ok := itemKey(item)
+	if !ok {
+		return nil
+	}
+
+	switch item := item.(type) {
+	case SectionItem:
+		return m.setCollapsed(key, true)
+	case DirItem:
+		if !item.Collapsed {
+			return m.setCollapsed(key, true)
+		}
+	}
+
+	parent := ItemKey{Section: key.Section}
+	if dir := path.Dir(key.Path); dir != "." {
+		parent.Path = dir
+	}
+	for i, item := range m.List.Items() {
+		if k, ok := itemKey(item); ok && k == parent {
+			m.List.Select(i)
+			break
+		}
+	}
+	return nil
+}
+
+// expand unfolds the section or folder under the cursor
+func (m *Model) expand() tea.Cmd {
+	switch item := m.List.SelectedItem().(type) {
+	case SectionItem:
+		return m.setCollapsed(ItemKey{Section: item.Section}, false)
+	case DirItem:
+		return m.setCollapsed(ItemKey{item.Section, item.Path}, false)
+	}
+	return nil
+}
+
+// setCollapsed folds or unfolds the section or folder of key
+func (m *Model) setCollapsed(key ItemKey, collapsed bool) tea.Cmd {
+	if m.Collapsed == nil {
+		m.Collapsed = make(map[ItemKey]bool)
+	}
+	if collapsed {
+		m.Collapsed[key] = true
+	} else {
+		delete(m.Collapsed, key)
+	}
+	return m.rebuildList()
+}
+
 // handleNavigationKeys handles navigation keys for both viewports
 func (m *Model) handleNavigationKeys(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Limit the maximum number of commands we'll send at once
@@ -363,39 +882,15 @@ func (m *Model) handleNavigationKeys(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			if prevIndex != m.List.Index() && len(m.List.Items()) > 0 {
 				if item, ok := m.List.SelectedItem().(FileItem); ok {
-					m.CurrentFile = item.Path
-
-					// Handle w/s navigation debouncing
-					if msg.String() == "w" || msg.String() == "s" {
-						// Set navigating state
-						m.isNavigating = true
-						m.lastNavTime = time.Now()
-
-						// Generate unique request ID for this navigation request
-						requestID := time.Now().UnixNano()
-
-						if len(cmds) < maxCommands {
-							// Start a debounced diff loading with unique ID
-							cmds = append(cmds, tea.Tick(m.navDebounceTime, func(t time.Time) tea.Msg {
-								// Safety check for synchronized state access
-								m.diffMutex.Lock()
-								navigatingSince := time.Since(m.lastNavTime)
-								quitting := m.Quitting
-								m.diffMutex.Unlock()
-
-								// Only load the diff if no other navigation has happened since this timer started
-								if navigatingSince >= m.navDebounceTime && !quitting {
-									m.diffMutex.Lock()
-									m.isNavigating = false
-									m.diffMutex.Unlock()
-									return DelayedDiffMsg{
-										FilePath:  item.Path,
-										RequestID: requestID,
-									}
-								}
-								return nil
-							}))
-						}
+					m.setCurrentFile(item.Path)
+
+					// Handle w/s navigation debouncing: a cached diff shows at once, others
+					// load once the cursor rests, while the neighbours are prefetched
+					if (msg.String() == "w" || msg.String() == "s") && len(cmds) < maxCommands {
+						cmds = append(cmds, tea.Batch(
+							m.loadDiff(item.Path, m.navDebounceTime),
+							m.prefetchDiffs(),
+						))
 					}
 				}
 			}
@@ -427,59 +922,119 @@ func (m *Model) handleNavigationKeys(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// ShowDiff loads and displays the diff for the selected file
+// ShowDiff starts loading the diff for the selected file, cancelling the load in flight.
+// The command only reports the result; handleDiffLoaded applies it if it is still current.
 func (m *Model) ShowDiff(filePath string) tea.Cmd {
-	return func() tea.Msg {
-		// Make a local copy of needed data to avoid race conditions
-		m.diffMutex.Lock()
-		if m.Quitting || m.GitService == nil || m.isNavigating || filePath != m.CurrentFile {
-			m.LoadingDiff = false
-			m.diffMutex.Unlock()
-			return nil
-		}
+	return m.loadDiff(filePath, 0)
+}
 
-		// Rate limiting to avoid excessive git operations
-		elapsed := time.Since(m.lastDiffTime)
-		if elapsed < m.minDiffDelay {
-			// Instead of sleeping which blocks the thread, just skip this update
-			// if it's too soon after the last one
-			m.diffMutex.Unlock()
-			return nil
-		}
-		m.lastDiffTime = time.Now()
-		m.diffMutex.Unlock()
+// loadDiff is ShowDiff for a diff that is computed only after delay, unless it is cached.
+// Cancelling the load during the delay, by moving on to another file, skips it.
+func (m *Model) loadDiff(filePath string, delay time.Duration) tea.Cmd {
+	m.cancelDiff()
+	if m.Quitting {
+		return nil
+	}
 
-		// Perform git operation outside of the lock to avoid blocking
-		var diff *git.DiffResult
-		var err error
+	ctx, cancel := context.WithCancel(context.Background())
+	m.diffCancel = cancel
+	m.LoadingDiff = true
+
+	service, requestID := m.GitService, m.diffRequestID
+	return func() tea.Msg {
+		msg := DiffLoadedMsg{RequestID: requestID}
 
 		// Safely call git service with recovery for potential panics
 		func() {
 			defer func() {
 				if r := recover(); r != nil {
-					err = fmt.Errorf("panic in GetFileDiff: %v", r)
+					msg.Err = fmt.Errorf("panic in GetFileDiff: %v", r)
 				}
 			}()
-			diff, err = m.GitService.GetFileDiff(filePath)
+			// Without a repository there is no diff to show
+			if service == nil {
+				return
+			}
+			if delay > 0 {
+				if diff, ok := service.CachedFileDiff(ctx, filePath); ok {
+					msg.Diff = diff
+					return
+				}
+
+				select {
+				case <-time.After(delay):
+				case <-ctx.Done():
+					msg.Err = ctx.Err()
+					return
+				}
+			}
+			msg.Diff, msg.Err = service.GetFileDiff(ctx, filePath)
 		}()
 
-		// Reacquire lock to check state before returning
-		m.diffMutex.Lock()
-		defer m.diffMutex.Unlock()
+		return msg
+	}
+}
 
-		// Final check if state changed while doing git operation
-		if m.Quitting || filePath != m.CurrentFile {
-			m.LoadingDiff = false
-			return nil
-		}
+// prefetchDiffs computes the diffs of the files around the cursor in the background,
+// cancelling the previous prefetch. Its command returns no message: the diffs end up in
+// the diff cache of the git service.
+func (m *Model) prefetchDiffs() tea.Cmd {
+	m.cancelPrefetch()
+	if m.Quitting || m.GitService == nil {
+		return nil
+	}
 
-		if err != nil {
-			m.LoadingDiff = false
-			return ErrMsg{err}
+	var paths []string
+	items, index := m.List.Items(), m.List.Index()
+	for distance := 1; distance <= prefetchRadius; distance++ {
+		for _, i := range []int{index + distance, index - distance} {
+			if i < 0 || i >= len(items) {
+				continue
+			}
+			if item, ok := items[i].(FileItem); ok {
+				paths = append(paths, item.Path)
+			}
 		}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.prefetchCancel = cancel
+
+	service := m.GitService
+	return func() tea.Msg {
+		_ = service.PrefetchDiffs(ctx, paths)
+		return nil
+	}
+}
+
+// cancelPrefetch stops computing the diffs of the previous prefetch
+func (m *Model) cancelPrefetch() {
+	if m.prefetchCancel != nil {
+		m.prefetchCancel()
+		m.prefetchCancel = nil
+	}
+}
 
-		return DiffLoadedMsg{Diff: diff}
+// setCurrentFile selects the file whose diff is shown. Moving to another file cancels
+// the diff load in flight for the previous one.
+func (m *Model) setCurrentFile(path string) {
+	if path != m.CurrentFile {
+		m.cancelDiff()
 	}
+	m.CurrentFile = path
+}
+
+// cancelDiff cancels the diff load in flight, if any, so that its result is dropped
+func (m *Model) cancelDiff() {
+	if m.diffCancel != nil {
+		m.diffCancel()
+		m.diffCancel = nil
+	}
+	m.diffRequestID++
+	m.LoadingDiff = false
 }
 
 // FormatDiffContent formats the diff content with syntax highlighting
@@ -532,40 +1087,211 @@ func (m *Model) GetDiffStats(diff *git.DiffResult) string {
 	)
 }
 
-// ConfirmStaging stages the selected files and exits
+// ConfirmStaging applies the default action of the section under the cursor: it unstages
+// in Staged, opens the conflict view in Conflicted and stages in the other sections. It
+// applies to the selected files of the section, or to the file, folder or section under
+// the cursor when none is selected. StagingCompleteMsg or ActionCompleteMsg reports
+// success, after which the list is refreshed, or the program exits after staging when
+// StayOpen is off.
 func (m *Model) ConfirmStaging() tea.Cmd {
-	return func() tea.Msg {
-		m.Quitting = true
-		m.LoadingDiff = false
+	section, paths := m.targets(true)
+	if len(paths) == 0 {
+		return nil
+	}
+	switch section {
+	case SectionStaged:
+		return m.runAction("unstaged", paths, (*git.DefaultGitService).Unstage)
+	case SectionConflicted:
+		// Staging would not resolve anything, the conflict view does
+		if m.GitService == nil {
+			return nil
+		}
+		_, files := m.targets(false)
+		return m.openConflict(files[0])
+	}
+	return m.stage(paths, false)
+}
 
-		var selectedPaths []string
-		for i, item := range m.List.Items() {
-			if m.Selected[i] {
-				if fileItem, ok := item.(FileItem); ok {
-					selectedPaths = append(selectedPaths, fileItem.Path)
-				}
-			}
+// StageAndCommit stages like ConfirmStaging and then opens the commit view. In Staged, or
+// with nothing to stage, the commit view opens right away to commit what is staged.
+// Conflicted files are never staged: they have to be resolved first.
+func (m *Model) StageAndCommit() tea.Cmd {
+	section, paths := m.targets(true)
+	if section == SectionConflicted {
+		return nil
+	}
+	if len(paths) == 0 || section == SectionStaged {
+		if m.GitService == nil {
+			return nil
 		}
+		return m.openCommit()
+	}
+	return m.stage(paths, true)
+}
 
-		if len(selectedPaths) == 0 && m.CurrentFile != "" {
-			selectedPaths = []string{m.CurrentFile}
+// MarkResolved marks the conflicted files the actions apply to, see ConfirmStaging, as
+// resolved. Files that still have conflict markers are refused, nothing is marked then.
+func (m *Model) MarkResolved() tea.Cmd {
+	section, paths := m.targets(false)
+	if section != SectionConflicted || len(paths) == 0 {
+		return nil
+	}
+	return m.runAction("marked resolved", paths, markResolved)
+}
+
+// markResolved marks paths resolved once LoadConflict finds no hunks left in any of them
+func markResolved(service *git.DefaultGitService, ctx context.Context, paths []string) error {
+	for _, path := range paths {
+		file, err := service.LoadConflict(ctx, path)
+		if err != nil {
+			return err
 		}
+		if n := len(file.Hunks); n > 0 {
+			return fmt.Errorf("%w: %s still has %d conflict(s), press Enter to resolve them", git.ErrUnmergedPaths, path, n)
+		}
+	}
 
-		if len(selectedPaths) == 0 {
-			return nil
+	for _, path := range paths {
+		if err := service.MarkResolved(ctx, path); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		gitService, err := git.NewGitService()
-		if err != nil {
+// Ignore adds the untracked files the actions apply to, see ConfirmStaging, to .gitignore
+func (m *Model) Ignore() tea.Cmd {
+	section, paths := m.targets(true)
+	if section != SectionUntracked || len(paths) == 0 {
+		return nil
+	}
+	return m.runAction("ignored", paths, (*git.DefaultGitService).Ignore)
+}
+
+// ConfirmDelete asks whether to delete the untracked files the actions apply to, see
+// ConfirmStaging. Folders are never deleted as a whole, only the files listed below them.
+func (m *Model) ConfirmDelete() tea.Cmd {
+	section, paths := m.targets(false)
+	if section != SectionUntracked || len(paths) == 0 {
+		return nil
+	}
+
+	m.Confirm = true
+	m.pendingDelete = paths
+	if len(paths) == 1 {
+		m.Message = fmt.Sprintf("Delete %s? It is not tracked and cannot be restored (y/N)", paths[0])
+	} else {
+		m.Message = fmt.Sprintf("Delete %d untracked files? They cannot be restored (y/N)", len(paths))
+	}
+	m.MessageTimeout = 0
+	return nil
+}
+
+// handleConfirmKeys deletes the untracked files on y and cancels on any other key
+func (m *Model) handleConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	paths := m.pendingDelete
+	m.Confirm = false
+	m.pendingDelete = nil
+	m.Message = ""
+
+	if msg.String() != "y" {
+		return m, nil
+	}
+	return m, m.runAction("deleted", paths, (*git.DefaultGitService).DeleteUntracked)
+}
+
+// targets returns the section under the cursor and the paths its actions apply to: the
+// selected files of the section, or else the file, folder or section under the cursor.
+// With fold set, folders whose changed files are all included are returned as a whole.
+func (m *Model) targets(fold bool) (Section, []string) {
+	key, ok := itemKey(m.List.SelectedItem())
+	if !ok {
+		return 0, nil
+	}
+
+	if paths := m.actionPaths(key.Section, m.Selected, fold); len(paths) > 0 {
+		return key.Section, paths
+	}
+
+	selected := make(map[ItemKey]bool)
+	if _, isFile := m.List.SelectedItem().(FileItem); isFile {
+		selected[key] = true
+	} else {
+		for _, p := range m.filesBelow(key) {
+			selected[ItemKey{key.Section, p}] = true
+		}
+	}
+	return key.Section, m.actionPaths(key.Section, selected, fold)
+}
+
+// actionPaths returns the selected files of section. With fold set, a folder is returned
+// instead of its files when they and every other changed file below it are selected,
+// the pathspec leaves none of its changes out of the list, and it is still on disk.
+func (m *Model) actionPaths(section Section, selected map[ItemKey]bool, fold bool) []string {
+	canFold := func(dir *treeNode) bool {
+		if !fold || m.Pathspec != nil || !dir.exists() {
+			return false
+		}
+		for _, file := range m.Files {
+			if strings.HasPrefix(file.Path, dir.path+"/") && !selected[ItemKey{section, file.Path}] {
+				return false
+			}
+		}
+		return true
+	}
+	return newTree(section, inSection(m.Files, section)).paths(selected, canFold)
+}
+
+// runAction runs action on paths with the git service of the list. ActionCompleteMsg
+// reports success, after which the list is refreshed.
+func (m *Model) runAction(action string, paths []string, run func(*git.DefaultGitService, context.Context, []string) error) tea.Cmd {
+	if m.GitService == nil {
+		return nil
+	}
+	m.cancelDiff()
+	m.cancelPrefetch()
+
+	service := m.GitService
+	return func() tea.Msg {
+		if err := run(service, context.Background(), paths); err != nil {
 			return ErrMsg{err}
 		}
+		return ActionCompleteMsg{Action: action, Files: paths}
+	}
+}
 
-		err = gitService.Stage(selectedPaths)
-		if err != nil {
+// handleActionComplete reports the files an action applied to and refreshes the list
+func (m *Model) handleActionComplete(msg ActionCompleteMsg) (tea.Model, tea.Cmd) {
+	m.Message = fmt.Sprintf("%d files %s", len(msg.Files), msg.Action)
+	m.MessageTimeout = 10
+	m.clearSelection()
+
+	return m, tea.Batch(
+		m.requestRefresh(),
+		tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg { return TickMsg{} }),
+	)
+}
+
+// stage stages paths with the git service of the list, then opens the commit view when
+// thenCommit is set
+func (m *Model) stage(paths []string, thenCommit bool) tea.Cmd {
+	m.cancelDiff()
+	m.cancelPrefetch()
+
+	service := m.GitService
+	return func() tea.Msg {
+		if service == nil {
+			var err error
+			if service, err = git.NewGitService(); err != nil {
+				return ErrMsg{err}
+			}
+		}
+
+		if err := service.Stage(context.Background(), paths); err != nil {
 			return ErrMsg{err}
 		}
 
-		return StagingCompleteMsg{Files: selectedPaths}
+		return StagingCompleteMsg{Files: paths, Commit: thenCommit}
 	}
 }
 
@@ -1,6 +1,10 @@
 package add
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/charmbracelet/bubbles/list"
@@ -8,6 +12,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/LaansDole/go-git-tui/internal/git"
 )
@@ -66,7 +71,7 @@ func TestModelUpdate(t *testing.T) {
 			DiffViewport: viewport.New(80, 40),
 			LoadingDiff:  true,
 			StyleConfig:  NewStyleConfig(),
-			Selected:     make(map[int]bool),
+			Selected:     make(map[ItemKey]bool),
 		}
 		diff := &git.DiffResult{
 			Content: "test content",
@@ -97,6 +102,60 @@ func TestModelUpdate(t *testing.T) {
 		assert.NotNil(t, cmd)
 	})
 
+	// Test that errors are explained instead of quitting
+	t.Run("error message", func(t *testing.T) {
+		fileItems := []list.Item{FileItem{Path: "file1.go", Status: "M "}}
+		model := &Model{
+			List:        list.New(fileItems, list.NewDefaultDelegate(), 80, 40),
+			Selected:    make(map[ItemKey]bool),
+			StyleConfig: NewStyleConfig(),
+			GitService:  &git.DefaultGitService{},
+			LoadingDiff: true,
+		}
+
+		err := fmt.Errorf("%w: file1.go", git.ErrFileNotFound)
+		newModel, cmd := model.Update(ErrMsg{err})
+		updatedModel := newModel.(*Model)
+		assert.Nil(t, cmd, "the UI keeps running")
+		assert.Equal(t, err, updatedModel.Err)
+		assert.False(t, updatedModel.LoadingDiff)
+		assert.Contains(t, updatedModel.View(), "The file no longer has changes")
+
+		// Any key other than q goes back to the file list
+		newModel, _ = updatedModel.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		updatedModel = newModel.(*Model)
+		assert.Nil(t, updatedModel.Err)
+		assert.False(t, updatedModel.Quitting)
+	})
+
+	// Test that moving to another file cancels the diff load in flight
+	t.Run("navigation cancels diff load", func(t *testing.T) {
+		fileItems := []list.Item{
+			FileItem{Path: "file1.go", Status: "M "},
+			FileItem{Path: "file2.go", Status: "M "},
+		}
+		cancelled := false
+		model := &Model{
+			List:         list.New(fileItems, list.NewDefaultDelegate(), 80, 40),
+			Selected:     make(map[ItemKey]bool),
+			StyleConfig:  NewStyleConfig(),
+			DiffViewport: viewport.New(80, 40),
+			CurrentFile:  "file1.go",
+			LoadingDiff:  true,
+			diffCancel:   func() { cancelled = true },
+		}
+
+		newModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+		updatedModel := newModel.(*Model)
+		assert.True(t, cancelled, "the load for file1.go is cancelled")
+		assert.True(t, updatedModel.LoadingDiff, "the load for file2.go waits for the cursor to rest")
+		assert.Equal(t, "file2.go", updatedModel.CurrentFile)
+
+		// A diff for the previous file that finished anyway is dropped
+		newModel, _ = updatedModel.Update(DiffLoadedMsg{Diff: &git.DiffResult{Path: "file1.go"}})
+		assert.Nil(t, newModel.(*Model).CurrentDiff)
+	})
+
 	// Test selection key handling
 	t.Run("selection toggle", func(t *testing.T) {
 		fileItems := []list.Item{
@@ -108,7 +167,7 @@ func TestModelUpdate(t *testing.T) {
 
 		model := &Model{
 			List:        l,
-			Selected:    make(map[int]bool),
+			Selected:    make(map[ItemKey]bool),
 			StyleConfig: NewStyleConfig(),
 		}
 
@@ -116,7 +175,7 @@ func TestModelUpdate(t *testing.T) {
 		newModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}})
 		updatedModel, ok := newModel.(*Model)
 		assert.True(t, ok)
-		assert.True(t, updatedModel.Selected[0], "First item should be selected")
+		assert.True(t, updatedModel.Selected[ItemKey{Path: "file1.go"}], "First item should be selected")
 	})
 
 	// Test w/s navigation key handling to verify diff loading
@@ -132,7 +191,7 @@ func TestModelUpdate(t *testing.T) {
 		// Create the model
 		model := &Model{
 			List:         l,
-			Selected:     make(map[int]bool),
+			Selected:     make(map[ItemKey]bool),
 			StyleConfig:  NewStyleConfig(),
 			DiffViewport: viewport.New(80, 40),
 		}
@@ -278,8 +337,8 @@ func TestConfirmStaging(t *testing.T) {
 			wantErr: false,
 			setup: func(model *Model, mockService *MockGitService) {
 				fileItems := []list.Item{
-					FileItem{Path: "file1.go", Status: "M ", IsSelected: true},
-					FileItem{Path: "file2.go", Status: "A ", IsSelected: true},
+					FileItem{Section: SectionUnstaged, Path: "file1.go", Status: " M", IsSelected: true},
+					FileItem{Section: SectionUnstaged, Path: "file2.go", Status: " M", IsSelected: true},
 				}
 				delegate := list.NewDefaultDelegate()
 				l := list.New(fileItems, delegate, 80, 40)
@@ -287,7 +346,8 @@ func TestConfirmStaging(t *testing.T) {
 				mockService.On("Stage", []string{"file1.go", "file2.go"}).Return(nil)
 
 				model.List = l
-				model.Selected = map[int]bool{0: true, 1: true}
+				model.Files = []git.GitFile{{Path: "file1.go", Status: " M"}, {Path: "file2.go", Status: " M"}}
+				model.Selected = map[ItemKey]bool{{SectionUnstaged, "file1.go"}: true, {SectionUnstaged, "file2.go"}: true}
 				model.GitService = nil // This would be set to mockService in a real test
 			},
 			check: func(t *testing.T, msg tea.Msg) {
@@ -302,7 +362,7 @@ func TestConfirmStaging(t *testing.T) {
 			wantErr: false,
 			setup: func(model *Model, mockService *MockGitService) {
 				fileItems := []list.Item{
-					FileItem{Path: "file1.go", Status: "M ", IsSelected: false},
+					FileItem{Section: SectionUnstaged, Path: "file1.go", Status: " M", IsSelected: false},
 				}
 				delegate := list.NewDefaultDelegate()
 				l := list.New(fileItems, delegate, 80, 40)
@@ -310,7 +370,8 @@ func TestConfirmStaging(t *testing.T) {
 				mockService.On("Stage", []string{"file1.go"}).Return(nil)
 
 				model.List = l
-				model.Selected = map[int]bool{}
+				model.Files = []git.GitFile{{Path: "file1.go", Status: " M"}}
+				model.Selected = map[ItemKey]bool{}
 				model.CurrentFile = "file1.go"
 				model.GitService = nil // This would be set to mockService in a real test
 			},
@@ -340,3 +401,64 @@ func TestConfirmStaging(t *testing.T) {
 		})
 	}
 }
+
+// TestStatusLoading tests loading the status when the UI starts
+func TestStatusLoading(t *testing.T) {
+	t.Run("GIVEN a slow load THEN the spinner shows until q cancels it", func(t *testing.T) {
+		model := New(nil)
+		cmd := model.Init()
+		assert.NotNil(t, cmd)
+		assert.True(t, model.Loading)
+		assert.Contains(t, model.View(), "Reading git status...")
+		assert.Contains(t, model.View(), "q: Cancel")
+
+		// Other keys wait for the load
+		newModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+		assert.False(t, newModel.(*Model).Quitting)
+
+		newModel, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+		updatedModel := newModel.(*Model)
+		assert.True(t, updatedModel.Quitting)
+		assert.Nil(t, updatedModel.loadCancel, "the load is cancelled")
+		assert.Equal(t, tea.Quit(), cmd())
+	})
+
+	t.Run("GIVEN a cancelled load THEN no error is shown", func(t *testing.T) {
+		model := New(nil)
+		model.Init()
+
+		newModel, _ := model.Update(StatusLoadedMsg{Err: context.Canceled})
+		assert.NoError(t, newModel.(*Model).Err)
+	})
+
+	t.Run("GIVEN a directory outside a repository THEN the error screen says so", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+
+		model := New(nil)
+		p := newProgram(t, model)
+		p.run(model.Init())
+		p.settle()
+
+		assert.ErrorIs(t, model.Err, git.ErrNotRepository)
+		assert.Contains(t, model.View(), "This is not a git repository")
+		assert.NotContains(t, model.View(), "No files to stage")
+
+		// There is nothing to go back to
+		_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		assert.True(t, model.Quitting)
+		assert.NotNil(t, cmd)
+	})
+
+	t.Run("GIVEN a corrupt index THEN the error screen says so", func(t *testing.T) {
+		setupRepoWithChanges(t, 1)
+		require.NoError(t, os.WriteFile(filepath.Join(".git", "index"), []byte("garbage\n"), 0o644))
+
+		model := New(nil)
+		p := newProgram(t, model)
+		p.run(model.Init())
+		p.settle()
+
+		assert.ErrorIs(t, model.Err, git.ErrCorruptRepository)
+		assert.Contains(t, model.View(), "The git repository is corrupt")
+	})
+}
@@ -3,8 +3,11 @@ package add
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/LaansDole/go-git-tui/internal/ui/common"
 )
 
 // View renders the UI - implements tea.Model interface
@@ -13,6 +16,26 @@ func (m *Model) View() string {
 		return m.Message
 	}
 
+	if m.Commit != nil {
+		return m.Commit.View()
+	}
+
+	if m.Blame != nil {
+		return m.Blame.View()
+	}
+
+	if m.Conflict != nil {
+		return m.Conflict.View()
+	}
+
+	if m.Err != nil {
+		return m.errorView()
+	}
+
+	if m.Loading {
+		return m.loadingView()
+	}
+
 	if !m.Ready {
 		return "Loading git repository..."
 	}
@@ -23,19 +46,25 @@ func (m *Model) View() string {
 	}
 
 	titleText := m.StyleConfig.TitleStyle.Render("Go Git TUI - Stage Files")
-
-	selectedCount := 0
-	for _, selected := range m.Selected {
-		if selected {
-			selectedCount++
-		}
+	if m.State.InProgress() {
+		banner := m.StyleConfig.DeletedStyle.Bold(true).Render(
+			fmt.Sprintf(" %s • resolve and stage files, then run commit to continue", m.State.Summary()))
+		titleText = lipgloss.JoinHorizontal(lipgloss.Top, titleText, banner)
 	}
 
 	statusText := m.StyleConfig.StatusBar.Render(
-		fmt.Sprintf("%d files, %d selected", len(m.List.Items()), selectedCount))
+		fmt.Sprintf("%d files, %d selected", len(m.Files), len(m.Selected)))
 
+	// The actions depend on the section under the cursor
+	actions := SectionUnstaged.help()
+	if key, ok := itemKey(m.List.SelectedItem()); ok {
+		actions = key.Section.help()
+	}
 	helpText := m.StyleConfig.HelpStyle.Render(
-		"w/s: Navigate Files • j/k: Scroll Diff • Tab: Select • Enter: Confirm • q: Quit")
+		"w/s: Navigate Files • ←/→: Fold • j/k: Scroll Diff • Tab: Select • b: Blame • " + actions + " • q: Quit")
+	if m.Confirm {
+		helpText = m.StyleConfig.HelpStyle.Render("y: Delete • Any key: Cancel")
+	}
 
 	diffTitle := "Diff"
 	diffStats := ""
@@ -108,3 +137,38 @@ func (m *Model) View() string {
 
 	return m.StyleConfig.AppStyle.Render(content)
 }
+
+// loadingView shows how long the status has been loading and how to cancel it
+func (m *Model) loadingView() string {
+	text := m.Spinner.View() + " Reading git status..."
+	if !m.loadStart.IsZero() {
+		text += fmt.Sprintf(" %.1fs", time.Since(m.loadStart).Seconds())
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		m.StyleConfig.InfoStyle.Render(text),
+		"",
+		m.StyleConfig.HelpStyle.Render("q: Cancel"),
+	)
+}
+
+// errorView explains the failed git operation and how to fix it
+func (m *Model) errorView() string {
+	help := common.DescribeError(m.Err)
+
+	lines := []string{m.StyleConfig.DeletedStyle.Bold(true).Render(help.Message)}
+	if help.Hint != "" {
+		lines = append(lines, m.StyleConfig.InfoStyle.Render(help.Hint))
+	}
+	if help.Detail != "" {
+		lines = append(lines, "", m.StyleConfig.HelpStyle.Render(help.Detail))
+	}
+
+	keys := "Any key: Back • q: Quit"
+	if m.GitService == nil {
+		keys = "Any key: Quit"
+	}
+	lines = append(lines, "", m.StyleConfig.HelpStyle.Render(keys))
+
+	return m.StyleConfig.AppStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
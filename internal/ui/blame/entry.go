@@ -0,0 +1,17 @@
+package blame
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Run initializes and runs the blame UI component for path in a fullscreen terminal view
+func Run(path string) error {
+	p := tea.NewProgram(
+		New(path),
+		tea.WithAltScreen(),
+		tea.WithMouseCellMotion(),
+	)
+
+	_, err := p.Run()
+	return err
+}
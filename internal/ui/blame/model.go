@@ -0,0 +1,74 @@
+package blame
+
+import (
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui/common"
+)
+
+// Custom message types
+type blameLoadedMsg struct {
+	result *git.BlameResult
+	from   string // Revision to return to with "b", empty when not drilling down
+}
+type diffLoadedMsg struct {
+	commit git.CommitInfo
+	diffs  []git.DiffResult
+}
+type errMsg struct{ err error }
+
+// ClosedMsg is emitted instead of quitting when the model is embedded in another view
+type ClosedMsg struct{}
+
+// Model represents the blame UI state
+type Model struct {
+	Path     string
+	Result   *git.BlameResult
+	Cursor   int      // Selected line, 0-based
+	Offset   int      // First visible line
+	History  []string // Revisions blamed before the current one, for going back
+	Diff     viewport.Model
+	ShowDiff bool
+	Loading  bool
+	Message  string
+	Err      error
+	Width    int
+	Height   int
+	Ready    bool
+	Quitting bool
+	Embedded bool // Emit ClosedMsg on quit instead of ending the program
+
+	GitService  *git.DefaultGitService
+	StyleConfig common.StyleConfig
+}
+
+// New initializes a blame model for path at HEAD
+func New(path string) *Model {
+	gitService, err := git.NewGitService()
+	m := NewWithService(gitService, path)
+	if err != nil {
+		m.Err = err
+	}
+	return m
+}
+
+// NewWithService initializes a blame model on an existing git service
+func NewWithService(gitService *git.DefaultGitService, path string) *Model {
+	return &Model{
+		Path:        path,
+		Diff:        viewport.New(0, 0),
+		Loading:     true,
+		GitService:  gitService,
+		StyleConfig: common.NewStyleConfig(),
+	}
+}
+
+// Init blames the file at HEAD - implements tea.Model interface
+func (m *Model) Init() tea.Cmd {
+	if m.GitService == nil {
+		return nil
+	}
+	return m.loadBlame("")
+}
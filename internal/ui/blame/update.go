@@ -0,0 +1,213 @@
+package blame
+
+import (
+	"context"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui/common"
+)
+
+// Update handles events and updates the model - implements tea.Model interface
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.Width, m.Height = msg.Width, msg.Height
+		m.Ready = true
+		h, _ := m.StyleConfig.AppStyle.GetFrameSize()
+		m.Diff.Width = msg.Width - h
+		m.Diff.Height = m.visibleLines()
+		m.scrollToCursor()
+		return m, nil
+
+	case blameLoadedMsg:
+		m.Loading = false
+		if msg.from != "" {
+			m.History = append(m.History, msg.from)
+		}
+		m.Result = msg.result
+		m.Cursor = min(m.Cursor, max(len(msg.result.Lines)-1, 0))
+		m.scrollToCursor()
+		m.Message = ""
+		return m, nil
+
+	case diffLoadedMsg:
+		m.Loading = false
+		m.ShowDiff = true
+		m.Diff.SetContent(common.RenderCommitDiff(msg.commit, msg.diffs, m.Diff.Width, m.StyleConfig))
+		m.Diff.GotoTop()
+		return m, nil
+
+	case errMsg:
+		m.Loading = false
+		if m.Result == nil {
+			m.Err = msg.err
+			return m, nil
+		}
+		m.Message = "Error: " + msg.err.Error()
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			m.Quitting = true
+			return m, tea.Quit
+		}
+		if m.Err != nil {
+			return m, m.quit()
+		}
+		if m.Loading {
+			return m, nil
+		}
+		if m.ShowDiff {
+			return m.handleDiffKeys(msg)
+		}
+		return m.handleBlameKeys(msg)
+	}
+
+	return m, nil
+}
+
+// handleBlameKeys handles keys while the annotated file is shown
+func (m *Model) handleBlameKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.Result == nil {
+		if msg.String() == "q" || msg.String() == "esc" {
+			return m, m.quit()
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "q", "esc":
+		return m, m.quit()
+	case "w", "up":
+		m.moveCursor(-1)
+	case "s", "down":
+		m.moveCursor(1)
+	case "pgup":
+		m.moveCursor(-m.visibleLines())
+	case "pgdown":
+		m.moveCursor(m.visibleLines())
+	case "g", "home":
+		m.moveCursor(-len(m.Result.Lines))
+	case "G", "end":
+		m.moveCursor(len(m.Result.Lines))
+	case "enter":
+		if line := m.currentLine(); line != nil {
+			m.Loading = true
+			return m, m.loadDiff(line.Hash)
+		}
+	case "p":
+		// Blame the parent of the commit that last touched this line to see past it
+		if line := m.currentLine(); line != nil {
+			m.Loading = true
+			m.Message = ""
+			return m, m.loadBlameFrom(line.Hash+"^", m.Result.Rev.Hash)
+		}
+	case "b", "backspace":
+		if n := len(m.History); n > 0 {
+			rev := m.History[n-1]
+			m.History = m.History[:n-1]
+			m.Loading = true
+			return m, m.loadBlame(rev)
+		}
+	}
+
+	return m, nil
+}
+
+// handleDiffKeys handles keys while a commit diff is shown
+func (m *Model) handleDiffKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "enter":
+		m.ShowDiff = false
+	case "w", "k", "up":
+		m.Diff.LineUp(1)
+	case "s", "j", "down":
+		m.Diff.LineDown(1)
+	case "pgup":
+		m.Diff.HalfViewUp()
+	case "pgdown", " ":
+		m.Diff.HalfViewDown()
+	case "g":
+		m.Diff.GotoTop()
+	case "G":
+		m.Diff.GotoBottom()
+	}
+	return m, nil
+}
+
+// quit ends the program, or hands control back to the parent view when embedded
+func (m *Model) quit() tea.Cmd {
+	if m.Embedded {
+		return func() tea.Msg { return ClosedMsg{} }
+	}
+	m.Quitting = true
+	return tea.Quit
+}
+
+// currentLine returns the blamed line under the cursor, if any
+func (m *Model) currentLine() *git.BlameLine {
+	if m.Result == nil || m.Cursor >= len(m.Result.Lines) {
+		return nil
+	}
+	return &m.Result.Lines[m.Cursor]
+}
+
+// moveCursor moves the cursor by delta lines and keeps it on screen
+func (m *Model) moveCursor(delta int) {
+	if m.Result == nil || len(m.Result.Lines) == 0 {
+		return
+	}
+	m.Cursor = min(max(m.Cursor+delta, 0), len(m.Result.Lines)-1)
+	m.scrollToCursor()
+}
+
+// scrollToCursor adjusts the offset so the cursor line is visible
+func (m *Model) scrollToCursor() {
+	visible := m.visibleLines()
+	if m.Cursor < m.Offset {
+		m.Offset = m.Cursor
+	} else if m.Cursor >= m.Offset+visible {
+		m.Offset = m.Cursor - visible + 1
+	}
+}
+
+// visibleLines is the number of file lines that fit on screen
+func (m *Model) visibleLines() int {
+	_, v := m.StyleConfig.AppStyle.GetFrameSize()
+	// Title, status, message and help lines
+	return max(m.Height-v-4, 1)
+}
+
+// loadBlame blames the file at rev
+func (m *Model) loadBlame(rev string) tea.Cmd {
+	return m.loadBlameFrom(rev, "")
+}
+
+// loadBlameFrom blames the file at rev, remembering from as the revision to go back to
+func (m *Model) loadBlameFrom(rev, from string) tea.Cmd {
+	service, path := m.GitService, m.Path
+	return func() tea.Msg {
+		result, err := service.Blame(context.Background(), path, rev)
+		if err != nil {
+			return errMsg{err}
+		}
+		return blameLoadedMsg{result: result, from: from}
+	}
+}
+
+// loadDiff loads the commit that introduced a line
+func (m *Model) loadDiff(hash string) tea.Cmd {
+	service := m.GitService
+	return func() tea.Msg {
+		commits, err := service.Log(context.Background(), git.LogOptions{From: hash, Limit: 1})
+		if err != nil {
+			return errMsg{err}
+		}
+		diffs, err := service.CommitDiff(context.Background(), hash)
+		if err != nil {
+			return errMsg{err}
+		}
+		return diffLoadedMsg{commit: commits[0], diffs: diffs}
+	}
+}
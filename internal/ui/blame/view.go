@@ -0,0 +1,107 @@
+package blame
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/LaansDole/go-git-tui/internal/ui/common"
+)
+
+// Column widths of the annotation shown before each line
+const (
+	authorWidth = 14
+	dateWidth   = 14
+)
+
+// View renders the current state of the model - implements tea.Model interface
+func (m *Model) View() string {
+	if m.Err != nil {
+		return m.StyleConfig.DeletedStyle.Render(fmt.Sprintf("Error: %v\nPress any key to exit", m.Err))
+	}
+
+	if m.Quitting {
+		return ""
+	}
+
+	if !m.Ready || m.Result == nil {
+		return "Loading blame..."
+	}
+
+	title := m.StyleConfig.TitleStyle.Render("Go Git TUI - Blame " + m.Path)
+
+	status := fmt.Sprintf("at %s %s", m.Result.Rev.ShortHash, m.Result.Rev.Subject)
+	if len(m.History) > 0 {
+		status += fmt.Sprintf(" • %d level(s) back from HEAD", len(m.History))
+	}
+	if m.Loading {
+		status += " • loading..."
+	}
+
+	var body, help string
+	if m.ShowDiff {
+		body = m.Diff.View()
+		help = "w/s: Scroll • PgUp/PgDn: Page • Esc: Back to blame • ctrl+c: Quit"
+	} else {
+		body = m.renderLines()
+		help = "w/s: Navigate • Enter: Show commit • p: Blame parent • b: Back • q: Quit"
+	}
+
+	message := ""
+	if m.Message != "" {
+		message = m.StyleConfig.DeletedStyle.Render(m.Message)
+	}
+
+	return m.StyleConfig.AppStyle.Render(lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		m.StyleConfig.StatusBar.Render(status),
+		body,
+		message,
+		m.StyleConfig.HelpStyle.Render(help),
+	))
+}
+
+// renderLines renders the visible lines with their annotations
+func (m *Model) renderLines() string {
+	lines := m.Result.Lines
+	if len(lines) == 0 {
+		return m.StyleConfig.InfoStyle.Render("The file is empty")
+	}
+
+	end := min(m.Offset+m.visibleLines(), len(lines))
+	numberWidth := len(fmt.Sprint(len(lines)))
+	h, _ := m.StyleConfig.AppStyle.GetFrameSize()
+	textWidth := max(m.Width-h-7-authorWidth-dateWidth-numberWidth-6, 10)
+	now := time.Now()
+
+	var sb strings.Builder
+	for i := m.Offset; i < end; i++ {
+		line := lines[i]
+
+		annotation := fmt.Sprintf("%s %-*s %-*s",
+			line.ShortHash,
+			authorWidth, common.TruncateText(line.Author, authorWidth, "…"),
+			dateWidth, common.RelativeTime(line.When, now))
+
+		// Consecutive lines from the same commit are dimmed to show blocks
+		if i > m.Offset && lines[i-1].Hash == line.Hash && i != m.Cursor {
+			annotation = m.StyleConfig.DividerStyle.Render(annotation)
+		} else {
+			annotation = m.StyleConfig.InfoStyle.Render(annotation)
+		}
+
+		cursor := "  "
+		if i == m.Cursor {
+			cursor = "▶ "
+		}
+
+		number := m.StyleConfig.StatusBar.Render(fmt.Sprintf("%*d", numberWidth, line.Number))
+		text := common.HighlightLine(m.Path, common.TruncateText(strings.ReplaceAll(line.Text, "\t", "    "), textWidth, "…"))
+
+		sb.WriteString(fmt.Sprintf("%s%s │ %s │ %s\n", cursor, annotation, number, text))
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,94 @@
+package branchview
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui/common"
+)
+
+// BranchItem represents a branch in the list
+type BranchItem struct {
+	Branch git.Branch
+}
+
+// Title implements the list.Item interface
+func (i BranchItem) Title() string {
+	if i.Branch.Current {
+		return "* " + i.Branch.Name
+	}
+	return "  " + i.Branch.Name
+}
+
+// Description implements the list.Item interface
+func (i BranchItem) Description() string {
+	c := i.Branch.Commit
+	description := fmt.Sprintf("  %s %s, %s", c.ShortHash, common.TruncateText(c.Subject, 60, "..."), common.RelativeTime(c.When, time.Now()))
+	if i.Branch.Upstream != "" {
+		description += " • tracks " + i.Branch.Upstream
+	}
+	return description
+}
+
+// FilterValue implements the list.Item interface
+func (i BranchItem) FilterValue() string { return i.Branch.Name }
+
+// Custom message types
+type branchesLoadedMsg struct{ branches []git.Branch }
+type errMsg struct{ err error }
+
+// Model represents the branch list UI state
+type Model struct {
+	List list.Model
+
+	Message  string
+	Err      error
+	Width    int
+	Height   int
+	Ready    bool
+	Quitting bool
+
+	GitService  *git.DefaultGitService
+	StyleConfig common.StyleConfig
+}
+
+// New initializes a branch model for the repository in the working directory
+func New() *Model {
+	gitService, err := git.NewGitService()
+	m := NewWithService(gitService)
+	if err != nil {
+		m.Err = err
+	}
+	return m
+}
+
+// NewWithService initializes a branch model on an existing git service
+func NewWithService(gitService *git.DefaultGitService) *Model {
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
+		Foreground(lipgloss.Color("170")).
+		Margin(0, 0)
+	delegate.SetSpacing(0)
+
+	l := list.New([]list.Item{}, delegate, 0, 0)
+	l.Title = "Branches"
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.SetShowHelp(false)
+
+	return &Model{
+		List:        l,
+		GitService:  gitService,
+		StyleConfig: common.NewStyleConfig(),
+	}
+}
+
+// Init loads the branches - implements tea.Model interface
+func (m *Model) Init() tea.Cmd {
+	return m.Refresh()
+}
@@ -0,0 +1,82 @@
+package branchview
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Update handles events and updates the model - implements tea.Model interface
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.Width, m.Height = msg.Width, msg.Height
+		m.Ready = true
+		h, v := m.StyleConfig.AppStyle.GetFrameSize()
+		m.List.SetSize(msg.Width-h, msg.Height-v-2) // Reserve space for message and help
+		return m, nil
+
+	case branchesLoadedMsg:
+		items := make([]list.Item, 0, len(msg.branches))
+		current := 0
+		for i, b := range msg.branches {
+			if b.Current {
+				current = i
+			}
+			items = append(items, BranchItem{Branch: b})
+		}
+		m.List.SetItems(items)
+		m.List.Select(current)
+		m.Message = ""
+		return m, nil
+
+	case errMsg:
+		m.Message = fmt.Sprintf("Error: %v", msg.err)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.Err != nil {
+			m.Quitting = true
+			return m, tea.Quit
+		}
+
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			m.Quitting = true
+			return m, tea.Quit
+		case "r":
+			m.Message = "Refreshing..."
+			return m, m.Refresh()
+		case "w":
+			m.List.CursorUp()
+			return m, nil
+		case "s":
+			m.List.CursorDown()
+			return m, nil
+		}
+
+		var cmd tea.Cmd
+		m.List, cmd = m.List.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+// Refresh loads the branches again
+func (m *Model) Refresh() tea.Cmd {
+	if m.GitService == nil {
+		return nil
+	}
+
+	service := m.GitService
+	return func() tea.Msg {
+		branches, err := service.Branches(context.Background())
+		if err != nil {
+			return errMsg{err}
+		}
+		return branchesLoadedMsg{branches: branches}
+	}
+}
@@ -0,0 +1,40 @@
+package branchview
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// View renders the current state of the model - implements tea.Model interface
+func (m *Model) View() string {
+	if m.Err != nil {
+		return m.StyleConfig.DeletedStyle.Render(fmt.Sprintf("Error: %v\nPress any key to exit", m.Err))
+	}
+
+	if m.Quitting {
+		return ""
+	}
+
+	if !m.Ready {
+		return "Loading branches..."
+	}
+
+	var body string
+	if len(m.List.Items()) == 0 {
+		body = m.StyleConfig.InfoStyle.Render("No branches yet. Create the first commit to start one.")
+	} else {
+		body = m.List.View()
+	}
+
+	message := ""
+	if m.Message != "" {
+		message = m.StyleConfig.InfoStyle.Render(m.Message)
+	}
+
+	return m.StyleConfig.AppStyle.Render(lipgloss.JoinVertical(lipgloss.Left,
+		body,
+		message,
+		m.StyleConfig.HelpStyle.Render("w/s: Navigate • r: Refresh • q: Quit"),
+	))
+}
@@ -2,12 +2,15 @@ package commit
 
 import (
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
 )
 
-// Run initializes and runs the commit UI component in a fullscreen terminal view
-func Run() error {
+// Run initializes and runs the commit UI component in a fullscreen terminal view,
+// starting at the first step that preset does not answer
+func Run(preset git.CommitMessage) error {
 	p := tea.NewProgram(
-		New(),
+		New(preset),
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
 	)
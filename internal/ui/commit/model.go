@@ -1,12 +1,21 @@
 package commit
 
 import (
+	"context"
+	"strings"
+
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui/common"
 )
 
+// fixupTargetLimit is the number of unpushed commits offered as fixup targets
+const fixupTargetLimit = 50
+
 // Model represents the commit UI state
 type Model struct {
 	Step          int // 0 = select type, 1 = enter message, 2 = confirm
@@ -21,40 +30,44 @@ type Model struct {
 	Ready         bool
 	Err           error
 	StyleConfig   StyleConfig
+	State         *git.RepoState // Merge, rebase, cherry-pick or revert in progress
+	Aborted       bool
+
+	FixupMode  bool            // Step 0 lists commits to fix up instead of commit types
+	TargetList list.Model      // Commits that are not on upstream yet
+	FixupKind  git.FixupKind   // fixup! or amend!
+	Target     *git.CommitInfo // Commit the fixup targets, nil for a regular commit
+	Notice     string          // Result of the last autosquash or a validation error
+
+	Preset git.CommitMessage // Scope, body and breaking flag given on the command line
+
+	Embedded   bool // Emit ClosedMsg on quit instead of ending the program
+	GitService *git.DefaultGitService
 }
 
-// New initializes a new commit model
-func New() *Model {
+// ClosedMsg is emitted instead of quitting when the model is embedded in another view
+type ClosedMsg struct{}
+
+// CommittedMsg reports that a commit was created, so that hosts can refresh other views
+type CommittedMsg struct{}
+
+// New initializes a new commit model. Fields of preset that are already filled in skip
+// the steps that ask for them, and its scope, body and breaking flag are used as given.
+func New(preset git.CommitMessage) *Model {
+	// Failing to open the repository is reported once the commit is attempted
+	gitService, _ := git.NewGitService()
+	return NewWithService(gitService, preset)
+}
+
+// NewWithService initializes a commit model on an existing git service
+func NewWithService(gitService *git.DefaultGitService, preset git.CommitMessage) *Model {
 	// Setup type selection list
-	items := []list.Item{
-		CommitTypeItem{
-			TypeTitle:       "feat",
-			TypeDescription: "A new feature",
-		},
-		CommitTypeItem{
-			TypeTitle:       "fix",
-			TypeDescription: "A bug fix",
-		},
-		CommitTypeItem{
-			TypeTitle:       "docs",
-			TypeDescription: "Documentation changes",
-		},
-		CommitTypeItem{
-			TypeTitle:       "chore",
-			TypeDescription: "Chores and maintenance tasks",
-		},
-		CommitTypeItem{
-			TypeTitle:       "refactor",
-			TypeDescription: "Code refactoring without functionality change",
-		},
-		CommitTypeItem{
-			TypeTitle:       "test",
-			TypeDescription: "Adding or fixing tests",
-		},
-		CommitTypeItem{
-			TypeTitle:       "style",
-			TypeDescription: "Code style/formatting changes",
-		},
+	items := make([]list.Item, 0, len(git.CommitTypes))
+	for _, t := range git.CommitTypes {
+		items = append(items, CommitTypeItem{
+			TypeTitle:       t.Name,
+			TypeDescription: t.Description,
+		})
 	}
 
 	// Create a custom delegate with more compact styling
@@ -85,6 +98,12 @@ func New() *Model {
 	typeList.SetFilteringEnabled(false)
 	typeList.SetShowHelp(false) // Use custom help instead
 
+	targetList := list.New([]list.Item{}, delegate, 0, 0)
+	targetList.Title = "Fixup of… (commits not on upstream):"
+	targetList.SetShowStatusBar(false)
+	targetList.SetFilteringEnabled(false)
+	targetList.SetShowHelp(false)
+
 	// Setup message input with improved styling
 	ti := textinput.New()
 	ti.Placeholder = "Enter commit message"
@@ -94,15 +113,74 @@ func New() *Model {
 	ti.TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
 	ti.Cursor.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("170"))
 
-	return &Model{
+	m := &Model{
 		Step:          0,
 		TypeList:      typeList,
+		TargetList:    targetList,
 		SelectedIndex: -1, // No selection initially
 		MessageInput:  ti,
 		Quitting:      false,
 		Ready:         false,
 		StyleConfig:   NewStyleConfig(),
+		Preset:        preset,
+		GitService:    gitService,
+	}
+
+	// Start at the first step the preset does not answer
+	for i, t := range git.CommitTypes {
+		if t.Name == preset.Type {
+			m.TypeList.Select(i)
+			m.SelectedIndex = i
+			m.SelectedType = t.Name
+			m.Step = 1
+			m.MessageInput.Focus()
+		}
+	}
+	if preset.Description != "" {
+		m.MessageInput.SetValue(preset.Description)
+		m.MessageInput.CursorEnd()
+	}
+
+	// An interrupted merge, rebase, cherry-pick or revert is concluded with git's
+	// prepared message instead of a new conventional commit
+	if gitService != nil {
+		if state, err := gitService.State(context.Background()); err == nil && state.InProgress() {
+			m.State = state
+			m.Step = 1
+			m.MessageInput.CharLimit = 0
+			m.MessageInput.SetValue(firstLine(state.Message))
+			m.MessageInput.Focus()
+		}
+	}
+
+	return m
+}
+
+// targetItems converts commits into fixup target list items
+func targetItems(commits []git.CommitInfo) []list.Item {
+	items := make([]list.Item, 0, len(commits))
+	for _, c := range commits {
+		// Fixups of fixups are folded by their own target, so they are not offered
+		if git.IsFixupSubject(c.Subject) {
+			continue
+		}
+		items = append(items, common.CommitItem{Commit: c})
 	}
+	return items
+}
+
+// message returns the conventional commit message entered so far
+func (m Model) message() git.CommitMessage {
+	msg := m.Preset
+	msg.Type = m.SelectedType
+	msg.Description = m.CommitMessage
+	return msg
+}
+
+// firstLine returns the subject line of a commit message
+func firstLine(message string) string {
+	subject, _, _ := strings.Cut(message, "\n")
+	return subject
 }
 
 // Init initializes the model
@@ -1,10 +1,15 @@
 package commit
 
 import (
+	"context"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui/common"
 )
 
 // Update handles events and updates the model
@@ -13,7 +18,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// Skip processing if quitting
 	if m.Quitting {
-		return m, tea.Quit
+		return m, m.quit()
 	}
 
 	switch msg := msg.(type) {
@@ -22,11 +27,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.Err = msg.err
 		return m, nil
 
-	case commitSuccessMsg:
+	case CommittedMsg:
 		// Handle successful commit
 		// Already in step 2 (confirmation)
 		return m, nil
 
+	case fixupTargetsMsg:
+		m.TargetList.SetItems(targetItems(msg.commits))
+		m.TargetList.Select(0)
+		return m, nil
+
+	case autosquashMsg:
+		if msg.state.InProgress() {
+			m.Notice = "Autosquash stopped on a conflict. Run 'go-git-tui rebase' to resolve and continue."
+		} else {
+			m.Notice = "Fixup commits were squashed into their targets."
+		}
+		if m.FixupMode {
+			return m, m.loadFixupTargets()
+		}
+		return m, nil
+
+	case operationAbortedMsg:
+		m.Aborted = true
+		m.Step = 2
+		return m, nil
+
 	case tea.WindowSizeMsg:
 		// Store dimensions and mark as ready
 		m.Width, m.Height = msg.Width, msg.Height
@@ -35,6 +61,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Set list size with proper margins for compact layout
 		listHeight := msg.Height - 7 // Reserve space for title, help, and margins
 		m.TypeList.SetSize(msg.Width-4, listHeight)
+		m.TargetList.SetSize(msg.Width-4, listHeight)
 
 		// Adjust input field width based on window size
 		m.MessageInput.Width = msg.Width - 10
@@ -42,32 +69,92 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.Err != nil {
+			if msg.String() != "esc" {
+				return m, m.quit()
+			}
+
+			// Go back to the message to commit again once the problem is fixed
+			m.Err = nil
+			if m.Step == 2 {
+				m.Step = 1
+				m.MessageInput.Focus()
+				return m, textinput.Blink
+			}
+			return m, nil
+		}
+
 		switch msg.String() {
 		case "ctrl+c", "q":
-			m.Quitting = true
-			return m, tea.Quit
+			return m, m.quit()
 
 		// w/s navigation for type selection
 		case "w":
 			if m.Step == 0 {
-				currentIndex := m.TypeList.Index()
-				if currentIndex > 0 {
-					m.TypeList.Select(currentIndex - 1)
+				l := m.activeList()
+				if currentIndex := l.Index(); currentIndex > 0 {
+					l.Select(currentIndex - 1)
 				}
 				return m, nil
 			}
 
 		case "s":
 			if m.Step == 0 {
-				currentIndex := m.TypeList.Index()
-				if currentIndex < len(m.TypeList.Items())-1 {
-					m.TypeList.Select(currentIndex + 1)
+				l := m.activeList()
+				if currentIndex := l.Index(); currentIndex < len(l.Items())-1 {
+					l.Select(currentIndex + 1)
+				}
+				return m, nil
+			}
+
+		// Toggle between commit types and fixup targets
+		case "f":
+			if m.Step == 0 && !m.State.InProgress() {
+				m.FixupMode = !m.FixupMode
+				m.Notice = ""
+				if m.FixupMode {
+					return m, m.loadFixupTargets()
+				}
+				return m, nil
+			}
+
+		// Autosquash pending fixups before pushing
+		case "S":
+			if (m.Step == 0 || m.Step == 2) && !m.State.InProgress() {
+				return m, m.autosquash()
+			}
+
+		// Create an amend! commit, which needs a replacement message for the target
+		case "e":
+			if m.Step == 0 && m.FixupMode {
+				if i, ok := m.TargetList.SelectedItem().(common.CommitItem); ok {
+					target := i.Commit
+					m.Target = &target
+					m.FixupKind = git.AmendCommit
+					m.Step = 1
+					m.MessageInput.CharLimit = 0
+					m.MessageInput.SetValue(target.Subject)
+					m.MessageInput.CursorEnd()
+					m.MessageInput.Focus()
+					return m, textinput.Blink
 				}
 				return m, nil
 			}
 
 		// Use tab to select commit type and proceed to message input
 		case "tab":
+			if m.Step == 0 && m.FixupMode {
+				// A fixup! commit reuses the target's message, so it is created right away
+				if i, ok := m.TargetList.SelectedItem().(common.CommitItem); ok {
+					target := i.Commit
+					m.Target = &target
+					m.FixupKind = git.FixupCommit
+					m.CommitMessage = ""
+					m.Step = 2
+					return m, m.performCommit()
+				}
+				return m, nil
+			}
 			if m.Step == 0 {
 				currentIndex := m.TypeList.Index()
 				if currentIndex >= 0 && currentIndex < len(m.TypeList.Items()) {
@@ -83,6 +170,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
+		case "ctrl+x":
+			if m.State.InProgress() && m.Step == 1 {
+				return m, m.abortOperation()
+			}
+
 		case "enter":
 			if m.Step == 1 {
 				// Get the commit message
@@ -90,6 +182,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if m.CommitMessage == "" {
 					return m, nil // Don't proceed with empty message
 				}
+				if m.Target == nil && !m.State.InProgress() {
+					if err := m.message().Validate(); err != nil {
+						m.Notice = err.Error()
+						return m, nil
+					}
+				}
+				m.Notice = ""
 				m.Step = 2
 
 				// Run the commit operation asynchronously
@@ -97,22 +196,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			} else if m.Step == 2 {
 				// Exit after confirmation
-				return m, tea.Quit
+				return m, m.quit()
 			}
 
 		case "esc":
-			if m.Step == 1 {
-				// Go back to type selection
+			if m.Step == 1 && !m.State.InProgress() {
+				// Go back to type or fixup target selection
 				m.Step = 0
+				m.Target = nil
 				m.MessageInput.Blur()
 				return m, nil
 			}
+			if m.Step == 0 && m.FixupMode {
+				m.FixupMode = false
+				return m, nil
+			}
 		}
 
 		// Handle special keys in step 2 (confirmation)
 		if m.Step == 2 {
 			switch msg.String() {
 			case "a":
+				// The operation has concluded, and fixup! commits have no message to amend
+				if m.State.InProgress() || (m.Target != nil && m.FixupKind == git.FixupCommit) {
+					return m, m.quit()
+				}
+
 				// Amend commit - go back to message input with current message
 				m.Step = 1
 				m.MessageInput.SetValue(m.CommitMessage)
@@ -121,13 +230,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				
 			default:
 				// Any other key exits
-				return m, tea.Quit
+				return m, m.quit()
 			}
 		}
 	}
 
 	// Handle updates for the current step components
-	if m.Step == 0 {
+	if m.Step == 0 && m.FixupMode {
+		m.TargetList, cmd = m.TargetList.Update(msg)
+		return m, cmd
+	} else if m.Step == 0 {
 		m.TypeList, cmd = m.TypeList.Update(msg)
 		return m, cmd
 	} else if m.Step == 1 {
@@ -138,24 +250,118 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// quit ends the program, or hands control back to the parent view when embedded
+func (m *Model) quit() tea.Cmd {
+	if m.Embedded {
+		return func() tea.Msg { return ClosedMsg{} }
+	}
+	m.Quitting = true
+	return tea.Quit
+}
+
+// gitService returns the git service the model was created with, or opens the repository
+// in the working directory when that failed
+func (m Model) gitService() (*git.DefaultGitService, error) {
+	if m.GitService != nil {
+		return m.GitService, nil
+	}
+	return git.NewGitService()
+}
+
 // performCommit handles the git commit operation
 func (m Model) performCommit() tea.Cmd {
 	return func() tea.Msg {
 		// Use git service for the commit operation
-		gitService, err := git.NewGitService()
+		gitService, err := m.gitService()
 		if err != nil {
 			return errMsg{err}
 		}
 
-		err = gitService.Commit(m.SelectedType, m.CommitMessage)
+		if m.State.InProgress() {
+			err = gitService.ContinueOperation(context.Background(), m.operationMessage())
+		} else if m.Target != nil {
+			err = gitService.CommitFixup(context.Background(), m.FixupKind, *m.Target, m.CommitMessage)
+		} else {
+			msg := m.message()
+			err = gitService.Commit(context.Background(), msg.Prefix(), msg.Text())
+		}
 		if err != nil {
 			return errMsg{err}
 		}
 
-		return commitSuccessMsg{}
+		return CommittedMsg{}
+	}
+}
+
+// activeList returns the list shown in step 0
+func (m *Model) activeList() *list.Model {
+	if m.FixupMode {
+		return &m.TargetList
+	}
+	return &m.TypeList
+}
+
+// loadFixupTargets fetches the commits that are not on upstream yet
+func (m Model) loadFixupTargets() tea.Cmd {
+	return func() tea.Msg {
+		gitService, err := m.gitService()
+		if err != nil {
+			return errMsg{err}
+		}
+
+		commits, err := gitService.UnpushedCommits(context.Background(), fixupTargetLimit)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return fixupTargetsMsg{commits: commits}
+	}
+}
+
+// autosquash folds fixup! and amend! commits into their targets
+func (m Model) autosquash() tea.Cmd {
+	return func() tea.Msg {
+		gitService, err := m.gitService()
+		if err != nil {
+			return errMsg{err}
+		}
+
+		state, err := gitService.Autosquash(context.Background())
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return autosquashMsg{state: state}
+	}
+}
+
+// operationMessage replaces the subject of the prepared message with the edited one
+func (m Model) operationMessage() string {
+	_, body, found := strings.Cut(m.State.Message, "\n")
+	if !found {
+		return m.CommitMessage
+	}
+	return m.CommitMessage + "\n" + body
+}
+
+// abortOperation abandons the in-progress merge, rebase, cherry-pick or revert
+func (m Model) abortOperation() tea.Cmd {
+	return func() tea.Msg {
+		gitService, err := m.gitService()
+		if err != nil {
+			return errMsg{err}
+		}
+
+		if err := gitService.AbortOperation(context.Background()); err != nil {
+			return errMsg{err}
+		}
+
+		return operationAbortedMsg{}
 	}
 }
 
 // Custom message types
 type errMsg struct{ err error }
-type commitSuccessMsg struct{}
+type operationAbortedMsg struct{}
+type fixupTargetsMsg struct{ commits []git.CommitInfo }
+type autosquashMsg struct{ state *git.RepoState }
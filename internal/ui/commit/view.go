@@ -4,13 +4,15 @@ import (
 	"fmt"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/LaansDole/go-git-tui/internal/ui/common"
 )
 
 // View renders the current state of the model
 func (m Model) View() string {
 	// Show error if any
 	if m.Err != nil {
-		return m.StyleConfig.ErrorStyle.Render(fmt.Sprintf("Error: %v\nPress any key to exit", m.Err))
+		return m.errorView()
 	}
 
 	if m.Quitting {
@@ -19,10 +21,20 @@ func (m Model) View() string {
 
 	// Different help text based on current step
 	var helpText string
-	if m.Step == 0 {
-		helpText = m.StyleConfig.HelpStyle.Render("w/s: Navigate Types • Tab: Select Type • q: Quit")
+	if m.State.InProgress() {
+		if m.Step == 1 {
+			helpText = m.StyleConfig.HelpStyle.Render("Enter: Continue " + m.State.Operation.String() + " • Ctrl+X: Abort • Ctrl+C: Quit")
+		} else {
+			helpText = m.StyleConfig.HelpStyle.Render("Enter/q: Exit")
+		}
+	} else if m.Step == 0 && m.FixupMode {
+		helpText = m.StyleConfig.HelpStyle.Render("w/s: Navigate Commits • Tab: fixup! • e: amend! • S: Autosquash • f/Esc: Commit Types • q: Quit")
+	} else if m.Step == 0 {
+		helpText = m.StyleConfig.HelpStyle.Render("w/s: Navigate Types • Tab: Select Type • f: Fixup of… • S: Autosquash • q: Quit")
 	} else if m.Step == 1 {
 		helpText = m.StyleConfig.HelpStyle.Render("Enter: Commit • Esc: Back • q: Quit")
+	} else if m.Target != nil {
+		helpText = m.StyleConfig.HelpStyle.Render("S: Autosquash • Enter/q: Exit")
 	} else {
 		helpText = m.StyleConfig.HelpStyle.Render("a: Amend Commit • Enter/q: Exit")
 	}
@@ -31,14 +43,34 @@ func (m Model) View() string {
 	switch m.Step {
 	case 0:
 		// Show commit type selection list in a compact format
-		content = lipgloss.JoinVertical(lipgloss.Left, m.TypeList.View())
+		if m.FixupMode {
+			content = m.TargetList.View()
+			if len(m.TargetList.Items()) == 0 {
+				content = lipgloss.JoinVertical(lipgloss.Left,
+					m.StyleConfig.SubTitleStyle.Render(m.TargetList.Title),
+					m.StyleConfig.InfoStyle.Render("No commits to fix up: everything is already on upstream"),
+				)
+			}
+		} else {
+			content = lipgloss.JoinVertical(lipgloss.Left, m.TypeList.View())
+		}
 
 	case 1:
 		// Show commit message input with compact styling
 		messageTitle := m.StyleConfig.SubTitleStyle.Render("Enter commit message:")
-		messageType := m.StyleConfig.InfoStyle.Render(fmt.Sprintf("Type: %s", m.SelectedType))
+		messageType := m.StyleConfig.InfoStyle.Render(fmt.Sprintf("Type: %s", m.message().Prefix()))
+		if m.State.InProgress() {
+			messageTitle = m.StyleConfig.SubTitleStyle.Render("Commit message prepared by git:")
+			messageType = m.StyleConfig.InfoStyle.Render("Conventional type is skipped while an operation is in progress")
+		} else if m.Target != nil {
+			messageTitle = m.StyleConfig.SubTitleStyle.Render("Replacement message for the amended commit:")
+			messageType = m.StyleConfig.InfoStyle.Render(fmt.Sprintf("amend! %s %s", m.Target.ShortHash, m.Target.Subject))
+		}
 		messageInput := m.StyleConfig.InputStyle.Render(m.MessageInput.View())
 		instructions := m.StyleConfig.InfoStyle.Render("Press Enter to commit or Esc to go back")
+		if m.State.InProgress() {
+			instructions = m.StyleConfig.InfoStyle.Render("Press Enter to continue or Ctrl+X to abort")
+		}
 
 		content = lipgloss.JoinVertical(
 			lipgloss.Left,
@@ -52,10 +84,28 @@ func (m Model) View() string {
 		// Show confirmation with compact styling
 		successTitle := m.StyleConfig.SubTitleStyle.Render("Commit successfully created:")
 		commitDetails := m.StyleConfig.SuccessStyle.Render(
-			fmt.Sprintf("Type: %s\nMessage: %s", m.SelectedType, m.CommitMessage),
+			fmt.Sprintf("Type: %s\nMessage: %s", m.message().Prefix(), m.CommitMessage),
 		)
 		exitInstructions := m.StyleConfig.InfoStyle.Render("Press any key to exit or 'a' to amend commit message")
 
+		if m.Target != nil {
+			commitDetails = m.StyleConfig.SuccessStyle.Render(
+				fmt.Sprintf("%s %s\nTarget: %s", m.FixupKind, m.Target.Subject, m.Target.ShortHash),
+			)
+			exitInstructions = m.StyleConfig.InfoStyle.Render("Press 'S' to autosquash before pushing or any key to exit")
+		}
+
+		if m.State.InProgress() {
+			operation := m.State.Operation.String()
+			successTitle = m.StyleConfig.SubTitleStyle.Render(fmt.Sprintf("Continued %s:", operation))
+			commitDetails = m.StyleConfig.SuccessStyle.Render(m.CommitMessage)
+			if m.Aborted {
+				successTitle = m.StyleConfig.SubTitleStyle.Render(fmt.Sprintf("Aborted %s", operation))
+				commitDetails = m.StyleConfig.InfoStyle.Render("The repository was restored to its previous state")
+			}
+			exitInstructions = m.StyleConfig.InfoStyle.Render("Press any key to exit")
+		}
+
 		content = lipgloss.JoinVertical(
 			lipgloss.Left,
 			successTitle,
@@ -67,8 +117,17 @@ func (m Model) View() string {
 	// Combine all elements with consistent padding and spacing
 	verticalLayout := []string{}
 
+	// Show a banner while a merge, rebase, cherry-pick or revert is in progress
+	if m.State.InProgress() {
+		verticalLayout = append(verticalLayout, m.StyleConfig.StatusBar.Render(m.State.Summary()))
+	}
+
 	// Add content and help text
-	verticalLayout = append(verticalLayout, content, helpText)
+	verticalLayout = append(verticalLayout, content)
+	if m.Notice != "" {
+		verticalLayout = append(verticalLayout, m.StyleConfig.InfoStyle.Render(m.Notice))
+	}
+	verticalLayout = append(verticalLayout, helpText)
 
 	return m.StyleConfig.AppStyle.Render(
 		lipgloss.JoinVertical(
@@ -77,3 +136,19 @@ func (m Model) View() string {
 		),
 	)
 }
+
+// errorView explains the failed git operation and how to fix it
+func (m Model) errorView() string {
+	help := common.DescribeError(m.Err)
+
+	lines := []string{m.StyleConfig.ErrorStyle.Render(help.Message)}
+	if help.Hint != "" {
+		lines = append(lines, m.StyleConfig.InfoStyle.Render(help.Hint))
+	}
+	if help.Detail != "" {
+		lines = append(lines, "", m.StyleConfig.HelpStyle.Render(help.Detail))
+	}
+	lines = append(lines, "", m.StyleConfig.HelpStyle.Render("Esc: Back • any other key: Exit"))
+
+	return m.StyleConfig.AppStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
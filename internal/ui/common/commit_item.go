@@ -0,0 +1,57 @@
+package common
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+)
+
+// CommitItem represents a commit in a list
+type CommitItem struct {
+	Commit     git.CommitInfo
+	IsSelected bool
+}
+
+// Title implements the list.Item interface
+func (i CommitItem) Title() string {
+	prefix := "  "
+	if i.IsSelected {
+		prefix = "✓ "
+	}
+	return prefix + i.Commit.ShortHash + " " + TruncateText(i.Commit.Subject, 72, "...")
+}
+
+// Description implements the list.Item interface
+func (i CommitItem) Description() string {
+	return fmt.Sprintf("%s, %s", i.Commit.Author, RelativeTime(i.Commit.When, time.Now()))
+}
+
+// FilterValue implements the list.Item interface
+func (i CommitItem) FilterValue() string { return i.Commit.Subject }
+
+// RelativeTime formats t relative to now, e.g. "3 days ago"
+func RelativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return fmt.Sprintf("1 %s ago", unit)
+		}
+		return fmt.Sprintf("%d %ss ago", n, unit)
+	}
+
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return plural(int(d.Minutes()), "minute")
+	case d < 24*time.Hour:
+		return plural(int(d.Hours()), "hour")
+	case d < 30*24*time.Hour:
+		return plural(int(d.Hours()/24), "day")
+	case d < 365*24*time.Hour:
+		return plural(int(d.Hours()/(24*30)), "month")
+	default:
+		return plural(int(d.Hours()/(24*365)), "year")
+	}
+}
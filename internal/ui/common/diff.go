@@ -0,0 +1,51 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+)
+
+// RenderDiff colors the added and deleted lines of a diff, truncating lines to width
+func RenderDiff(diff git.DiffResult, width int, styles StyleConfig) string {
+	if diff.IsBinary {
+		return "Binary file differences not shown"
+	}
+
+	var sb strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(diff.Content, "\n"), "\n") {
+		// Tabs are expanded so truncation matches the rendered width
+		display := TruncateText(strings.ReplaceAll(line, "\t", "    "), max(width-1, 20), "...")
+		switch {
+		case strings.HasPrefix(line, "+"):
+			sb.WriteString(styles.AddedStyle.Render(display))
+		case strings.HasPrefix(line, "-"):
+			sb.WriteString(styles.DeletedStyle.Render(display))
+		default:
+			sb.WriteString(display)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// RenderCommitDiff renders a commit header followed by the diff of every file it changed
+func RenderCommitDiff(commit git.CommitInfo, diffs []git.DiffResult, width int, styles StyleConfig) string {
+	var sb strings.Builder
+	sb.WriteString(styles.TitleStyle.Render(commit.ShortHash+" "+commit.Subject) + "\n")
+	sb.WriteString(styles.StatusBar.Render(fmt.Sprintf("%s <%s> • %s",
+		commit.Author, commit.Email, commit.When.Format("2006-01-02 15:04"))) + "\n")
+
+	if body := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(commit.Message), commit.Subject)); body != "" {
+		sb.WriteString("\n" + body + "\n")
+	}
+
+	for _, diff := range diffs {
+		sb.WriteString("\n" + styles.InfoStyle.Render(fmt.Sprintf("%s  +%d -%d",
+			diff.Path, diff.Stats.Added, diff.Stats.Deleted)) + "\n")
+		sb.WriteString(RenderDiff(diff, width, styles))
+	}
+
+	return sb.String()
+}
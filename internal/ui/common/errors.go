@@ -0,0 +1,83 @@
+package common
+
+import (
+	"errors"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+)
+
+// ErrorHelp explains a failed git operation to the user
+type ErrorHelp struct {
+	Message string // What went wrong
+	Hint    string // How to fix it, empty when there is nothing to suggest
+	Detail  string // The full error, including git's output
+}
+
+// errorHelp maps the errors of internal/git to their message and hint, most specific first
+var errorHelp = []struct {
+	err  error
+	help ErrorHelp
+}{
+	{git.ErrNotRepository, ErrorHelp{
+		Message: "This is not a git repository",
+		Hint:    "Run go-git-tui inside a repository, or create one with 'git init'.",
+	}},
+	{git.ErrCorruptRepository, ErrorHelp{
+		Message: "The git repository is corrupt",
+		Hint:    "Check it with 'git fsck'. A damaged index can be rebuilt with 'rm .git/index && git reset'.",
+	}},
+	{git.ErrIdentityMissing, ErrorHelp{
+		Message: "Your git identity is not configured",
+		Hint:    "Set it with 'git config --global user.name \"Your Name\"' and 'git config --global user.email you@example.com'.",
+	}},
+	{git.ErrHookRejected, ErrorHelp{
+		Message: "A git hook rejected the commit",
+		Hint:    "Fix the problems the hook reported and try again.",
+	}},
+	{git.ErrUnmergedPaths, ErrorHelp{
+		Message: "There are unresolved merge conflicts",
+		Hint:    "Resolve them with 'go-git-tui resolve' before committing.",
+	}},
+	{git.ErrNothingStaged, ErrorHelp{
+		Message: "Nothing is staged to commit",
+		Hint:    "Stage changes with 'go-git-tui add' first.",
+	}},
+	{git.ErrOperationInProgress, ErrorHelp{
+		Message: "Another git operation is in progress",
+		Hint:    "Conclude it with 'go-git-tui commit' or abort it from there.",
+	}},
+	{git.ErrNoOperation, ErrorHelp{
+		Message: "There is no operation in progress",
+		Hint:    "The merge, rebase, cherry-pick or revert has already been concluded.",
+	}},
+	{git.ErrNoUpstream, ErrorHelp{
+		Message: "The current branch has no upstream",
+		Hint:    "Set one with 'git branch --set-upstream-to=<remote>/<branch>'.",
+	}},
+	{git.ErrFileNotFound, ErrorHelp{
+		Message: "The file no longer has changes",
+		Hint:    "It was committed, staged or reverted outside go-git-tui.",
+	}},
+	{git.ErrUnresolvedHunks, ErrorHelp{
+		Message: "The file still has unresolved conflicts",
+		Hint:    "Choose a side for every hunk before saving.",
+	}},
+}
+
+// DescribeError returns the message and remediation hint for an error from internal/git.
+// Unknown errors are described by their own message without a hint.
+func DescribeError(err error) ErrorHelp {
+	if err == nil {
+		return ErrorHelp{}
+	}
+
+	for _, known := range errorHelp {
+		if errors.Is(err, known.err) {
+			help := known.help
+			help.Detail = err.Error()
+			return help
+		}
+	}
+
+	return ErrorHelp{Message: err.Error()}
+}
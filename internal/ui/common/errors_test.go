@@ -0,0 +1,54 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+)
+
+func TestDescribeError(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantMessage string
+		wantHint    bool
+	}{
+		{
+			name:        "GIVEN a wrapped sentinel THEN its message and hint are returned",
+			err:         fmt.Errorf("%w: %w", git.ErrNothingStaged, errors.New("exit status 1")),
+			wantMessage: "Nothing is staged to commit",
+			wantHint:    true,
+		},
+		{
+			name:        "GIVEN a hook rejection THEN it is described as such",
+			err:         fmt.Errorf("%w: %w", git.ErrHookRejected, errors.New("exit status 1")),
+			wantMessage: "A git hook rejected the commit",
+			wantHint:    true,
+		},
+		{
+			name:        "GIVEN an operation in progress THEN the typed error is matched",
+			err:         &git.InProgressError{Action: "start a rebase", Operation: git.OperationMerge},
+			wantMessage: "Another git operation is in progress",
+			wantHint:    true,
+		},
+		{
+			name:        "GIVEN an unknown error THEN its own message is used without a hint",
+			err:         errors.New("disk full"),
+			wantMessage: "disk full",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			help := DescribeError(tc.err)
+			assert.Equal(t, tc.wantMessage, help.Message)
+			assert.Equal(t, tc.wantHint, help.Hint != "")
+		})
+	}
+
+	assert.Equal(t, ErrorHelp{}, DescribeError(nil))
+}
@@ -0,0 +1,179 @@
+package common
+
+import (
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TokenKind classifies a piece of source text for highlighting
+type TokenKind int
+
+const (
+	TokenPlain TokenKind = iota
+	TokenKeyword
+	TokenString
+	TokenNumber
+	TokenComment
+)
+
+// Token is a run of source text of a single kind
+type Token struct {
+	Kind TokenKind
+	Text string
+}
+
+// language describes just enough of a language to highlight single lines
+type language struct {
+	comments []string
+	quotes   string
+	keywords map[string]bool
+}
+
+func words(s string) map[string]bool {
+	m := map[string]bool{}
+	for _, w := range strings.Fields(s) {
+		m[w] = true
+	}
+	return m
+}
+
+var (
+	goLang = language{
+		comments: []string{"//"},
+		quotes:   "\"'`",
+		keywords: words("break case chan const continue default defer else fallthrough for func go goto if import interface map package range return select struct switch type var nil true false"),
+	}
+	jsLang = language{
+		comments: []string{"//"},
+		quotes:   "\"'`",
+		keywords: words("async await break case catch class const continue default delete do else export extends false finally for from function if import in instanceof interface let new null return switch this throw true try type typeof undefined var void while yield"),
+	}
+	pyLang = language{
+		comments: []string{"#"},
+		quotes:   "\"'",
+		keywords: words("and as assert async await break class continue def del elif else except False finally for from global if import in is lambda None nonlocal not or pass raise return True try while with yield"),
+	}
+	shLang = language{
+		comments: []string{"#"},
+		quotes:   "\"'",
+		keywords: words("case do done elif else esac export fi for function if in local return then until while"),
+	}
+
+	// languages maps file extensions to their language
+	languages = map[string]language{
+		".go":   goLang,
+		".js":   jsLang,
+		".jsx":  jsLang,
+		".ts":   jsLang,
+		".tsx":  jsLang,
+		".py":   pyLang,
+		".sh":   shLang,
+		".bash": shLang,
+		".zsh":  shLang,
+	}
+)
+
+// Tokenize splits a single line of source into highlight tokens based on the file extension.
+// Files in unknown languages produce a single plain token.
+func Tokenize(path, line string) []Token {
+	lang, ok := languages[strings.ToLower(filepath.Ext(path))]
+	if !ok || line == "" {
+		return []Token{{Kind: TokenPlain, Text: line}}
+	}
+
+	var tokens []Token
+	emit := func(kind TokenKind, text string) {
+		// Merge adjacent plain text to keep the rendered output small
+		if kind == TokenPlain && len(tokens) > 0 && tokens[len(tokens)-1].Kind == TokenPlain {
+			tokens[len(tokens)-1].Text += text
+			return
+		}
+		tokens = append(tokens, Token{Kind: kind, Text: text})
+	}
+
+	runes := []rune(line)
+	for i := 0; i < len(runes); {
+		rest := string(runes[i:])
+		r := runes[i]
+
+		if isComment(lang, rest) {
+			emit(TokenComment, rest)
+			break
+		}
+
+		switch {
+		case strings.ContainsRune(lang.quotes, r):
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				if runes[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(runes))
+			emit(TokenString, string(runes[i:end]))
+			i = end
+
+		case unicode.IsLetter(r) || r == '_':
+			end := i + 1
+			for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || runes[end] == '_') {
+				end++
+			}
+			word := string(runes[i:end])
+			if lang.keywords[word] {
+				emit(TokenKeyword, word)
+			} else {
+				emit(TokenPlain, word)
+			}
+			i = end
+
+		case unicode.IsDigit(r):
+			end := i + 1
+			for end < len(runes) && (unicode.IsDigit(runes[end]) || unicode.IsLetter(runes[end]) || runes[end] == '.') {
+				end++
+			}
+			emit(TokenNumber, string(runes[i:end]))
+			i = end
+
+		default:
+			emit(TokenPlain, string(r))
+			i++
+		}
+	}
+
+	return tokens
+}
+
+// isComment reports whether text starts with one of the language's line comment markers
+func isComment(lang language, text string) bool {
+	for _, prefix := range lang.comments {
+		if strings.HasPrefix(text, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenStyles are the colors used for each token kind
+var tokenStyles = map[TokenKind]lipgloss.Style{
+	TokenKeyword: lipgloss.NewStyle().Foreground(lipgloss.Color("13")), // Magenta
+	TokenString:  lipgloss.NewStyle().Foreground(lipgloss.Color("10")), // Green
+	TokenNumber:  lipgloss.NewStyle().Foreground(lipgloss.Color("11")), // Yellow
+	TokenComment: lipgloss.NewStyle().Foreground(lipgloss.Color("8")),  // Gray
+}
+
+// HighlightLine renders a line of source with colors for keywords, strings, numbers and comments
+func HighlightLine(path, line string) string {
+	var sb strings.Builder
+	for _, token := range Tokenize(path, line) {
+		if style, ok := tokenStyles[token.Kind]; ok {
+			sb.WriteString(style.Render(token.Text))
+		} else {
+			sb.WriteString(token.Text)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,55 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestTokenize tests the single-line syntax tokenizer
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		line string
+		want []Token
+	}{
+		{
+			name: "GIVEN a Go line with a keyword, string and comment THEN each is classified",
+			path: "main.go",
+			line: `return "a\"b" // done`,
+			want: []Token{
+				{TokenKeyword, "return"},
+				{TokenPlain, " "},
+				{TokenString, `"a\"b"`},
+				{TokenPlain, " "},
+				{TokenComment, "// done"},
+			},
+		},
+		{
+			name: "GIVEN a Python line with a number THEN the number is classified",
+			path: "script.py",
+			line: "x = 42 # answer",
+			want: []Token{
+				{TokenPlain, "x = "},
+				{TokenNumber, "42"},
+				{TokenPlain, " "},
+				{TokenComment, "# answer"},
+			},
+		},
+		{
+			name: "GIVEN an unknown extension THEN the line is plain",
+			path: "notes.txt",
+			line: "return 42",
+			want: []Token{{TokenPlain, "return 42"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Tokenize(tc.path, tc.line)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Tokenize() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package common
 
 import (
 	"testing"
+	"time"
 )
 
 // TestTruncateText tests the text truncation helper function
@@ -113,3 +114,44 @@ func TestTruncatePath(t *testing.T) {
 		})
 	}
 }
+
+// TestRelativeTime tests the relative date formatting helper
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		when time.Time
+		want string
+	}{
+		{
+			name: "GIVEN a time seconds ago THEN returns just now",
+			when: now.Add(-30 * time.Second),
+			want: "just now",
+		},
+		{
+			name: "GIVEN a time one hour ago THEN returns singular unit",
+			when: now.Add(-time.Hour),
+			want: "1 hour ago",
+		},
+		{
+			name: "GIVEN a time three days ago THEN returns plural unit",
+			when: now.Add(-72 * time.Hour),
+			want: "3 days ago",
+		},
+		{
+			name: "GIVEN a time two years ago THEN returns years",
+			when: now.AddDate(-2, 0, 0),
+			want: "2 years ago",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := RelativeTime(tc.when, now)
+			if got != tc.want {
+				t.Errorf("RelativeTime() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
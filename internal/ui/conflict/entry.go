@@ -0,0 +1,17 @@
+package conflict
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Run initializes and runs the conflict resolution UI component in a fullscreen terminal view
+func Run() error {
+	p := tea.NewProgram(
+		New(),
+		tea.WithAltScreen(),
+		tea.WithMouseCellMotion(),
+	)
+
+	_, err := p.Run()
+	return err
+}
@@ -0,0 +1,103 @@
+package conflict
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui/common"
+)
+
+// FileItem represents a conflicted file in the list
+type FileItem struct {
+	Status   string
+	Path     string
+	Resolved bool
+}
+
+// Title implements the list.Item interface
+func (i FileItem) Title() string {
+	prefix := "  "
+	if i.Resolved {
+		prefix = "✓ "
+	}
+	status := lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Render(fmt.Sprintf("[%s]", i.Status))
+	return prefix + status + " " + common.TruncatePath(i.Path, 40, 20, 17)
+}
+
+// Description implements the list.Item interface
+func (i FileItem) Description() string { return "" }
+
+// FilterValue implements the list.Item interface
+func (i FileItem) FilterValue() string { return i.Path }
+
+// Custom message types
+type filesLoadedMsg struct{ files []git.GitFile }
+type conflictLoadedMsg struct{ file *git.ConflictFile }
+type resolvedMsg struct{ path string }
+type errMsg struct{ err error }
+
+// ClosedMsg is emitted instead of quitting when the model is embedded in another view
+type ClosedMsg struct{}
+
+// Model represents the conflict resolution UI state
+type Model struct {
+	Files    list.Model
+	Hunk     viewport.Model
+	Current  *git.ConflictFile
+	HunkIdx  int
+	Message  string
+	Err      error
+	Width    int
+	Height   int
+	Ready    bool
+	Done     bool // Set once no conflicted files remain
+	Quitting bool
+	Embedded bool   // Emit ClosedMsg on quit instead of ending the program
+	Path     string // File to show once the list is loaded, empty shows the first one
+
+	GitService  *git.DefaultGitService
+	StyleConfig common.StyleConfig
+}
+
+// New initializes a new conflict resolution model
+func New() *Model {
+	gitService, err := git.NewGitService()
+	m := NewWithService(gitService)
+	if err != nil {
+		m.Err = err
+	}
+	return m
+}
+
+// NewWithService initializes a conflict resolution model on an existing git service
+func NewWithService(gitService *git.DefaultGitService) *Model {
+	delegate := list.NewDefaultDelegate()
+	delegate.ShowDescription = false
+	delegate.SetSpacing(0)
+	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
+		Foreground(lipgloss.Color("170")).
+		Margin(0, 0)
+
+	l := list.New([]list.Item{}, delegate, 0, 0)
+	l.Title = "Conflicted Files"
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.SetShowHelp(false)
+
+	return &Model{
+		Files:       l,
+		Hunk:        viewport.New(0, 0),
+		GitService:  gitService,
+		StyleConfig: common.NewStyleConfig(),
+	}
+}
+
+// Init loads the conflicted files - implements tea.Model interface
+func (m *Model) Init() tea.Cmd {
+	return m.loadFiles()
+}
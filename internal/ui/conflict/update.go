@@ -0,0 +1,251 @@
+package conflict
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui/common"
+)
+
+// Update handles events and updates the model - implements tea.Model interface
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.Width, m.Height = msg.Width, msg.Height
+		m.Ready = true
+
+		h, v := m.StyleConfig.AppStyle.GetFrameSize()
+		available := msg.Width - h
+		listWidth := available * common.ListRatio / 100
+		m.Files.SetSize(listWidth, msg.Height-v-3)
+		m.Hunk.Width = available - listWidth - common.DividerWidth - 1
+		m.Hunk.Height = msg.Height - v - 5 // Reserve space for title, hunk header and help
+		m.renderHunk()
+		return m, nil
+
+	case filesLoadedMsg:
+		items := []list.Item{}
+		for _, f := range msg.files {
+			if git.IsConflicted(f.Status) {
+				items = append(items, FileItem{Status: f.Status, Path: f.Path})
+			}
+		}
+		m.Files.SetItems(items)
+		if len(items) == 0 {
+			m.Done = true
+			m.Current = nil
+			return m, nil
+		}
+		for i, item := range items {
+			if item.(FileItem).Path == m.Path {
+				m.Files.Select(i)
+			}
+		}
+		m.Path = ""
+		return m, m.loadSelected()
+
+	case conflictLoadedMsg:
+		m.Current = msg.file
+		m.HunkIdx = 0
+		m.renderHunk()
+		return m, nil
+
+	case resolvedMsg:
+		m.Message = fmt.Sprintf("Resolved and staged %s", msg.path)
+		m.Current = nil
+		return m, m.loadFiles()
+
+	case errMsg:
+		m.Message = fmt.Sprintf("Error: %v", msg.err)
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKeys(msg)
+	}
+
+	return m, nil
+}
+
+// handleKeys handles keyboard input for file navigation and hunk resolution
+func (m *Model) handleKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.Err != nil {
+		return m, m.quit()
+	}
+
+	switch msg.String() {
+	case "ctrl+c":
+		m.Quitting = true
+		return m, tea.Quit
+
+	case "q", "esc":
+		return m, m.quit()
+
+	case "w", "s":
+		if m.Done {
+			return m, nil
+		}
+		prev := m.Files.Index()
+		if msg.String() == "w" {
+			m.Files.CursorUp()
+		} else {
+			m.Files.CursorDown()
+		}
+		if prev != m.Files.Index() {
+			return m, m.loadSelected()
+		}
+		return m, nil
+
+	case "n":
+		if m.Current != nil && m.HunkIdx < len(m.Current.Hunks)-1 {
+			m.HunkIdx++
+			m.renderHunk()
+		}
+		return m, nil
+
+	case "p":
+		if m.HunkIdx > 0 {
+			m.HunkIdx--
+			m.renderHunk()
+		}
+		return m, nil
+
+	case "o":
+		return m, m.resolve(git.ResolveOurs)
+	case "t":
+		return m, m.resolve(git.ResolveTheirs)
+	case "b":
+		return m, m.resolve(git.ResolveBoth)
+	case "a":
+		if hunk := m.currentHunk(); hunk != nil && hunk.HasBase {
+			return m, m.resolve(git.ResolveBase)
+		}
+		return m, nil
+	case "u":
+		return m, m.resolve(git.Unresolved)
+
+	case "j":
+		m.Hunk.LineDown(1)
+		return m, nil
+	case "k":
+		m.Hunk.LineUp(1)
+		return m, nil
+
+	case "enter":
+		return m, m.writeResolution()
+	}
+
+	return m, nil
+}
+
+// quit ends the program, or hands control back to the parent view when embedded
+func (m *Model) quit() tea.Cmd {
+	if m.Embedded {
+		return func() tea.Msg { return ClosedMsg{} }
+	}
+	m.Quitting = true
+	return tea.Quit
+}
+
+// currentHunk returns the hunk under the cursor, if any
+func (m *Model) currentHunk() *git.ConflictHunk {
+	if m.Current == nil || m.HunkIdx >= len(m.Current.Hunks) {
+		return nil
+	}
+	return m.Current.Hunks[m.HunkIdx]
+}
+
+// resolve records a resolution for the current hunk and advances to the next unresolved one
+func (m *Model) resolve(resolution git.Resolution) tea.Cmd {
+	hunk := m.currentHunk()
+	if hunk == nil {
+		return nil
+	}
+
+	hunk.Resolution = resolution
+	if resolution != git.Unresolved {
+		for i := m.HunkIdx + 1; i < len(m.Current.Hunks); i++ {
+			if m.Current.Hunks[i].Resolution == git.Unresolved {
+				m.HunkIdx = i
+				break
+			}
+		}
+	}
+
+	if m.Current.Resolved() {
+		m.Message = "All hunks resolved • Enter: write and stage"
+	} else {
+		m.Message = ""
+	}
+	m.renderHunk()
+	return nil
+}
+
+// writeResolution writes the resolved file and stages it
+func (m *Model) writeResolution() tea.Cmd {
+	file := m.Current
+	service := m.GitService
+	if file == nil || service == nil {
+		return nil
+	}
+
+	if !file.Resolved() {
+		m.Message = "Resolve every hunk before writing the file"
+		return nil
+	}
+
+	return func() tea.Msg {
+		// Files without hunks (e.g. modify/delete) are resolved as they are in the worktree
+		if len(file.Hunks) == 0 {
+			if err := service.MarkResolved(context.Background(), file.Path); err != nil {
+				return errMsg{err}
+			}
+			return resolvedMsg{path: file.Path}
+		}
+
+		content, err := file.Content()
+		if err != nil {
+			return errMsg{err}
+		}
+		if err := service.ResolveConflict(context.Background(), file.Path, content); err != nil {
+			return errMsg{err}
+		}
+		return resolvedMsg{path: file.Path}
+	}
+}
+
+// loadFiles refreshes the list of conflicted files
+func (m *Model) loadFiles() tea.Cmd {
+	service := m.GitService
+	if service == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		files, err := service.Status(context.Background())
+		if err != nil {
+			return errMsg{err}
+		}
+		return filesLoadedMsg{files: files}
+	}
+}
+
+// loadSelected parses the conflict hunks of the selected file
+func (m *Model) loadSelected() tea.Cmd {
+	item, ok := m.Files.SelectedItem().(FileItem)
+	service := m.GitService
+	if !ok || service == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		file, err := service.LoadConflict(context.Background(), item.Path)
+		if err != nil {
+			return errMsg{err}
+		}
+		return conflictLoadedMsg{file: file}
+	}
+}
@@ -0,0 +1,102 @@
+package conflict
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+)
+
+// View renders the current state of the model - implements tea.Model interface
+func (m *Model) View() string {
+	if m.Err != nil {
+		return m.StyleConfig.DeletedStyle.Render(fmt.Sprintf("Error: %v\nPress any key to exit", m.Err))
+	}
+
+	if m.Quitting {
+		return ""
+	}
+
+	if !m.Ready {
+		return "Loading conflicts..."
+	}
+
+	title := m.StyleConfig.TitleStyle.Render("Go Git TUI - Resolve Conflicts")
+
+	if m.Done {
+		done := m.StyleConfig.AddedStyle.Render("No conflicted files remain.")
+		help := m.StyleConfig.HelpStyle.Render("q: Quit")
+		return m.StyleConfig.AppStyle.Render(lipgloss.JoinVertical(lipgloss.Left, title, m.Message, done, help))
+	}
+
+	header := "Select a file"
+	if m.Current != nil {
+		if len(m.Current.Hunks) == 0 {
+			header = fmt.Sprintf("%s • no conflict markers", m.Current.Path)
+		} else {
+			hunk := m.currentHunk()
+			header = fmt.Sprintf("%s • hunk %d/%d • %s",
+				m.Current.Path, m.HunkIdx+1, len(m.Current.Hunks), hunk.Resolution)
+		}
+	}
+
+	divider := m.StyleConfig.DividerStyle.Render(strings.Repeat("│\n", max(m.Hunk.Height, 0)))
+	right := lipgloss.JoinVertical(lipgloss.Left,
+		m.StyleConfig.InfoStyle.Render(header),
+		m.Hunk.View(),
+	)
+	content := lipgloss.JoinHorizontal(lipgloss.Top, m.Files.View(), divider, right)
+
+	message := ""
+	if m.Message != "" {
+		message = m.StyleConfig.InfoStyle.Bold(true).Render(m.Message)
+	}
+
+	help := m.StyleConfig.HelpStyle.Render(
+		"w/s: Files • n/p: Hunks • o: Ours • t: Theirs • b: Both • a: Base • u: Undo • Enter: Write & Stage • q: Quit")
+
+	return m.StyleConfig.AppStyle.Render(lipgloss.JoinVertical(lipgloss.Left, title, content, message, help))
+}
+
+// renderHunk fills the hunk viewport with the base, ours and theirs sections of the current hunk
+func (m *Model) renderHunk() {
+	if m.Current == nil {
+		m.Hunk.SetContent("")
+		return
+	}
+
+	hunk := m.currentHunk()
+	if hunk == nil {
+		m.Hunk.SetContent(m.StyleConfig.InfoStyle.Render(
+			"This file has no conflict markers (e.g. modify/delete).\nEdit it as needed, then press Enter to stage it as resolved."))
+		return
+	}
+
+	section := func(label string, lines []string, selected bool, style lipgloss.Style) string {
+		marker := "  "
+		if selected {
+			marker = "▶ "
+		}
+		var sb strings.Builder
+		sb.WriteString(m.StyleConfig.TitleStyle.Render(marker+label) + "\n")
+		for _, line := range lines {
+			sb.WriteString(style.Render("  "+line) + "\n")
+		}
+		return sb.String()
+	}
+
+	ours := hunk.Resolution == git.ResolveOurs || hunk.Resolution == git.ResolveBoth
+	theirs := hunk.Resolution == git.ResolveTheirs || hunk.Resolution == git.ResolveBoth
+
+	var sb strings.Builder
+	sb.WriteString(section("ours ("+hunk.OursLabel+")", hunk.Ours, ours, m.StyleConfig.AddedStyle))
+	if hunk.HasBase {
+		sb.WriteString(section("base", hunk.Base, hunk.Resolution == git.ResolveBase, m.StyleConfig.StatusBar))
+	}
+	sb.WriteString(section("theirs ("+hunk.TheirsLabel+")", hunk.Theirs, theirs, m.StyleConfig.InfoStyle))
+
+	m.Hunk.SetContent(sb.String())
+	m.Hunk.GotoTop()
+}
@@ -0,0 +1,148 @@
+package dashboard
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui/add"
+	"github.com/LaansDole/go-git-tui/internal/ui/branchview"
+	"github.com/LaansDole/go-git-tui/internal/ui/commit"
+	"github.com/LaansDole/go-git-tui/internal/ui/common"
+	"github.com/LaansDole/go-git-tui/internal/ui/logview"
+	"github.com/LaansDole/go-git-tui/internal/ui/statusview"
+)
+
+// Tab identifies a view hosted by the dashboard
+type Tab int
+
+const (
+	// TabStatus shows the branch and the changed files
+	TabStatus Tab = iota
+	// TabAdd stages files
+	TabAdd
+	// TabCommit writes the commit message
+	TabCommit
+	// TabLog browses, cherry-picks and reverts commits
+	TabLog
+	// TabBranches lists the local branches
+	TabBranches
+
+	tabCount = iota
+)
+
+// tabNames are the titles of the tabs, in Tab order
+var tabNames = [tabCount]string{"Status", "Stage", "Commit", "Log", "Branches"}
+
+// chromeHeight is the number of lines the tab bar and the help line take from the views
+const chromeHeight = 2
+
+// repoLoadedMsg carries the repository state shown in the tab bar
+type repoLoadedMsg struct {
+	branch *git.BranchStatus
+	state  *git.RepoState
+}
+
+// refresher is implemented by the views that can reload what they show
+type refresher interface {
+	Refresh() tea.Cmd
+}
+
+// Model hosts the status, add, commit, log and branch views as tabs on one git service
+type Model struct {
+	Active   Tab
+	Status   *statusview.Model
+	Add      *add.Model
+	Commit   *commit.Model
+	Log      *logview.Model
+	Branches *branchview.Model
+
+	Branch *git.BranchStatus // Checked out branch, shown in the tab bar
+	State  *git.RepoState    // Operation in progress, nil when there is none
+
+	Err      error
+	Width    int
+	Height   int
+	Quitting bool
+
+	GitService  *git.DefaultGitService
+	StyleConfig common.StyleConfig // Shared by every view that uses the common styles
+}
+
+// New opens the repository in the working directory and creates every tab on it
+func New() *Model {
+	m := &Model{StyleConfig: common.NewStyleConfig()}
+
+	gitService, err := git.NewGitService()
+	if err != nil {
+		m.Err = err
+		return m
+	}
+	m.GitService = gitService
+
+	m.Status = statusview.NewWithService(gitService)
+	m.Status.StyleConfig = m.StyleConfig
+	m.Add = add.NewWithService(gitService, nil)
+	m.Add.StyleConfig = add.StyleConfig(m.StyleConfig)
+	m.Commit = m.newCommit()
+	m.Log = logview.NewWithService(gitService, "")
+	m.Log.StyleConfig = m.StyleConfig
+	m.Branches = branchview.NewWithService(gitService)
+	m.Branches.StyleConfig = m.StyleConfig
+
+	return m
+}
+
+// newCommit creates the commit view, which hands control back instead of quitting
+func (m *Model) newCommit() *commit.Model {
+	c := commit.NewWithService(m.GitService, git.CommitMessage{})
+	c.Embedded = true
+	return c
+}
+
+// Init loads the repository state and then every tab, one after the other since they
+// share the repository - implements tea.Model interface
+func (m *Model) Init() tea.Cmd {
+	if m.Err != nil {
+		return nil
+	}
+
+	cmds := []tea.Cmd{m.loadRepo()}
+	for tab := range Tab(tabCount) {
+		cmds = append(cmds, m.tab(tab).Init())
+	}
+	return tea.Sequence(cmds...)
+}
+
+// tab returns the view of tab
+func (m *Model) tab(tab Tab) tea.Model {
+	switch tab {
+	case TabStatus:
+		return m.Status
+	case TabAdd:
+		return m.Add
+	case TabCommit:
+		return m.Commit
+	case TabLog:
+		return m.Log
+	default:
+		return m.Branches
+	}
+}
+
+// loadRepo reads the branch and the operation in progress
+func (m *Model) loadRepo() tea.Cmd {
+	service := m.GitService
+	return func() tea.Msg {
+		ctx := context.Background()
+		msg := repoLoadedMsg{}
+		if branch, err := service.BranchStatus(ctx); err == nil {
+			msg.branch = branch
+		}
+		if state, err := service.State(ctx); err == nil && state.InProgress() {
+			msg.state = state
+		}
+		return msg
+	}
+}
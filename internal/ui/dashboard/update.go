@@ -0,0 +1,115 @@
+package dashboard
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/LaansDole/go-git-tui/internal/ui/commit"
+)
+
+// Update routes keys to the active tab and every other message to all tabs, since the
+// commands of inactive tabs keep running - implements tea.Model interface
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.Quitting {
+		return m, tea.Quit
+	}
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.Width, m.Height = msg.Width, msg.Height
+		if m.Err != nil {
+			return m, nil
+		}
+		return m, m.broadcast(tea.WindowSizeMsg{Width: msg.Width, Height: max(msg.Height-chromeHeight, 0)})
+
+	case repoLoadedMsg:
+		m.Branch, m.State = msg.branch, msg.state
+		return m, nil
+
+	case commit.CommittedMsg:
+		// A commit changes what every other tab shows
+		return m, tea.Batch(m.broadcast(msg), m.refresh())
+
+	case commit.ClosedMsg:
+		// Only the view the user is in can have been closed
+		if m.Active != TabCommit {
+			return m, m.updateTab(m.Active, msg)
+		}
+		m.Commit = m.newCommit()
+		return m, tea.Batch(m.Commit.Init(), m.resize(TabCommit))
+
+	case tea.KeyMsg:
+		if m.Err != nil || msg.String() == "ctrl+c" {
+			m.Quitting = true
+			return m, tea.Quit
+		}
+
+		switch msg.String() {
+		case "ctrl+n":
+			return m, m.activate((m.Active + 1) % tabCount)
+		case "ctrl+p":
+			return m, m.activate((m.Active + tabCount - 1) % tabCount)
+		}
+		return m, m.updateTab(m.Active, msg)
+
+	case tea.MouseMsg:
+		return m, m.updateTab(m.Active, msg)
+	}
+
+	if m.Err != nil {
+		return m, nil
+	}
+	return m, m.broadcast(msg)
+}
+
+// activate switches to tab and reloads it, since the repository may have changed
+func (m *Model) activate(tab Tab) tea.Cmd {
+	m.Active = tab
+	if r, ok := m.tab(tab).(refresher); ok {
+		return tea.Sequence(r.Refresh(), m.loadRepo())
+	}
+	return m.loadRepo()
+}
+
+// refresh reloads the repository state and every tab that shows it, one after the other
+// like Init
+func (m *Model) refresh() tea.Cmd {
+	cmds := []tea.Cmd{m.loadRepo()}
+	for tab := range Tab(tabCount) {
+		if r, ok := m.tab(tab).(refresher); ok {
+			cmds = append(cmds, r.Refresh())
+		}
+	}
+	return tea.Sequence(cmds...)
+}
+
+// broadcast sends msg to every tab
+func (m *Model) broadcast(msg tea.Msg) tea.Cmd {
+	cmds := make([]tea.Cmd, 0, tabCount)
+	for tab := range Tab(tabCount) {
+		cmds = append(cmds, m.updateTab(tab, msg))
+	}
+	return tea.Batch(cmds...)
+}
+
+// resize lays out tab for the current window
+func (m *Model) resize(tab Tab) tea.Cmd {
+	if m.Width == 0 {
+		return nil
+	}
+	return m.updateTab(tab, tea.WindowSizeMsg{Width: m.Width, Height: max(m.Height-chromeHeight, 0)})
+}
+
+// updateTab updates the view of tab with msg
+func (m *Model) updateTab(tab Tab, msg tea.Msg) tea.Cmd {
+	if tab == TabCommit {
+		// The commit view is a value, so the updated copy replaces it
+		updated, cmd := m.Commit.Update(msg)
+		if c, ok := updated.(commit.Model); ok {
+			m.Commit = &c
+		}
+		return cmd
+	}
+
+	_, cmd := m.tab(tab).Update(msg)
+	return cmd
+}
@@ -0,0 +1,85 @@
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/LaansDole/go-git-tui/internal/ui/common"
+)
+
+// View renders the tab bar, the active tab and the help line - implements tea.Model interface
+func (m *Model) View() string {
+	if m.Quitting {
+		return ""
+	}
+
+	if m.Err != nil {
+		return m.errorView()
+	}
+
+	body := lipgloss.NewStyle().MaxHeight(max(m.Height-chromeHeight, 0)).Render(m.tab(m.Active).View())
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		m.tabBar(),
+		body,
+		m.StyleConfig.HelpStyle.Render("ctrl+n/ctrl+p: Switch Tab • ctrl+c: Quit"),
+	)
+}
+
+// tabBar lists the tabs, highlighting the active one, followed by the branch and the
+// operation in progress
+func (m *Model) tabBar() string {
+	tabs := make([]string, 0, tabCount)
+	for tab, name := range tabNames {
+		if Tab(tab) == m.Active {
+			tabs = append(tabs, m.StyleConfig.TitleStyle.Reverse(true).Padding(0, 1).Render(name))
+		} else {
+			tabs = append(tabs, m.StyleConfig.HelpStyle.Padding(0, 1).Render(name))
+		}
+	}
+	bar := strings.Join(tabs, m.StyleConfig.DividerStyle.Render("│"))
+
+	if branch := m.branchSummary(); branch != "" {
+		bar += "  " + m.StyleConfig.InfoStyle.Render(branch)
+	}
+	if m.State.InProgress() {
+		bar += "  " + m.StyleConfig.DeletedStyle.Bold(true).Render(m.State.Summary())
+	}
+	return bar
+}
+
+// branchSummary names the checked out branch and how it relates to its upstream
+func (m *Model) branchSummary() string {
+	switch {
+	case m.Branch == nil:
+		return ""
+	case m.Branch.Detached:
+		return fmt.Sprintf("HEAD detached at %s", m.Branch.Head[:min(7, len(m.Branch.Head))])
+	case m.Branch.Upstream == "":
+		return m.Branch.Branch
+	}
+
+	divergence := m.Branch.Divergence()
+	if divergence == "" {
+		divergence = "up to date"
+	}
+	return fmt.Sprintf("%s → %s (%s)", m.Branch.Branch, m.Branch.Upstream, divergence)
+}
+
+// errorView explains why the repository could not be opened
+func (m *Model) errorView() string {
+	help := common.DescribeError(m.Err)
+
+	lines := []string{m.StyleConfig.DeletedStyle.Bold(true).Render(help.Message)}
+	if help.Hint != "" {
+		lines = append(lines, m.StyleConfig.InfoStyle.Render(help.Hint))
+	}
+	if help.Detail != "" {
+		lines = append(lines, "", m.StyleConfig.HelpStyle.Render(help.Detail))
+	}
+	lines = append(lines, "", m.StyleConfig.HelpStyle.Render("Any key: Quit"))
+
+	return m.StyleConfig.AppStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
@@ -0,0 +1,104 @@
+package history
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui/common"
+)
+
+// historyLimit is the maximum number of revisions loaded for a file
+const historyLimit = 500
+
+// RevisionItem is a commit that changed the file, shown in the revision list
+type RevisionItem struct {
+	Revision git.FileRevision
+}
+
+// Title implements the list.Item interface
+func (i RevisionItem) Title() string {
+	return common.CommitItem{Commit: i.Revision.Commit}.Title()
+}
+
+// Description implements the list.Item interface
+func (i RevisionItem) Description() string {
+	description := common.CommitItem{Commit: i.Revision.Commit}.Description()
+	if i.Revision.OldPath != "" {
+		description += " • renamed from " + i.Revision.OldPath
+	}
+	return description
+}
+
+// FilterValue implements the list.Item interface
+func (i RevisionItem) FilterValue() string { return i.Revision.Commit.Subject }
+
+// Custom message types
+type historyLoadedMsg struct{ revisions []git.FileRevision }
+type diffLoadedMsg struct {
+	hash string
+	diff *git.DiffResult
+}
+type restoredMsg struct{ revision git.FileRevision }
+type errMsg struct{ err error }
+
+// Model represents the file history UI state
+type Model struct {
+	Path        string
+	List        list.Model
+	Diff        viewport.Model
+	CurrentHash string // Commit whose diff is shown
+	Confirm     bool   // Waiting for y/N before restoring
+	Loading     bool
+	Message     string
+	Err         error
+	Width       int
+	Height      int
+	Ready       bool
+	Quitting    bool
+
+	GitService  *git.DefaultGitService
+	StyleConfig common.StyleConfig
+}
+
+// New initializes a file history model for path
+func New(path string) *Model {
+	delegate := list.NewDefaultDelegate()
+	delegate.SetSpacing(0)
+	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
+		Foreground(lipgloss.Color("170")).
+		Margin(0, 0)
+
+	l := list.New([]list.Item{}, delegate, 0, 0)
+	l.Title = "History of " + path
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.SetShowHelp(false)
+
+	m := &Model{
+		Path:        path,
+		List:        l,
+		Diff:        viewport.New(0, 0),
+		Loading:     true,
+		StyleConfig: common.NewStyleConfig(),
+	}
+
+	gitService, err := git.NewGitService()
+	if err != nil {
+		m.Err = err
+		return m
+	}
+	m.GitService = gitService
+
+	return m
+}
+
+// Init loads the commits that changed the file - implements tea.Model interface
+func (m *Model) Init() tea.Cmd {
+	if m.GitService == nil {
+		return nil
+	}
+	return m.loadHistory()
+}
@@ -0,0 +1,165 @@
+package history
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui/common"
+)
+
+// Update handles events and updates the model - implements tea.Model interface
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.Width, m.Height = msg.Width, msg.Height
+		m.Ready = true
+		h, v := m.StyleConfig.AppStyle.GetFrameSize()
+		listWidth := (msg.Width - h) * common.ListRatio / 100
+		m.List.SetSize(listWidth, msg.Height-v-3)
+		m.Diff.Width = msg.Width - h - listWidth - common.DividerWidth - 1
+		m.Diff.Height = msg.Height - v - 4
+		return m, m.reloadDiff()
+
+	case historyLoadedMsg:
+		m.Loading = false
+		items := make([]list.Item, 0, len(msg.revisions))
+		for _, rev := range msg.revisions {
+			items = append(items, RevisionItem{Revision: rev})
+		}
+		m.List.SetItems(items)
+		if len(items) == 0 {
+			m.Message = fmt.Sprintf("No commits changed %s", m.Path)
+			return m, nil
+		}
+		return m, m.selectionChanged()
+
+	case diffLoadedMsg:
+		// Drop diffs for revisions the user has already moved past
+		if msg.hash != m.CurrentHash {
+			return m, nil
+		}
+		m.Diff.SetContent(common.RenderDiff(*msg.diff, m.Diff.Width, m.StyleConfig))
+		m.Diff.GotoTop()
+		return m, nil
+
+	case restoredMsg:
+		m.Message = fmt.Sprintf("Restored %s from %s (not staged)", m.Path, msg.revision.Commit.ShortHash)
+		return m, nil
+
+	case errMsg:
+		m.Loading = false
+		m.Message = "Error: " + msg.err.Error()
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.Err != nil || msg.String() == "ctrl+c" {
+			m.Quitting = true
+			return m, tea.Quit
+		}
+
+		if m.Confirm {
+			m.Confirm = false
+			m.Message = ""
+			if msg.String() == "y" {
+				return m, m.restore()
+			}
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "q", "esc":
+			m.Quitting = true
+			return m, tea.Quit
+		case "w", "up":
+			m.List.CursorUp()
+			return m, m.selectionChanged()
+		case "s", "down":
+			m.List.CursorDown()
+			return m, m.selectionChanged()
+		case "j":
+			m.Diff.LineDown(1)
+			return m, nil
+		case "k":
+			m.Diff.LineUp(1)
+			return m, nil
+		case "pgdown":
+			m.Diff.HalfViewDown()
+			return m, nil
+		case "pgup":
+			m.Diff.HalfViewUp()
+			return m, nil
+		case "r":
+			if item, ok := m.List.SelectedItem().(RevisionItem); ok {
+				m.Confirm = true
+				m.Message = fmt.Sprintf("Overwrite %s with its content at %s? (y/N)", m.Path, item.Revision.Commit.ShortHash)
+			}
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// selectionChanged loads the diff of the selected revision when it differs from the one shown
+func (m *Model) selectionChanged() tea.Cmd {
+	item, ok := m.List.SelectedItem().(RevisionItem)
+	if !ok || item.Revision.Commit.Hash == m.CurrentHash {
+		return nil
+	}
+	m.CurrentHash = item.Revision.Commit.Hash
+	m.Diff.SetContent("Loading diff...")
+	return m.loadDiff(item.Revision)
+}
+
+// reloadDiff re-renders the current diff after a resize
+func (m *Model) reloadDiff() tea.Cmd {
+	item, ok := m.List.SelectedItem().(RevisionItem)
+	if !ok {
+		return nil
+	}
+	return m.loadDiff(item.Revision)
+}
+
+// loadHistory fetches the commits that changed the file
+func (m *Model) loadHistory() tea.Cmd {
+	service, path := m.GitService, m.Path
+	return func() tea.Msg {
+		revisions, err := service.FileHistory(context.Background(), path, historyLimit)
+		if err != nil {
+			return errMsg{err}
+		}
+		return historyLoadedMsg{revisions: revisions}
+	}
+}
+
+// loadDiff fetches what a revision changed in the file
+func (m *Model) loadDiff(rev git.FileRevision) tea.Cmd {
+	service := m.GitService
+	return func() tea.Msg {
+		diff, err := service.FileDiffAt(context.Background(), rev.Commit.Hash, rev.Path)
+		if err != nil {
+			return errMsg{err}
+		}
+		return diffLoadedMsg{hash: rev.Commit.Hash, diff: diff}
+	}
+}
+
+// restore writes the file as it was at the selected revision into the working tree
+func (m *Model) restore() tea.Cmd {
+	item, ok := m.List.SelectedItem().(RevisionItem)
+	if !ok {
+		return nil
+	}
+
+	service, dest := m.GitService, m.Path
+	return func() tea.Msg {
+		if err := service.RestoreFileAt(context.Background(), item.Revision.Commit.Hash, item.Revision.Path, dest); err != nil {
+			return errMsg{err}
+		}
+		return restoredMsg{revision: item.Revision}
+	}
+}
@@ -0,0 +1,50 @@
+package history
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// View renders the current state of the model - implements tea.Model interface
+func (m *Model) View() string {
+	if m.Err != nil {
+		return m.StyleConfig.DeletedStyle.Render(fmt.Sprintf("Error: %v\nPress any key to exit", m.Err))
+	}
+
+	if m.Quitting {
+		return ""
+	}
+
+	if !m.Ready || m.Loading {
+		return "Loading history..."
+	}
+
+	title := m.StyleConfig.TitleStyle.Render("Go Git TUI - File History")
+
+	header := "Select a commit"
+	if item, ok := m.List.SelectedItem().(RevisionItem); ok {
+		rev := item.Revision
+		header = fmt.Sprintf("%s in %s", rev.Path, rev.Commit.ShortHash)
+		if rev.OldPath != "" {
+			header = fmt.Sprintf("%s → %s in %s", rev.OldPath, rev.Path, rev.Commit.ShortHash)
+		}
+	}
+
+	divider := m.StyleConfig.DividerStyle.Render(strings.Repeat("│\n", max(m.Diff.Height, 0)))
+	right := lipgloss.JoinVertical(lipgloss.Left,
+		m.StyleConfig.InfoStyle.Render(header),
+		m.Diff.View(),
+	)
+	content := lipgloss.JoinHorizontal(lipgloss.Top, m.List.View(), divider, right)
+
+	message := ""
+	if m.Message != "" {
+		message = m.StyleConfig.InfoStyle.Bold(true).Render(m.Message)
+	}
+
+	help := m.StyleConfig.HelpStyle.Render("w/s: Commits • j/k: Scroll Diff • r: Restore file at commit • q: Quit")
+
+	return m.StyleConfig.AppStyle.Render(lipgloss.JoinVertical(lipgloss.Left, title, content, message, help))
+}
@@ -0,0 +1,17 @@
+package logview
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Run initializes and runs the log UI component for rev in a fullscreen terminal view
+func Run(rev string) error {
+	p := tea.NewProgram(
+		New(rev),
+		tea.WithAltScreen(),
+		tea.WithMouseCellMotion(),
+	)
+
+	_, err := p.Run()
+	return err
+}
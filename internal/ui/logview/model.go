@@ -0,0 +1,135 @@
+package logview
+
+import (
+	"context"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui/common"
+	"github.com/LaansDole/go-git-tui/internal/ui/conflict"
+)
+
+// Phase is the screen the log UI is currently showing
+type Phase int
+
+const (
+	// PhaseBrowse lists commits and the diff of the one under the cursor
+	PhaseBrowse Phase = iota
+	// PhaseRunning waits for git to apply the selected commits
+	PhaseRunning
+	// PhaseStopped is shown when a cherry-pick or revert stopped on a conflict
+	PhaseStopped
+	// PhaseConflicts hosts the conflict resolution view
+	PhaseConflicts
+)
+
+// logLimit is the number of commits listed
+const logLimit = 200
+
+// Custom message types
+type logLoadedMsg struct{ commits []git.CommitInfo }
+type diffLoadedMsg struct {
+	commit git.CommitInfo
+	diffs  []git.DiffResult
+}
+type pickResultMsg struct {
+	result     *git.PickResult
+	conflicted int
+}
+type stateMsg struct {
+	state      *git.RepoState
+	conflicted int
+}
+type continuedMsg struct{}
+type abortedMsg struct{}
+type errMsg struct{ err error }
+
+// Model represents the log UI state
+type Model struct {
+	Rev         string // Revision the log starts from, HEAD when empty
+	Phase       Phase
+	List        list.Model
+	Diff        viewport.Model
+	CurrentHash string          // Commit whose diff is shown
+	Selected    map[string]bool // Selected commits by hash
+
+	Operation  git.Operation    // Cherry-pick or revert being applied
+	Pending    []git.CommitInfo // Commits still to apply after the stopped one
+	Applied    int              // Commits applied so far
+	State      *git.RepoState
+	Conflicted int
+	Conflict   *conflict.Model
+
+	Message  string
+	Err      error
+	Width    int
+	Height   int
+	Ready    bool
+	Quitting bool
+
+	GitService  *git.DefaultGitService
+	StyleConfig common.StyleConfig
+}
+
+// New initializes a log model starting at rev, resuming a stopped cherry-pick or revert
+func New(rev string) *Model {
+	gitService, err := git.NewGitService()
+	m := NewWithService(gitService, rev)
+	if err != nil {
+		m.Err = err
+	}
+	return m
+}
+
+// NewWithService initializes a log model on an existing git service
+func NewWithService(gitService *git.DefaultGitService, rev string) *Model {
+	delegate := list.NewDefaultDelegate()
+	delegate.SetSpacing(0)
+	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
+		Foreground(lipgloss.Color("170")).
+		Margin(0, 0)
+
+	l := list.New([]list.Item{}, delegate, 0, 0)
+	l.Title = "Log"
+	if rev != "" {
+		l.Title = "Log of " + rev
+	}
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.SetShowHelp(false)
+
+	m := &Model{
+		Rev:         rev,
+		List:        l,
+		Diff:        viewport.New(0, 0),
+		Selected:    map[string]bool{},
+		StyleConfig: common.NewStyleConfig(),
+		GitService:  gitService,
+	}
+	if gitService == nil {
+		return m
+	}
+
+	if state, err := gitService.State(context.Background()); err == nil &&
+		(state.Operation == git.OperationCherryPick || state.Operation == git.OperationRevert) {
+		m.Phase = PhaseStopped
+		m.State = state
+		m.Operation = state.Operation
+	}
+
+	return m
+}
+
+// Init loads the log - implements tea.Model interface
+func (m *Model) Init() tea.Cmd {
+	if m.GitService == nil {
+		return nil
+	}
+	if m.Phase == PhaseStopped {
+		return tea.Batch(m.loadLog(), m.refreshConflicts())
+	}
+	return m.loadLog()
+}
@@ -0,0 +1,344 @@
+package logview
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui/common"
+	"github.com/LaansDole/go-git-tui/internal/ui/conflict"
+)
+
+// Update handles events and updates the model - implements tea.Model interface
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// The embedded conflict view owns all input until it is closed
+	if m.Phase == PhaseConflicts && m.Conflict != nil {
+		if _, ok := msg.(conflict.ClosedMsg); ok {
+			m.Conflict = nil
+			m.Phase = PhaseStopped
+			return m, m.refreshConflicts()
+		}
+		if key, ok := msg.(tea.KeyMsg); ok && key.String() == "ctrl+c" {
+			m.Quitting = true
+			return m, tea.Quit
+		}
+		_, cmd := m.Conflict.Update(msg)
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.Width, m.Height = msg.Width, msg.Height
+		m.Ready = true
+		h, v := m.StyleConfig.AppStyle.GetFrameSize()
+		listWidth := (msg.Width - h) * common.ListRatio / 100
+		m.List.SetSize(listWidth, msg.Height-v-3)
+		m.Diff.Width = msg.Width - h - listWidth - common.DividerWidth - 1
+		m.Diff.Height = msg.Height - v - 3
+		m.CurrentHash = ""
+		return m, m.selectionChanged()
+
+	case logLoadedMsg:
+		items := make([]list.Item, 0, len(msg.commits))
+		for _, c := range msg.commits {
+			items = append(items, common.CommitItem{Commit: c, IsSelected: m.Selected[c.Hash]})
+		}
+		m.List.SetItems(items)
+		m.CurrentHash = ""
+		return m, m.selectionChanged()
+
+	case diffLoadedMsg:
+		// Drop diffs for commits the user has already moved past
+		if msg.commit.Hash != m.CurrentHash {
+			return m, nil
+		}
+		m.Diff.SetContent(common.RenderCommitDiff(msg.commit, msg.diffs, m.Diff.Width, m.StyleConfig))
+		m.Diff.GotoTop()
+		return m, nil
+
+	case pickResultMsg:
+		applied := msg.result.Applied
+		m.Applied += applied
+		m.State = msg.result.State
+		m.Conflicted = msg.conflicted
+		if m.State.InProgress() {
+			// The stopped commit is concluded by continuing the operation
+			m.Pending = m.Pending[min(applied+1, len(m.Pending)):]
+			m.Phase = PhaseStopped
+			return m, nil
+		}
+		return m, m.finish()
+
+	case stateMsg:
+		m.State = msg.state
+		m.Conflicted = msg.conflicted
+		return m, nil
+
+	case continuedMsg:
+		m.Applied++
+		if len(m.Pending) > 0 {
+			m.Phase = PhaseRunning
+			return m, m.apply(m.Operation, m.Pending)
+		}
+		return m, m.finish()
+
+	case abortedMsg:
+		m.Phase = PhaseBrowse
+		m.Message = fmt.Sprintf("Aborted %s; %d commit(s) were applied before it", m.Operation, m.Applied)
+		m.Pending = nil
+		m.State = nil
+		return m, m.loadLog()
+
+	case errMsg:
+		m.Message = "Error: " + msg.err.Error()
+		if m.Phase == PhaseRunning {
+			m.Phase = PhaseBrowse
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.Err != nil || msg.String() == "ctrl+c" {
+			m.Quitting = true
+			return m, tea.Quit
+		}
+
+		switch m.Phase {
+		case PhaseBrowse:
+			return m.handleBrowseKeys(msg)
+		case PhaseStopped:
+			return m.handleStoppedKeys(msg)
+		}
+	}
+
+	return m, nil
+}
+
+// handleBrowseKeys handles keys while browsing the log
+func (m *Model) handleBrowseKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		m.Quitting = true
+		return m, tea.Quit
+	case "w", "up":
+		m.List.CursorUp()
+		return m, m.selectionChanged()
+	case "s", "down":
+		m.List.CursorDown()
+		return m, m.selectionChanged()
+	case "j":
+		m.Diff.LineDown(1)
+	case "k":
+		m.Diff.LineUp(1)
+	case "pgdown":
+		m.Diff.HalfViewDown()
+	case "pgup":
+		m.Diff.HalfViewUp()
+	case "tab", " ":
+		m.toggleSelection()
+	case "c":
+		// Apply oldest first so the picked commits keep their original order
+		commits := m.selectedCommits()
+		for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+			commits[i], commits[j] = commits[j], commits[i]
+		}
+		return m, m.start(git.OperationCherryPick, commits)
+	case "v":
+		// Revert newest first so later changes are undone before the ones they build on
+		return m, m.start(git.OperationRevert, m.selectedCommits())
+	}
+	return m, nil
+}
+
+// handleStoppedKeys handles keys while a cherry-pick or revert waits on a conflict
+func (m *Model) handleStoppedKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		// The operation stays in progress and can be resumed later
+		m.Quitting = true
+		return m, tea.Quit
+	case "r":
+		if m.Conflicted == 0 {
+			return m, nil
+		}
+		m.Conflict = conflict.NewWithService(m.GitService)
+		m.Conflict.Embedded = true
+		m.Phase = PhaseConflicts
+		cmds := []tea.Cmd{m.Conflict.Init()}
+		if m.Width > 0 {
+			_, cmd := m.Conflict.Update(tea.WindowSizeMsg{Width: m.Width, Height: m.Height})
+			cmds = append(cmds, cmd)
+		}
+		return m, tea.Batch(cmds...)
+	case "c":
+		m.Message = ""
+		return m, m.continueOperation()
+	case "a":
+		m.Message = ""
+		return m, m.abortOperation()
+	}
+	return m, nil
+}
+
+// toggleSelection selects or deselects the commit under the cursor
+func (m *Model) toggleSelection() {
+	item, ok := m.List.SelectedItem().(common.CommitItem)
+	if !ok {
+		return
+	}
+
+	hash := item.Commit.Hash
+	m.Selected[hash] = !m.Selected[hash]
+	item.IsSelected = m.Selected[hash]
+	m.List.SetItem(m.List.Index(), item)
+
+	if m.List.Index() < len(m.List.Items())-1 {
+		m.List.CursorDown()
+	}
+}
+
+// selectedCommits returns the selected commits in log order, or the one under the cursor
+func (m *Model) selectedCommits() []git.CommitInfo {
+	var commits []git.CommitInfo
+	for _, item := range m.List.Items() {
+		if c, ok := item.(common.CommitItem); ok && m.Selected[c.Commit.Hash] {
+			commits = append(commits, c.Commit)
+		}
+	}
+
+	if len(commits) == 0 {
+		if c, ok := m.List.SelectedItem().(common.CommitItem); ok {
+			commits = append(commits, c.Commit)
+		}
+	}
+	return commits
+}
+
+// start applies commits with a cherry-pick or revert
+func (m *Model) start(op git.Operation, commits []git.CommitInfo) tea.Cmd {
+	if len(commits) == 0 {
+		return nil
+	}
+	m.Operation = op
+	m.Pending = commits
+	m.Applied = 0
+	m.Phase = PhaseRunning
+	m.Message = ""
+	return m.apply(op, commits)
+}
+
+// finish reports the applied commits and returns to browsing
+func (m *Model) finish() tea.Cmd {
+	verb := "Cherry-picked"
+	if m.Operation == git.OperationRevert {
+		verb = "Reverted"
+	}
+	m.Message = fmt.Sprintf("%s %d commit(s)", verb, m.Applied)
+	m.Phase = PhaseBrowse
+	m.Pending = nil
+	m.State = nil
+	m.Selected = map[string]bool{}
+	return m.loadLog()
+}
+
+// selectionChanged loads the diff of the commit under the cursor when it is not shown yet
+func (m *Model) selectionChanged() tea.Cmd {
+	item, ok := m.List.SelectedItem().(common.CommitItem)
+	if !ok || item.Commit.Hash == m.CurrentHash {
+		return nil
+	}
+	m.CurrentHash = item.Commit.Hash
+	m.Diff.SetContent("Loading diff...")
+
+	service, commit := m.GitService, item.Commit
+	return func() tea.Msg {
+		diffs, err := service.CommitDiff(context.Background(), commit.Hash)
+		if err != nil {
+			return errMsg{err}
+		}
+		return diffLoadedMsg{commit: commit, diffs: diffs}
+	}
+}
+
+// Refresh reloads the log while browsing it, for hosts that created commits
+func (m *Model) Refresh() tea.Cmd {
+	if m.GitService == nil || m.Phase != PhaseBrowse {
+		return nil
+	}
+	return m.loadLog()
+}
+
+// loadLog fetches the commits to list
+func (m *Model) loadLog() tea.Cmd {
+	service, rev := m.GitService, m.Rev
+	return func() tea.Msg {
+		commits, err := service.Log(context.Background(), git.LogOptions{From: rev, Limit: logLimit})
+		if err != nil {
+			return errMsg{err}
+		}
+		return logLoadedMsg{commits: commits}
+	}
+}
+
+// apply runs the cherry-pick or revert of commits
+func (m *Model) apply(op git.Operation, commits []git.CommitInfo) tea.Cmd {
+	service := m.GitService
+	commits = append([]git.CommitInfo{}, commits...)
+	return func() tea.Msg {
+		run := service.CherryPick
+		if op == git.OperationRevert {
+			run = service.Revert
+		}
+
+		result, err := run(context.Background(), commits)
+		if err != nil {
+			return errMsg{err}
+		}
+		return pickResultMsg{result: result, conflicted: countConflicted(service)}
+	}
+}
+
+// continueOperation concludes the stopped commit once its conflicts are resolved
+func (m *Model) continueOperation() tea.Cmd {
+	service := m.GitService
+	return func() tea.Msg {
+		if err := service.ContinueOperation(context.Background(), ""); err != nil {
+			return errMsg{err}
+		}
+		return continuedMsg{}
+	}
+}
+
+// abortOperation abandons the stopped commit and the ones after it
+func (m *Model) abortOperation() tea.Cmd {
+	service := m.GitService
+	return func() tea.Msg {
+		if err := service.AbortOperation(context.Background()); err != nil {
+			return errMsg{err}
+		}
+		return abortedMsg{}
+	}
+}
+
+// refreshConflicts reloads the operation state and the number of conflicted files
+func (m *Model) refreshConflicts() tea.Cmd {
+	service := m.GitService
+	return func() tea.Msg {
+		state, err := service.State(context.Background())
+		if err != nil {
+			return errMsg{err}
+		}
+		return stateMsg{state: state, conflicted: countConflicted(service)}
+	}
+}
+
+// countConflicted returns the number of unmerged files, or 0 if the status cannot be read
+func countConflicted(service *git.DefaultGitService) int {
+	files, err := service.Status(context.Background())
+	if err != nil {
+		return 0
+	}
+	return git.CountConflicted(files)
+}
@@ -0,0 +1,82 @@
+package logview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// View renders the current state of the model - implements tea.Model interface
+func (m *Model) View() string {
+	if m.Err != nil {
+		return m.StyleConfig.DeletedStyle.Render(fmt.Sprintf("Error: %v\nPress any key to exit", m.Err))
+	}
+
+	if m.Quitting {
+		return ""
+	}
+
+	if !m.Ready {
+		return "Loading log..."
+	}
+
+	if m.Phase == PhaseConflicts && m.Conflict != nil {
+		return m.Conflict.View()
+	}
+
+	title := m.StyleConfig.TitleStyle.Render("Go Git TUI - Log")
+
+	var body, help string
+	switch m.Phase {
+	case PhaseRunning:
+		body = m.StyleConfig.InfoStyle.Render(fmt.Sprintf("Applying %d commit(s) with %s...", len(m.Pending), m.Operation))
+	case PhaseStopped:
+		body = m.renderStopped()
+		help = "c: Continue • a: Abort • q: Quit (resume later from the log view)"
+		if m.Conflicted > 0 {
+			help = "r: Resolve conflicts • " + help
+		}
+	default:
+		divider := m.StyleConfig.DividerStyle.Render(strings.Repeat("│\n", max(m.Diff.Height, 0)))
+		body = lipgloss.JoinHorizontal(lipgloss.Top, m.List.View(), divider, m.Diff.View())
+		help = "w/s: Navigate • Tab: Select • c: Cherry-pick • v: Revert • j/k: Scroll Diff • q: Quit"
+	}
+
+	message := ""
+	if m.Message != "" {
+		message = m.StyleConfig.InfoStyle.Bold(true).Render(m.Message)
+	}
+
+	return m.StyleConfig.AppStyle.Render(lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		body,
+		message,
+		m.StyleConfig.HelpStyle.Render(help),
+	))
+}
+
+// renderStopped describes the stopped cherry-pick or revert and what is left to apply
+func (m *Model) renderStopped() string {
+	lines := []string{m.StyleConfig.StatusBar.Render(m.State.Summary())}
+
+	if m.Conflicted > 0 {
+		lines = append(lines, m.StyleConfig.DeletedStyle.Render(
+			fmt.Sprintf("%d conflicted file(s). Resolve them, then continue.", m.Conflicted)))
+	} else {
+		lines = append(lines, m.StyleConfig.InfoStyle.Render("All conflicts are resolved. Continue to commit."))
+	}
+
+	if m.State.Message != "" {
+		lines = append(lines, "", m.StyleConfig.HelpStyle.Render("Commit message:"), m.State.Message)
+	}
+
+	if len(m.Pending) > 0 {
+		lines = append(lines, "", m.StyleConfig.HelpStyle.Render(fmt.Sprintf("Still to apply after this one (%d):", len(m.Pending))))
+		for _, c := range m.Pending {
+			lines = append(lines, "  "+c.ShortHash+" "+c.Subject)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
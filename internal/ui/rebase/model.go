@@ -0,0 +1,125 @@
+package rebase
+
+import (
+	"context"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui/common"
+	"github.com/LaansDole/go-git-tui/internal/ui/conflict"
+)
+
+// Phase is the screen the rebase UI is currently showing
+type Phase int
+
+const (
+	// PhaseBase lets the user pick the commit to rebase onto
+	PhaseBase Phase = iota
+	// PhasePlan is the todo editor for reordering commits and choosing actions
+	PhasePlan
+	// PhaseReword collects a new message for a reword step
+	PhaseReword
+	// PhaseRunning waits for git to replay the todo list
+	PhaseRunning
+	// PhaseStopped is shown when git stopped for an edit step or a conflict
+	PhaseStopped
+	// PhaseConflicts hosts the conflict resolution view
+	PhaseConflicts
+	// PhaseDone is shown once the rebase has finished or was aborted
+	PhaseDone
+)
+
+// logLimit is the number of recent commits offered as rebase bases
+const logLimit = 100
+
+// Custom message types
+type logLoadedMsg struct{ commits []git.CommitInfo }
+type planLoadedMsg struct{ commits []git.CommitInfo }
+type rebaseResultMsg struct {
+	state      *git.RepoState
+	conflicted int
+	aborted    bool
+}
+type errMsg struct{ err error }
+
+// Model represents the interactive rebase UI state
+type Model struct {
+	Phase    Phase
+	Log      list.Model
+	Base     git.CommitInfo
+	Steps    []git.RebaseStep
+	Cursor   int
+	Input    textinput.Model
+	State    *git.RepoState
+	Conflict *conflict.Model
+
+	Conflicted int // Number of unmerged files while stopped
+	Aborted    bool
+	Message    string
+	Err        error
+	Width      int
+	Height     int
+	Ready      bool
+
+	GitService  *git.DefaultGitService
+	StyleConfig common.StyleConfig
+}
+
+// New initializes a new rebase model, resuming a rebase that is already in progress
+func New() *Model {
+	delegate := list.NewDefaultDelegate()
+	delegate.SetSpacing(0)
+	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
+		Foreground(lipgloss.Color("170")).
+		Margin(0, 0)
+
+	l := list.New([]list.Item{}, delegate, 0, 0)
+	l.Title = "Select the base commit (commits after it will be rebased)"
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.SetShowHelp(false)
+
+	ti := textinput.New()
+	ti.Width = 60
+	ti.PromptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("63"))
+
+	m := &Model{
+		Phase:       PhaseBase,
+		Log:         l,
+		Input:       ti,
+		StyleConfig: common.NewStyleConfig(),
+	}
+
+	gitService, err := git.NewGitService()
+	if err != nil {
+		m.Err = err
+		return m
+	}
+	m.GitService = gitService
+
+	if state, err := gitService.State(context.Background()); err == nil && state.InProgress() {
+		if state.Operation != git.OperationRebase {
+			m.Err = &git.InProgressError{Action: "start a rebase", Operation: state.Operation}
+			return m
+		}
+		m.Phase = PhaseStopped
+		m.State = state
+	}
+
+	return m
+}
+
+// Init loads the commit log or the state of the stopped rebase - implements tea.Model interface
+func (m *Model) Init() tea.Cmd {
+	if m.GitService == nil {
+		return nil
+	}
+	if m.Phase == PhaseStopped {
+		return m.refreshState()
+	}
+	return m.loadLog()
+}
@@ -0,0 +1,338 @@
+package rebase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui/common"
+	"github.com/LaansDole/go-git-tui/internal/ui/conflict"
+)
+
+// actionKeys maps todo editor keys to rebase actions, the letters of git's todo list.
+var actionKeys = map[string]git.RebaseAction{
+	"p": git.RebasePick,
+	"r": git.RebaseReword,
+	"e": git.RebaseEdit,
+	"s": git.RebaseSquash,
+	"f": git.RebaseFixup,
+	"d": git.RebaseDrop,
+}
+
+// Update handles events and updates the model - implements tea.Model interface
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// The embedded conflict view owns all input until it is closed
+	if m.Phase == PhaseConflicts && m.Conflict != nil {
+		if _, ok := msg.(conflict.ClosedMsg); ok {
+			m.Conflict = nil
+			m.Phase = PhaseStopped
+			return m, m.refreshState()
+		}
+		if key, ok := msg.(tea.KeyMsg); ok && key.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		_, cmd := m.Conflict.Update(msg)
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.Width, m.Height = msg.Width, msg.Height
+		m.Ready = true
+		h, v := m.StyleConfig.AppStyle.GetFrameSize()
+		m.Log.SetSize(msg.Width-h, msg.Height-v-3)
+		m.Input.Width = msg.Width - h - 4
+		return m, nil
+
+	case logLoadedMsg:
+		items := make([]list.Item, 0, len(msg.commits))
+		for _, c := range msg.commits {
+			items = append(items, common.CommitItem{Commit: c})
+		}
+		m.Log.SetItems(items)
+		return m, nil
+
+	case planLoadedMsg:
+		if len(msg.commits) == 0 {
+			m.Message = "There are no commits after the selected base"
+			return m, nil
+		}
+		m.Steps = git.NewRebasePlan(msg.commits)
+		m.Cursor = 0
+		m.Phase = PhasePlan
+		m.Message = ""
+		return m, nil
+
+	case rebaseResultMsg:
+		m.State = msg.state
+		m.Conflicted = msg.conflicted
+		if msg.aborted || !msg.state.InProgress() {
+			m.Aborted = msg.aborted
+			m.Phase = PhaseDone
+		} else {
+			m.Phase = PhaseStopped
+		}
+		return m, nil
+
+	case errMsg:
+		m.Message = fmt.Sprintf("Error: %v", msg.err)
+		if m.Phase == PhaseRunning {
+			m.Phase = PhasePlan
+		}
+		return m, m.refreshIfStopped()
+
+	case tea.KeyMsg:
+		if m.Err != nil || msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+
+		switch m.Phase {
+		case PhaseBase:
+			return m.handleBaseKeys(msg)
+		case PhasePlan:
+			return m.handlePlanKeys(msg)
+		case PhaseReword:
+			return m.handleRewordKeys(msg)
+		case PhaseStopped:
+			return m.handleStoppedKeys(msg)
+		case PhaseDone:
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+// handleBaseKeys handles keys while choosing the base commit
+func (m *Model) handleBaseKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		return m, tea.Quit
+	case "up", "k":
+		m.Log.CursorUp()
+		return m, nil
+	case "down", "j":
+		m.Log.CursorDown()
+		return m, nil
+	case "enter":
+		if item, ok := m.Log.SelectedItem().(common.CommitItem); ok {
+			m.Base = item.Commit
+			return m, m.loadPlan(item.Commit.Hash)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.Log, cmd = m.Log.Update(msg)
+	return m, cmd
+}
+
+// handlePlanKeys handles keys in the todo editor
+func (m *Model) handlePlanKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+
+	if action, ok := actionKeys[key]; ok {
+		if action == git.RebaseReword {
+			step := m.Steps[m.Cursor]
+			message := step.Message
+			if message == "" {
+				message = step.Commit.Subject
+			}
+			m.Phase = PhaseReword
+			m.Input.SetValue(message)
+			m.Input.CursorEnd()
+			m.Input.Focus()
+			return m, textinput.Blink
+		}
+		m.Steps[m.Cursor].Action = action
+		return m, nil
+	}
+
+	switch key {
+	case "q":
+		return m, tea.Quit
+	case "esc":
+		m.Phase = PhaseBase
+		m.Steps = nil
+		return m, nil
+	case "up", "k":
+		if m.Cursor > 0 {
+			m.Cursor--
+		}
+	case "down", "j":
+		if m.Cursor < len(m.Steps)-1 {
+			m.Cursor++
+		}
+	case "K", "shift+up":
+		if m.Cursor > 0 {
+			m.Steps[m.Cursor], m.Steps[m.Cursor-1] = m.Steps[m.Cursor-1], m.Steps[m.Cursor]
+			m.Cursor--
+		}
+	case "J", "shift+down":
+		if m.Cursor < len(m.Steps)-1 {
+			m.Steps[m.Cursor], m.Steps[m.Cursor+1] = m.Steps[m.Cursor+1], m.Steps[m.Cursor]
+			m.Cursor++
+		}
+	case "enter":
+		if err := git.ValidateRebasePlan(m.Steps); err != nil {
+			m.Message = err.Error()
+			return m, nil
+		}
+		m.Phase = PhaseRunning
+		m.Message = ""
+		return m, m.runRebase()
+	}
+
+	return m, nil
+}
+
+// handleRewordKeys handles keys while editing a reword message
+func (m *Model) handleRewordKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.Phase = PhasePlan
+		m.Input.Blur()
+		return m, nil
+	case "enter":
+		if m.Input.Value() == "" {
+			return m, nil
+		}
+		m.Steps[m.Cursor].Action = git.RebaseReword
+		m.Steps[m.Cursor].Message = m.Input.Value()
+		m.Phase = PhasePlan
+		m.Input.Blur()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.Input, cmd = m.Input.Update(msg)
+	return m, cmd
+}
+
+// handleStoppedKeys handles keys while git waits on an edit step or a conflict
+func (m *Model) handleStoppedKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		// The rebase stays in progress and can be resumed later
+		return m, tea.Quit
+	case "r":
+		if m.Conflicted == 0 {
+			return m, nil
+		}
+		m.Conflict = conflict.NewWithService(m.GitService)
+		m.Conflict.Embedded = true
+		m.Phase = PhaseConflicts
+		cmds := []tea.Cmd{m.Conflict.Init()}
+		if m.Width > 0 {
+			_, cmd := m.Conflict.Update(tea.WindowSizeMsg{Width: m.Width, Height: m.Height})
+			cmds = append(cmds, cmd)
+		}
+		return m, tea.Batch(cmds...)
+	case "c":
+		m.Message = ""
+		return m, m.continueRebase()
+	case "a":
+		m.Message = ""
+		return m, m.abortRebase()
+	}
+	return m, nil
+}
+
+// loadLog fetches recent commits to choose a base from
+func (m *Model) loadLog() tea.Cmd {
+	service := m.GitService
+	return func() tea.Msg {
+		commits, err := service.Log(context.Background(), git.LogOptions{Limit: logLimit})
+		if err != nil {
+			return errMsg{err}
+		}
+		return logLoadedMsg{commits: commits}
+	}
+}
+
+// loadPlan fetches the commits that a rebase onto base would replay
+func (m *Model) loadPlan(base string) tea.Cmd {
+	service := m.GitService
+	return func() tea.Msg {
+		commits, err := service.CommitsBetween(context.Background(), base, "HEAD")
+		if err != nil {
+			return errMsg{err}
+		}
+		return planLoadedMsg{commits: commits}
+	}
+}
+
+// runRebase starts the rebase with the edited todo list
+func (m *Model) runRebase() tea.Cmd {
+	service := m.GitService
+	base := m.Base.Hash
+	steps := append([]git.RebaseStep{}, m.Steps...)
+	return func() tea.Msg {
+		state, err := service.StartRebase(context.Background(), base, steps)
+		if err != nil {
+			return errMsg{err}
+		}
+		return stateResult(service, state)
+	}
+}
+
+// continueRebase resumes a stopped rebase
+func (m *Model) continueRebase() tea.Cmd {
+	service := m.GitService
+	return func() tea.Msg {
+		if err := service.ContinueOperation(context.Background(), ""); err != nil {
+			return errMsg{err}
+		}
+		state, err := service.State(context.Background())
+		if err != nil {
+			return errMsg{err}
+		}
+		return stateResult(service, state)
+	}
+}
+
+// abortRebase abandons the rebase and restores the original branch
+func (m *Model) abortRebase() tea.Cmd {
+	service := m.GitService
+	return func() tea.Msg {
+		if err := service.AbortOperation(context.Background()); err != nil {
+			return errMsg{err}
+		}
+		return rebaseResultMsg{state: &git.RepoState{}, aborted: true}
+	}
+}
+
+// refreshState reloads the rebase state and the number of conflicted files
+func (m *Model) refreshState() tea.Cmd {
+	service := m.GitService
+	return func() tea.Msg {
+		state, err := service.State(context.Background())
+		if err != nil {
+			return errMsg{err}
+		}
+		return stateResult(service, state)
+	}
+}
+
+// refreshIfStopped reloads the state after a failed continue so conflicts are shown again
+func (m *Model) refreshIfStopped() tea.Cmd {
+	if m.Phase != PhaseStopped {
+		return nil
+	}
+	return m.refreshState()
+}
+
+// stateResult counts the conflicted files of a stopped rebase
+func stateResult(service *git.DefaultGitService, state *git.RepoState) tea.Msg {
+	conflicted := 0
+	if state.InProgress() {
+		if files, err := service.Status(context.Background()); err == nil {
+			conflicted = git.CountConflicted(files)
+		}
+	}
+	return rebaseResultMsg{state: state, conflicted: conflicted}
+}
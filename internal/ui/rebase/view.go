@@ -0,0 +1,147 @@
+package rebase
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui/common"
+)
+
+// View renders the current state of the model - implements tea.Model interface
+func (m *Model) View() string {
+	if m.Err != nil {
+		return m.errorView()
+	}
+
+	if !m.Ready {
+		return "Loading commits..."
+	}
+
+	if m.Phase == PhaseConflicts && m.Conflict != nil {
+		return m.Conflict.View()
+	}
+
+	title := m.StyleConfig.TitleStyle.Render("Go Git TUI - Interactive Rebase")
+
+	var body, help string
+	switch m.Phase {
+	case PhaseBase:
+		body = m.Log.View()
+		help = "j/k: Navigate • Enter: Rebase commits after this one • q: Quit"
+
+	case PhasePlan:
+		body = m.renderPlan()
+		help = "j/k: Navigate • J/K: Reorder • p/r/e/s/f/d: pick/reword/edit/squash/fixup/drop • Enter: Start • Esc: Back"
+
+	case PhaseReword:
+		step := m.Steps[m.Cursor]
+		body = lipgloss.JoinVertical(lipgloss.Left,
+			m.StyleConfig.InfoStyle.Render(fmt.Sprintf("New message for %s:", step.Commit.ShortHash)),
+			m.Input.View(),
+		)
+		help = "Enter: Save • Esc: Cancel"
+
+	case PhaseRunning:
+		body = m.StyleConfig.InfoStyle.Render("Rebasing...")
+
+	case PhaseStopped:
+		body = m.renderStopped()
+		help = "c: Continue • a: Abort • q: Quit (resume later with the rebase command)"
+		if m.Conflicted > 0 {
+			help = "r: Resolve conflicts • " + help
+		}
+
+	case PhaseDone:
+		if m.Aborted {
+			body = m.StyleConfig.InfoStyle.Render("Rebase aborted. The branch was restored.")
+		} else {
+			body = m.StyleConfig.AddedStyle.Render("Rebase completed successfully.")
+		}
+		help = "Press any key to exit"
+	}
+
+	message := ""
+	if m.Message != "" {
+		message = m.StyleConfig.DeletedStyle.Render(m.Message)
+	}
+
+	return m.StyleConfig.AppStyle.Render(lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		body,
+		message,
+		m.StyleConfig.HelpStyle.Render(help),
+	))
+}
+
+// renderPlan renders the todo list with the cursor and an action per commit
+func (m *Model) renderPlan() string {
+	var sb strings.Builder
+	sb.WriteString(m.StyleConfig.InfoStyle.Render(
+		fmt.Sprintf("Rebasing %d commits onto %s %s", len(m.Steps), m.Base.ShortHash, m.Base.Subject)) + "\n\n")
+
+	for i, step := range m.Steps {
+		cursor := "  "
+		if i == m.Cursor {
+			cursor = "▶ "
+		}
+
+		action := fmt.Sprintf("%-6s", step.Action)
+		switch step.Action {
+		case git.RebaseDrop:
+			action = m.StyleConfig.DeletedStyle.Render(action)
+		case git.RebaseSquash, git.RebaseFixup:
+			action = m.StyleConfig.InfoStyle.Render(action)
+		case git.RebaseReword, git.RebaseEdit:
+			action = m.StyleConfig.TitleStyle.Render(action)
+		default:
+			action = m.StyleConfig.AddedStyle.Render(action)
+		}
+
+		subject := step.Commit.Subject
+		if step.Action == git.RebaseReword && step.Message != "" {
+			subject = step.Message + m.StyleConfig.HelpStyle.Render(" (was: "+step.Commit.Subject+")")
+		}
+
+		sb.WriteString(fmt.Sprintf("%s%s %s %s\n", cursor, action, step.Commit.ShortHash, subject))
+	}
+
+	return sb.String()
+}
+
+// renderStopped describes why git stopped and what the user can do next
+func (m *Model) renderStopped() string {
+	lines := []string{m.StyleConfig.StatusBar.Render(m.State.Summary())}
+
+	if m.Conflicted > 0 {
+		lines = append(lines, m.StyleConfig.DeletedStyle.Render(
+			fmt.Sprintf("%d conflicted file(s). Resolve them, then continue.", m.Conflicted)))
+	} else {
+		lines = append(lines, m.StyleConfig.InfoStyle.Render(
+			"Stopped for editing. Amend the commit or stage fixes, then continue."))
+	}
+
+	if m.State.Message != "" {
+		lines = append(lines, "", m.StyleConfig.HelpStyle.Render("Current commit message:"), m.State.Message)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// errorView explains the failed git operation and how to fix it
+func (m *Model) errorView() string {
+	help := common.DescribeError(m.Err)
+
+	lines := []string{m.StyleConfig.DeletedStyle.Bold(true).Render(help.Message)}
+	if help.Hint != "" {
+		lines = append(lines, m.StyleConfig.InfoStyle.Render(help.Hint))
+	}
+	if help.Detail != "" {
+		lines = append(lines, "", m.StyleConfig.HelpStyle.Render(help.Detail))
+	}
+	lines = append(lines, "", m.StyleConfig.HelpStyle.Render("Any key: Exit"))
+
+	return m.StyleConfig.AppStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
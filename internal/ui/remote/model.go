@@ -0,0 +1,124 @@
+package remote
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui/common"
+)
+
+// RemoteItem represents a configured remote in the list
+type RemoteItem struct {
+	Remote git.Remote
+}
+
+// Title implements the list.Item interface
+func (i RemoteItem) Title() string { return i.Remote.Name }
+
+// Description implements the list.Item interface
+func (i RemoteItem) Description() string {
+	push := strings.Join(i.Remote.PushURLs, ", ")
+	return fmt.Sprintf("fetch: %s  push: %s", i.Remote.FetchURL, push)
+}
+
+// FilterValue implements the list.Item interface
+func (i RemoteItem) FilterValue() string { return i.Remote.Name }
+
+// Mode describes what the remote UI is currently waiting for
+type Mode int
+
+const (
+	// ModeBrowse is the default mode where remotes are listed
+	ModeBrowse Mode = iota
+	// ModePrompt collects text input for the pending action
+	ModePrompt
+	// ModeConfirm asks for confirmation before removing a remote
+	ModeConfirm
+)
+
+// Action identifies the remote operation being prepared
+type Action int
+
+const (
+	ActionNone Action = iota
+	ActionAddName
+	ActionAddURL
+	ActionRename
+	ActionSetURL
+	ActionSetPushURL
+	ActionRemove
+)
+
+// Custom message types
+type remotesLoadedMsg struct{ remotes []git.Remote }
+type actionDoneMsg struct {
+	message string
+	err     error // The action failed, the remotes may still have changed
+}
+type errMsg struct{ err error }
+
+// Model represents the remote management UI state
+type Model struct {
+	List  list.Model
+	Input textinput.Model
+
+	Mode    Mode
+	Action  Action
+	Target  string // Remote the pending action applies to
+	NewName string // Name collected in the first step of adding a remote
+	Message string
+	Err     error
+	Width   int
+	Height  int
+	Ready   bool
+
+	GitService  *git.DefaultGitService
+	StyleConfig common.StyleConfig
+}
+
+// New initializes a new remote model
+func New() *Model {
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
+		Foreground(lipgloss.Color("170")).
+		Margin(0, 0)
+	delegate.SetSpacing(0)
+
+	l := list.New([]list.Item{}, delegate, 0, 0)
+	l.Title = "Remotes"
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.SetShowHelp(false)
+
+	ti := textinput.New()
+	ti.CharLimit = 256
+	ti.Width = 60
+	ti.PromptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("63"))
+
+	m := &Model{
+		List:        l,
+		Input:       ti,
+		Mode:        ModeBrowse,
+		StyleConfig: common.NewStyleConfig(),
+	}
+
+	gitService, err := git.NewGitService()
+	if err != nil {
+		m.Err = err
+		return m
+	}
+	m.GitService = gitService
+
+	return m
+}
+
+// Init loads the remotes - implements tea.Model interface
+func (m *Model) Init() tea.Cmd {
+	return m.loadRemotes()
+}
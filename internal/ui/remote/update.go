@@ -0,0 +1,232 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Update handles events and updates the model - implements tea.Model interface
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.Width, m.Height = msg.Width, msg.Height
+		m.Ready = true
+		h, v := m.StyleConfig.AppStyle.GetFrameSize()
+		m.List.SetSize(msg.Width-h, msg.Height-v-6) // Reserve space for prompt, message and help
+		m.Input.Width = msg.Width - h - 4
+		return m, nil
+
+	case remotesLoadedMsg:
+		items := make([]list.Item, 0, len(msg.remotes))
+		for _, r := range msg.remotes {
+			items = append(items, RemoteItem{Remote: r})
+		}
+		m.List.SetItems(items)
+		return m, nil
+
+	case actionDoneMsg:
+		m.Message = msg.message
+		if msg.err != nil {
+			m.Message = fmt.Sprintf("Error: %v", msg.err)
+		}
+		return m, m.loadRemotes()
+
+	case errMsg:
+		// Loading failed, loading again would only fail again
+		m.Message = fmt.Sprintf("Error: %v", msg.err)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.Mode {
+		case ModePrompt:
+			return m.handlePromptKeys(msg)
+		case ModeConfirm:
+			return m.handleConfirmKeys(msg)
+		default:
+			return m.handleBrowseKeys(msg)
+		}
+	}
+
+	return m, nil
+}
+
+// handleBrowseKeys handles keys while the remote list is focused
+func (m *Model) handleBrowseKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.Err != nil {
+		return m, tea.Quit
+	}
+
+	selected, hasSelection := m.List.SelectedItem().(RemoteItem)
+
+	switch msg.String() {
+	case "q", "ctrl+c", "esc":
+		return m, tea.Quit
+
+	case "a":
+		return m, m.startPrompt(ActionAddName, "", "Remote name", "")
+
+	case "r":
+		if hasSelection {
+			return m, m.startPrompt(ActionRename, selected.Remote.Name, "New name", selected.Remote.Name)
+		}
+
+	case "u":
+		if hasSelection {
+			return m, m.startPrompt(ActionSetURL, selected.Remote.Name, "Fetch URL", selected.Remote.FetchURL)
+		}
+
+	case "p":
+		if hasSelection {
+			current := ""
+			if len(selected.Remote.PushURLs) > 0 {
+				current = selected.Remote.PushURLs[0]
+			}
+			return m, m.startPrompt(ActionSetPushURL, selected.Remote.Name, "Push URL", current)
+		}
+
+	case "d", "x":
+		if hasSelection {
+			m.Mode = ModeConfirm
+			m.Action = ActionRemove
+			m.Target = selected.Remote.Name
+		}
+		return m, nil
+
+	case "w":
+		m.List.CursorUp()
+		return m, nil
+
+	case "s":
+		m.List.CursorDown()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.List, cmd = m.List.Update(msg)
+	return m, cmd
+}
+
+// handlePromptKeys handles keys while text input is focused
+func (m *Model) handlePromptKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "esc":
+		m.resetMode()
+		return m, nil
+
+	case "enter":
+		value := strings.TrimSpace(m.Input.Value())
+		if value == "" {
+			return m, nil
+		}
+
+		if m.Action == ActionAddName {
+			m.NewName = value
+			return m, m.startPrompt(ActionAddURL, "", "URL for "+value, "")
+		}
+
+		cmd := m.runAction(m.Action, m.Target, value)
+		m.resetMode()
+		return m, cmd
+	}
+
+	var cmd tea.Cmd
+	m.Input, cmd = m.Input.Update(msg)
+	return m, cmd
+}
+
+// handleConfirmKeys handles the yes/no prompt before removing a remote
+func (m *Model) handleConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		cmd := m.runAction(ActionRemove, m.Target, "")
+		m.resetMode()
+		return m, cmd
+	case "ctrl+c":
+		return m, tea.Quit
+	default:
+		m.resetMode()
+		return m, nil
+	}
+}
+
+// startPrompt switches to prompt mode for the given action
+func (m *Model) startPrompt(action Action, target, placeholder, value string) tea.Cmd {
+	m.Mode = ModePrompt
+	m.Action = action
+	m.Target = target
+	m.Message = ""
+	m.Input.Placeholder = placeholder
+	m.Input.SetValue(value)
+	m.Input.CursorEnd()
+	m.Input.Focus()
+	return textinput.Blink
+}
+
+// resetMode returns to browsing the remote list
+func (m *Model) resetMode() {
+	m.Mode = ModeBrowse
+	m.Action = ActionNone
+	m.Target = ""
+	m.Input.Blur()
+	m.Input.SetValue("")
+}
+
+// runAction performs the remote operation asynchronously
+func (m *Model) runAction(action Action, target, value string) tea.Cmd {
+	service := m.GitService
+	newName := m.NewName
+	if service == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		var err error
+		var done string
+
+		switch action {
+		case ActionAddURL:
+			err = service.AddRemote(context.Background(), newName, value)
+			done = fmt.Sprintf("Added remote %s", newName)
+		case ActionRename:
+			err = service.RenameRemote(context.Background(), target, value)
+			done = fmt.Sprintf("Renamed remote %s to %s", target, value)
+		case ActionSetURL:
+			err = service.SetRemoteURL(context.Background(), target, value, false)
+			done = fmt.Sprintf("Updated fetch URL of %s", target)
+		case ActionSetPushURL:
+			err = service.SetRemoteURL(context.Background(), target, value, true)
+			done = fmt.Sprintf("Updated push URL of %s", target)
+		case ActionRemove:
+			err = service.RemoveRemote(context.Background(), target)
+			done = fmt.Sprintf("Removed remote %s", target)
+		default:
+			return nil
+		}
+
+		return actionDoneMsg{message: done, err: err}
+	}
+}
+
+// loadRemotes fetches the configured remotes
+func (m *Model) loadRemotes() tea.Cmd {
+	service := m.GitService
+	if service == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		remotes, err := service.Remotes(context.Background())
+		if err != nil {
+			return errMsg{err}
+		}
+		return remotesLoadedMsg{remotes: remotes}
+	}
+}
@@ -0,0 +1,50 @@
+package remote
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// View renders the current state of the model - implements tea.Model interface
+func (m *Model) View() string {
+	if m.Err != nil {
+		return m.StyleConfig.DeletedStyle.Render(fmt.Sprintf("Error: %v\nPress any key to exit", m.Err))
+	}
+
+	if !m.Ready {
+		return "Loading remotes..."
+	}
+
+	var body string
+	if len(m.List.Items()) == 0 {
+		body = m.StyleConfig.InfoStyle.Render("No remotes configured. Press 'a' to add one.")
+	} else {
+		body = m.List.View()
+	}
+
+	var prompt string
+	switch m.Mode {
+	case ModePrompt:
+		prompt = m.StyleConfig.TitleStyle.Render(m.Input.Placeholder+":") + "\n" + m.Input.View()
+	case ModeConfirm:
+		prompt = m.StyleConfig.DeletedStyle.Render(fmt.Sprintf("Remove remote %s? (y/N)", m.Target))
+	}
+
+	message := ""
+	if m.Message != "" {
+		message = m.StyleConfig.InfoStyle.Render(m.Message)
+	}
+
+	var help string
+	if m.Mode == ModePrompt {
+		help = m.StyleConfig.HelpStyle.Render("Enter: Confirm • Esc: Cancel")
+	} else {
+		help = m.StyleConfig.HelpStyle.Render(
+			"w/s: Navigate • a: Add • r: Rename • u: Fetch URL • p: Push URL • d: Remove • q: Quit")
+	}
+
+	return m.StyleConfig.AppStyle.Render(
+		lipgloss.JoinVertical(lipgloss.Left, body, prompt, message, help),
+	)
+}
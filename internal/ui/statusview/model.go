@@ -0,0 +1,88 @@
+package statusview
+
+import (
+	"context"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui/common"
+)
+
+// Custom message types
+type statusLoadedMsg struct {
+	branch *git.BranchStatus
+	state  *git.RepoState
+	files  []git.GitFile
+}
+type errMsg struct{ err error }
+
+// Model represents the status UI state
+type Model struct {
+	Branch *git.BranchStatus
+	State  *git.RepoState // Operation in progress, nil when there is none
+	Files  []git.GitFile
+	Body   viewport.Model
+
+	Loading  bool
+	Message  string
+	Err      error
+	Width    int
+	Height   int
+	Ready    bool
+	Quitting bool
+
+	GitService  *git.DefaultGitService
+	StyleConfig common.StyleConfig
+}
+
+// New initializes a status model for the repository in the working directory
+func New() *Model {
+	gitService, err := git.NewGitService()
+	m := NewWithService(gitService)
+	if err != nil {
+		m.Err = err
+	}
+	return m
+}
+
+// NewWithService initializes a status model on an existing git service
+func NewWithService(gitService *git.DefaultGitService) *Model {
+	return &Model{
+		Body:        viewport.New(0, 0),
+		Loading:     true,
+		GitService:  gitService,
+		StyleConfig: common.NewStyleConfig(),
+	}
+}
+
+// Init loads the status - implements tea.Model interface
+func (m *Model) Init() tea.Cmd {
+	return m.Refresh()
+}
+
+// Refresh reads the branch, the operation in progress and the changed files again
+func (m *Model) Refresh() tea.Cmd {
+	if m.GitService == nil {
+		return nil
+	}
+
+	service := m.GitService
+	return func() tea.Msg {
+		ctx := context.Background()
+		branch, err := service.BranchStatus(ctx)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		msg := statusLoadedMsg{branch: branch}
+		if state, err := service.State(ctx); err == nil && state.InProgress() {
+			msg.state = state
+		}
+		if msg.files, err = service.Status(ctx); err != nil {
+			return errMsg{err}
+		}
+		return msg
+	}
+}
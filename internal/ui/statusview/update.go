@@ -0,0 +1,137 @@
+package statusview
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+)
+
+// stateWidth is the width of the index and worktree columns, which fits "typechange"
+const stateWidth = 12
+
+// Update handles events and updates the model - implements tea.Model interface
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.Width, m.Height = msg.Width, msg.Height
+		m.Ready = true
+		h, v := m.StyleConfig.AppStyle.GetFrameSize()
+		m.Body.Width = msg.Width - h
+		m.Body.Height = max(msg.Height-v-3, 0) // Reserve space for title, message and help
+		m.render()
+		return m, nil
+
+	case statusLoadedMsg:
+		m.Loading = false
+		m.Message = ""
+		m.Branch, m.State, m.Files = msg.branch, msg.state, msg.files
+		m.render()
+		return m, nil
+
+	case errMsg:
+		m.Loading = false
+		m.Message = fmt.Sprintf("Error: %v", msg.err)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.Err != nil {
+			m.Quitting = true
+			return m, tea.Quit
+		}
+
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			m.Quitting = true
+			return m, tea.Quit
+		case "r":
+			m.Message = "Refreshing..."
+			return m, m.Refresh()
+		case "w", "k", "up":
+			m.Body.LineUp(1)
+		case "s", "j", "down":
+			m.Body.LineDown(1)
+		case "pgup":
+			m.Body.HalfViewUp()
+		case "pgdown":
+			m.Body.HalfViewDown()
+		case "g":
+			m.Body.GotoTop()
+		case "G":
+			m.Body.GotoBottom()
+		}
+	}
+
+	return m, nil
+}
+
+// render lays out the branch, the operation in progress and the changed files in the body
+func (m *Model) render() {
+	if m.Branch == nil {
+		return
+	}
+
+	var lines []string
+	switch {
+	case m.Branch.Detached:
+		lines = append(lines, fmt.Sprintf("HEAD detached at %s", shortHash(m.Branch.Head)))
+	case m.Branch.Head == "":
+		lines = append(lines, fmt.Sprintf("On branch %s, no commits yet", m.Branch.Branch))
+	default:
+		lines = append(lines, fmt.Sprintf("On branch %s", m.Branch.Branch))
+	}
+	if m.Branch.Upstream != "" {
+		state := m.Branch.Divergence()
+		if state == "" {
+			state = "up to date"
+		}
+		lines = append(lines, fmt.Sprintf("Tracking %s (%s)", m.Branch.Upstream, state))
+	}
+	if m.State.InProgress() {
+		lines = append(lines, m.StyleConfig.StatusBar.Render(m.State.Summary()))
+	}
+	lines = append(lines, "")
+
+	if len(m.Files) == 0 {
+		lines = append(lines, m.StyleConfig.InfoStyle.Render("Nothing to commit, working tree clean"))
+	} else {
+		lines = append(lines, m.StyleConfig.HelpStyle.Render(
+			fmt.Sprintf("%-*s%-*s%s", stateWidth, "INDEX", stateWidth, "WORKTREE", "PATH")))
+		for _, f := range m.Files {
+			lines = append(lines, m.renderFile(f))
+		}
+	}
+
+	m.Body.SetContent(strings.Join(lines, "\n"))
+}
+
+// renderFile shows the index state in green and the worktree state in red, like git status
+func (m *Model) renderFile(f git.GitFile) string {
+	index, worktree := f.IndexState(), f.WorktreeState()
+	if git.IsConflicted(f.Status) {
+		conflict := m.StyleConfig.DeletedStyle.Bold(true)
+		return conflict.Render(fmt.Sprintf("%-*s%-*s", stateWidth, index, stateWidth, worktree)) + f.Path
+	}
+
+	return column(index, stateWidth, m.StyleConfig.AddedStyle.Render) +
+		column(worktree, stateWidth, m.StyleConfig.DeletedStyle.Render) +
+		f.Path
+}
+
+// column pads state to width, styling it unless the file is unmodified on that side
+func column(state string, width int, style func(...string) string) string {
+	if state == "unmodified" {
+		return "-" + strings.Repeat(" ", max(width-1, 0))
+	}
+	return style(state) + strings.Repeat(" ", max(width-len(state), 0))
+}
+
+// shortHash abbreviates a commit hash
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
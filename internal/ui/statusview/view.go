@@ -0,0 +1,34 @@
+package statusview
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// View renders the current state of the model - implements tea.Model interface
+func (m *Model) View() string {
+	if m.Err != nil {
+		return m.StyleConfig.DeletedStyle.Render(fmt.Sprintf("Error: %v\nPress any key to exit", m.Err))
+	}
+
+	if m.Quitting {
+		return ""
+	}
+
+	if !m.Ready || m.Loading {
+		return "Loading status..."
+	}
+
+	message := ""
+	if m.Message != "" {
+		message = m.StyleConfig.InfoStyle.Render(m.Message)
+	}
+
+	return m.StyleConfig.AppStyle.Render(lipgloss.JoinVertical(lipgloss.Left,
+		m.StyleConfig.TitleStyle.Render("Go Git TUI - Status"),
+		m.Body.View(),
+		message,
+		m.StyleConfig.HelpStyle.Render("w/s: Scroll • r: Refresh • q: Quit"),
+	))
+}
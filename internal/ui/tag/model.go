@@ -0,0 +1,90 @@
+package tag
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/LaansDole/go-git-tui/internal/git"
+	"github.com/LaansDole/go-git-tui/internal/ui/common"
+)
+
+// TagItem represents a tag in the list
+type TagItem struct {
+	Tag git.Tag
+}
+
+// Title implements the list.Item interface
+func (i TagItem) Title() string { return i.Tag.Name }
+
+// Description implements the list.Item interface
+func (i TagItem) Description() string {
+	short := i.Tag.Hash
+	if len(short) > 7 {
+		short = short[:7]
+	}
+	if !i.Tag.Annotated {
+		return fmt.Sprintf("%s  lightweight", short)
+	}
+	return fmt.Sprintf("%s  %s, %s  %s", short, i.Tag.Tagger, i.Tag.When.Format("2006-01-02"), i.Tag.Message)
+}
+
+// FilterValue implements the list.Item interface
+func (i TagItem) FilterValue() string { return i.Tag.Name }
+
+// Custom message types
+type tagsLoadedMsg struct{ tags []git.Tag }
+type tagDeletedMsg struct{ name string }
+type errMsg struct{ err error }
+
+// Model represents the tag management UI state
+type Model struct {
+	List list.Model
+
+	Confirm bool   // Waiting for confirmation before deleting Target
+	Target  string // Tag the pending deletion applies to
+	Message string
+	Err     error
+	Width   int
+	Height  int
+	Ready   bool
+
+	GitService  *git.DefaultGitService
+	StyleConfig common.StyleConfig
+}
+
+// New initializes a new tag model
+func New() *Model {
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
+		Foreground(lipgloss.Color("170")).
+		Margin(0, 0)
+	delegate.SetSpacing(0)
+
+	l := list.New([]list.Item{}, delegate, 0, 0)
+	l.Title = "Tags"
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.SetShowHelp(false)
+
+	m := &Model{
+		List:        l,
+		StyleConfig: common.NewStyleConfig(),
+	}
+
+	gitService, err := git.NewGitService()
+	if err != nil {
+		m.Err = err
+		return m
+	}
+	m.GitService = gitService
+
+	return m
+}
+
+// Init loads the tags - implements tea.Model interface
+func (m *Model) Init() tea.Cmd {
+	return m.loadTags()
+}
@@ -0,0 +1,124 @@
+package tag
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Update handles events and updates the model - implements tea.Model interface
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.Width, m.Height = msg.Width, msg.Height
+		m.Ready = true
+		h, v := m.StyleConfig.AppStyle.GetFrameSize()
+		m.List.SetSize(msg.Width-h, msg.Height-v-4) // Reserve space for prompt, message and help
+		return m, nil
+
+	case tagsLoadedMsg:
+		items := make([]list.Item, 0, len(msg.tags))
+		for _, t := range msg.tags {
+			items = append(items, TagItem{Tag: t})
+		}
+		m.List.SetItems(items)
+		return m, nil
+
+	case tagDeletedMsg:
+		m.Message = fmt.Sprintf("Deleted tag %s", msg.name)
+		return m, m.loadTags()
+
+	case errMsg:
+		m.Message = fmt.Sprintf("Error: %v", msg.err)
+		return m, m.loadTags()
+
+	case tea.KeyMsg:
+		if m.Confirm {
+			return m.handleConfirmKeys(msg)
+		}
+		return m.handleBrowseKeys(msg)
+	}
+
+	return m, nil
+}
+
+// handleBrowseKeys handles keys while the tag list is focused
+func (m *Model) handleBrowseKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.Err != nil {
+		return m, tea.Quit
+	}
+
+	switch msg.String() {
+	case "q", "ctrl+c", "esc":
+		return m, tea.Quit
+
+	case "d", "x":
+		if selected, ok := m.List.SelectedItem().(TagItem); ok {
+			m.Confirm = true
+			m.Target = selected.Tag.Name
+			m.Message = ""
+		}
+		return m, nil
+
+	case "w":
+		m.List.CursorUp()
+		return m, nil
+
+	case "s":
+		m.List.CursorDown()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.List, cmd = m.List.Update(msg)
+	return m, cmd
+}
+
+// handleConfirmKeys handles the yes/no prompt before deleting a tag
+func (m *Model) handleConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	target := m.Target
+	m.Confirm = false
+	m.Target = ""
+
+	switch msg.String() {
+	case "y", "Y":
+		return m, m.deleteTag(target)
+	case "ctrl+c":
+		return m, tea.Quit
+	default:
+		return m, nil
+	}
+}
+
+// deleteTag deletes a tag asynchronously
+func (m *Model) deleteTag(name string) tea.Cmd {
+	service := m.GitService
+	if service == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		if err := service.DeleteTag(context.Background(), name); err != nil {
+			return errMsg{err}
+		}
+		return tagDeletedMsg{name: name}
+	}
+}
+
+// loadTags fetches the repository's tags
+func (m *Model) loadTags() tea.Cmd {
+	service := m.GitService
+	if service == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		tags, err := service.Tags(context.Background())
+		if err != nil {
+			return errMsg{err}
+		}
+		return tagsLoadedMsg{tags: tags}
+	}
+}
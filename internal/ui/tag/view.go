@@ -0,0 +1,41 @@
+package tag
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// View renders the current state of the model - implements tea.Model interface
+func (m *Model) View() string {
+	if m.Err != nil {
+		return m.StyleConfig.DeletedStyle.Render(fmt.Sprintf("Error: %v\nPress any key to exit", m.Err))
+	}
+
+	if !m.Ready {
+		return "Loading tags..."
+	}
+
+	var body string
+	if len(m.List.Items()) == 0 {
+		body = m.StyleConfig.InfoStyle.Render("No tags yet. Run 'go-git-tui release' to tag a release.")
+	} else {
+		body = m.List.View()
+	}
+
+	prompt := ""
+	if m.Confirm {
+		prompt = m.StyleConfig.DeletedStyle.Render(fmt.Sprintf("Delete tag %s? (y/N)", m.Target))
+	}
+
+	message := ""
+	if m.Message != "" {
+		message = m.StyleConfig.InfoStyle.Render(m.Message)
+	}
+
+	help := m.StyleConfig.HelpStyle.Render("w/s: Navigate • d: Delete • q: Quit")
+
+	return m.StyleConfig.AppStyle.Render(
+		lipgloss.JoinVertical(lipgloss.Left, body, prompt, message, help),
+	)
+}
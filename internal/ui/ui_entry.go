@@ -1,16 +1,67 @@
 package ui
 
 import (
+	"github.com/LaansDole/go-git-tui/internal/git"
 	"github.com/LaansDole/go-git-tui/internal/ui/add"
+	"github.com/LaansDole/go-git-tui/internal/ui/blame"
 	"github.com/LaansDole/go-git-tui/internal/ui/commit"
+	"github.com/LaansDole/go-git-tui/internal/ui/conflict"
+	"github.com/LaansDole/go-git-tui/internal/ui/dashboard"
+	"github.com/LaansDole/go-git-tui/internal/ui/history"
+	"github.com/LaansDole/go-git-tui/internal/ui/logview"
+	"github.com/LaansDole/go-git-tui/internal/ui/rebase"
+	"github.com/LaansDole/go-git-tui/internal/ui/remote"
+	"github.com/LaansDole/go-git-tui/internal/ui/tag"
 )
 
-// StartAddTUI runs the add UI application with terminal UI
-func StartAddTUI() error {
-	return add.Run()
+// StartDashboardTUI runs the dashboard hosting the status, add, commit, log and branch
+// views as tabs with terminal UI
+func StartDashboardTUI() error {
+	return dashboard.Run()
 }
 
-// StartCommitTUI runs the commit UI application with terminal UI
-func StartCommitTUI() error {
-	return commit.Run()
+// StartAddTUI runs the add UI application with terminal UI, listing the changes matched by spec.
+// With stayOpen, staging refreshes the list instead of leaving the program.
+func StartAddTUI(spec *git.Pathspec, stayOpen bool) error {
+	return add.Run(spec, stayOpen)
+}
+
+// StartCommitTUI runs the commit UI application with terminal UI, pre-filled with preset
+func StartCommitTUI(preset git.CommitMessage) error {
+	return commit.Run(preset)
+}
+
+// StartRemoteTUI runs the remote management UI application with terminal UI
+func StartRemoteTUI() error {
+	return remote.Run()
+}
+
+// StartConflictTUI runs the merge conflict resolution UI application with terminal UI
+func StartConflictTUI() error {
+	return conflict.Run()
+}
+
+// StartRebaseTUI runs the interactive rebase UI application with terminal UI
+func StartRebaseTUI() error {
+	return rebase.Run()
+}
+
+// StartBlameTUI runs the blame UI application for a file with terminal UI
+func StartBlameTUI(path string) error {
+	return blame.Run(path)
+}
+
+// StartHistoryTUI runs the file history UI application for a file with terminal UI
+func StartHistoryTUI(path string) error {
+	return history.Run(path)
+}
+
+// StartLogTUI runs the commit log UI application with terminal UI
+func StartLogTUI(rev string) error {
+	return logview.Run(rev)
+}
+
+// StartTagTUI runs the tag management UI application with terminal UI
+func StartTagTUI() error {
+	return tag.Run()
 }